@@ -0,0 +1,465 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/api"
+	"github.com/the20100/trello-cli/internal/output"
+)
+
+// maxActionsPage is the largest page the actions endpoints return.
+const maxActionsPage = 1000
+
+var (
+	activityBoard   string
+	activityModel   string
+	activityTypes   []string
+	activitySince   string
+	activityBefore  string
+	activityMembers []string
+	activityLimit   int
+	activityAll     bool
+
+	activityFollow     bool
+	activityInterval   time.Duration
+	activityResume     bool
+	activityCheckpoint string
+	activityFormat     string
+)
+
+var activityCmd = &cobra.Command{
+	Use:   "activity <board|list|card|member>",
+	Short: "Show the activity feed of a board, list, card, or member",
+	Long: `Show recent actions on a board, list, card, or member as readable sentences,
+newest first ("Alice moved 'Fix login' from Doing to Done").
+
+The reference is resolved like "trello open": an ID, short link, URL, board
+name, or list/card name or "#<number>" with --board. Members are given as
+"me", "@username", or with --model member.
+
+--type filters by action type and accepts Trello's field-specific forms, e.g.
+updateCard:idList (card moves), updateCard:closed, commentCard, createCard.
+
+Results are fetched page by page (up to 1000 actions per request) until
+--limit actions are collected, or everything with --all.
+
+--follow keeps running and prints new actions as they happen, oldest first.
+The feed is polled every --interval; the delay grows while nothing happens
+and when the API rate limit runs low, and resets on new activity. Press
+Ctrl-C to stop. With --resume, the ID of the last printed action is saved
+(to --checkpoint, or a file in the config directory) and the next run
+continues from there, so nothing is missed or printed twice.
+
+--format selects the output: "table", "ndjson" (one JSON action per line),
+or a Go template executed per action, with the functions describe, member,
+time, and json, e.g. '{{time .Date}} {{describe .}}'. Without --format,
+piped output and --json print a JSON array, or NDJSON with --follow.
+
+Examples:
+  trello activity "My Project"
+  trello activity abc123 --type commentCard,createCard
+  trello activity "My Project" --type updateCard:idList --since 2024-06-01
+  trello activity "#42" --board "My Project"
+  trello activity @alice --limit 20
+  trello activity "My Project" --member alice --before 2024-07-01 --all --json
+  trello activity "My Project" --follow
+  trello activity "My Project" --follow --resume --json | ./handle-events
+  trello activity "My Project" --follow --format '{{.Type}} {{member .}}: {{describe .}}'`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		model, id, err := resolveActivityModel(args[0], activityModel, activityBoard)
+		if err != nil {
+			return err
+		}
+
+		params, err := activityParams(activityTypes, activitySince, activityBefore)
+		if err != nil {
+			return err
+		}
+		if activityResume && !activityFollow {
+			return fmt.Errorf("--resume requires --follow")
+		}
+
+		var memberIDs []string
+		if len(activityMembers) > 0 {
+			if memberIDs, err = resolveMemberIDs(activityMembers); err != nil {
+				return err
+			}
+		}
+
+		if activityFollow {
+			if activityInterval <= 0 {
+				return fmt.Errorf("--interval must be positive")
+			}
+			p, err := newActionPrinter(activityFormat, output.IsJSON(cmd))
+			if err != nil {
+				return err
+			}
+			return followActivity(model, id, params, memberIDs, p)
+		}
+
+		limit := activityLimit
+		if activityAll {
+			limit = 0
+		}
+		var keep func(api.Action) bool
+		if len(memberIDs) > 0 {
+			keep = func(a api.Action) bool { return containsString(memberIDs, a.IDMemberCreator) }
+		}
+		actions, err := fetchMatchingActions(model, id, params, limit, keep)
+		if err != nil {
+			return err
+		}
+
+		if activityFormat != "" && activityFormat != "table" {
+			p, err := newActionPrinter(activityFormat, false)
+			if err != nil {
+				return err
+			}
+			for _, a := range actions {
+				if err := p.print(a); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(actions, output.IsPretty(cmd))
+		}
+		printActionsTable(actions)
+		return nil
+	},
+}
+
+// resolveActivityModel maps a reference to the actions collection and object ID.
+func resolveActivityModel(ref, kind, board string) (string, string, error) {
+	if kind == "member" || ref == "me" || strings.HasPrefix(ref, "@") {
+		m, err := client.GetMember(strings.TrimPrefix(ref, "@"), url.Values{"fields": {"id"}})
+		if err != nil {
+			return "", "", err
+		}
+		return "members", m.ID, nil
+	}
+	r, err := resolveRef(ref, kind, board)
+	if err != nil {
+		return "", "", err
+	}
+	return r.Kind + "s", r.ID, nil
+}
+
+// activityParams builds the query params shared by activity listings.
+func activityParams(types []string, since, before string) (url.Values, error) {
+	params := url.Values{}
+	if len(types) > 0 {
+		params.Set("filter", strings.Join(types, ","))
+	}
+	for _, f := range []struct{ name, value string }{{"since", since}, {"before", before}} {
+		if f.value == "" {
+			continue
+		}
+		v, err := actionBound(f.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --%s: %w", f.name, err)
+		}
+		params.Set(f.name, v)
+	}
+	return params, nil
+}
+
+// actionBound converts a --since/--before value to what the API accepts:
+// an action ID is passed through, dates are normalised to ISO-8601 UTC.
+func actionBound(v string) (string, error) {
+	if isTrelloID(v) {
+		return v, nil
+	}
+	t, err := parseDateFlag(v)
+	if err != nil {
+		return "", err
+	}
+	return t.UTC().Format(time.RFC3339), nil
+}
+
+// fetchActions pages through a model's actions, newest first, until limit
+// actions are collected (0 = all).
+func fetchActions(model, id string, params url.Values, limit int) ([]api.Action, error) {
+	return fetchMatchingActions(model, id, params, limit, nil)
+}
+
+// fetchMatchingActions is fetchActions for the actions keep accepts: it
+// keeps paging until limit of them are collected or the feed ends.
+func fetchMatchingActions(model, id string, params url.Values, limit int, keep func(api.Action) bool) ([]api.Action, error) {
+	p := url.Values{}
+	for k, vs := range params {
+		p[k] = append([]string(nil), vs...)
+	}
+
+	var all []api.Action
+	for {
+		page := maxActionsPage
+		if keep == nil && limit > 0 && limit-len(all) < page {
+			page = limit - len(all)
+		}
+		p.Set("limit", fmt.Sprintf("%d", page))
+		actions, err := client.GetActions(model, id, p)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range actions {
+			if keep != nil && !keep(a) {
+				continue
+			}
+			all = append(all, a)
+			if limit > 0 && len(all) >= limit {
+				return all, nil
+			}
+		}
+		if len(actions) < page {
+			return all, nil
+		}
+		p.Set("before", actions[len(actions)-1].ID)
+	}
+}
+
+// printActionsTable renders actions as a DATE / MEMBER / ACTIVITY table.
+func printActionsTable(actions []api.Action) {
+	if len(actions) == 0 {
+		fmt.Println("No activity found.")
+		return
+	}
+	headers := []string{"DATE", "MEMBER", "ACTIVITY"}
+	rows := make([][]string, len(actions))
+	for i, a := range actions {
+		rows[i] = []string{
+			output.FormatTime(a.Date),
+			output.Truncate(actionMemberName(a), 20),
+			output.Truncate(describeAction(a), 100),
+		}
+	}
+	output.PrintTable(headers, rows)
+}
+
+// actionMemberName returns the display name of the member who performed a.
+func actionMemberName(a api.Action) string {
+	if m := a.MemberCreator; m != nil {
+		if m.FullName != "" {
+			return m.FullName
+		}
+		if m.Username != "" {
+			return m.Username
+		}
+	}
+	if a.IDMemberCreator != "" {
+		return a.IDMemberCreator
+	}
+	return "someone"
+}
+
+// describeAction renders an action as a human sentence, e.g.
+// "Alice moved 'Fix login' from Doing to Done".
+func describeAction(a api.Action) string {
+	who := actionMemberName(a)
+	d := a.Data
+	card, list, board := "a card", "a list", "the board"
+	if d.Card != nil {
+		card = quoteName(d.Card.Name)
+	}
+	if d.List != nil {
+		list = d.List.Name
+	}
+	if d.Board != nil {
+		board = d.Board.Name
+	}
+
+	switch a.Type {
+	case "createCard":
+		return fmt.Sprintf("%s created %s in %s", who, card, list)
+	case "copyCard":
+		return fmt.Sprintf("%s copied %s into %s", who, card, list)
+	case "deleteCard":
+		if d.Card != nil && d.Card.IDShort > 0 {
+			return fmt.Sprintf("%s deleted card #%d from %s", who, d.Card.IDShort, list)
+		}
+		return fmt.Sprintf("%s deleted a card from %s", who, list)
+	case "commentCard":
+		return fmt.Sprintf("%s commented on %s: %s", who, card, oneLine(d.Text, 60))
+	case "updateComment":
+		return fmt.Sprintf("%s edited a comment on %s", who, card)
+	case "deleteComment":
+		return fmt.Sprintf("%s deleted a comment on %s", who, card)
+	case "updateCard":
+		return describeCardUpdate(who, card, a)
+	case "moveCardToBoard":
+		from := "another board"
+		if d.BoardSource != nil {
+			from = d.BoardSource.Name
+		}
+		return fmt.Sprintf("%s moved %s to %s from %s", who, card, board, from)
+	case "moveCardFromBoard":
+		to := "another board"
+		if d.BoardTarget != nil {
+			to = d.BoardTarget.Name
+		}
+		return fmt.Sprintf("%s moved %s from %s to %s", who, card, board, to)
+	case "addMemberToCard", "removeMemberFromCard":
+		name := "a member"
+		if d.Member != nil {
+			name = d.Member.Name
+		}
+		if a.Type == "addMemberToCard" {
+			return fmt.Sprintf("%s added %s to %s", who, name, card)
+		}
+		return fmt.Sprintf("%s removed %s from %s", who, name, card)
+	case "addLabelToCard", "removeLabelFromCard":
+		name := "a label"
+		if d.Label != nil {
+			name = "label " + quoteName(labelName(d.Label.Name, d.Label.Color))
+		}
+		if a.Type == "addLabelToCard" {
+			return fmt.Sprintf("%s added %s to %s", who, name, card)
+		}
+		return fmt.Sprintf("%s removed %s from %s", who, name, card)
+	case "addChecklistToCard", "removeChecklistFromCard":
+		name := "a checklist"
+		if d.Checklist != nil {
+			name = "checklist " + quoteName(d.Checklist.Name)
+		}
+		if a.Type == "addChecklistToCard" {
+			return fmt.Sprintf("%s added %s to %s", who, name, card)
+		}
+		return fmt.Sprintf("%s removed %s from %s", who, name, card)
+	case "updateCheckItemStateOnCard":
+		if d.CheckItem != nil {
+			if d.CheckItem.State == "complete" {
+				return fmt.Sprintf("%s completed %s on %s", who, quoteName(d.CheckItem.Name), card)
+			}
+			return fmt.Sprintf("%s marked %s incomplete on %s", who, quoteName(d.CheckItem.Name), card)
+		}
+	case "createCheckItem":
+		if d.CheckItem != nil {
+			return fmt.Sprintf("%s added item %s to %s", who, quoteName(d.CheckItem.Name), card)
+		}
+	case "addAttachmentToCard", "deleteAttachmentFromCard":
+		name := "a file"
+		if d.Attachment != nil {
+			name = quoteName(d.Attachment.Name)
+		}
+		if a.Type == "addAttachmentToCard" {
+			return fmt.Sprintf("%s attached %s to %s", who, name, card)
+		}
+		return fmt.Sprintf("%s removed attachment %s from %s", who, name, card)
+	case "createList":
+		return fmt.Sprintf("%s added list %s to %s", who, list, board)
+	case "updateList":
+		if old, ok := d.Old["name"].(string); ok {
+			return fmt.Sprintf("%s renamed list %s to %s", who, old, list)
+		}
+		if closed, ok := d.Old["closed"].(bool); ok {
+			if closed {
+				return fmt.Sprintf("%s restored list %s", who, list)
+			}
+			return fmt.Sprintf("%s archived list %s", who, list)
+		}
+		return fmt.Sprintf("%s updated list %s", who, list)
+	case "createBoard":
+		return fmt.Sprintf("%s created board %s", who, board)
+	case "updateBoard":
+		return fmt.Sprintf("%s updated board %s", who, board)
+	case "addMemberToBoard":
+		name := "a member"
+		if d.Member != nil {
+			name = d.Member.Name
+		}
+		return fmt.Sprintf("%s added %s to %s", who, name, board)
+	case "createLabel", "updateLabel", "deleteLabel":
+		name := "a label"
+		if d.Label != nil {
+			name = "label " + quoteName(labelName(d.Label.Name, d.Label.Color))
+		}
+		verb := map[string]string{"createLabel": "created", "updateLabel": "updated", "deleteLabel": "deleted"}[a.Type]
+		return fmt.Sprintf("%s %s %s on %s", who, verb, name, board)
+	}
+
+	subject := card
+	if d.Card == nil {
+		subject = board
+	}
+	return fmt.Sprintf("%s: %s %s", who, a.Type, subject)
+}
+
+// describeCardUpdate describes an updateCard action from its changed fields.
+func describeCardUpdate(who, card string, a api.Action) string {
+	d := a.Data
+	if d.ListBefore != nil && d.ListAfter != nil {
+		return fmt.Sprintf("%s moved %s from %s to %s", who, card, d.ListBefore.Name, d.ListAfter.Name)
+	}
+	if closed, ok := d.Old["closed"].(bool); ok {
+		if closed {
+			return fmt.Sprintf("%s restored %s", who, card)
+		}
+		return fmt.Sprintf("%s archived %s", who, card)
+	}
+	if old, ok := d.Old["name"].(string); ok {
+		return fmt.Sprintf("%s renamed %s to %s", who, quoteName(old), card)
+	}
+	if _, ok := d.Old["desc"]; ok {
+		return fmt.Sprintf("%s updated the description of %s", who, card)
+	}
+	if _, ok := d.Old["due"]; ok {
+		return fmt.Sprintf("%s changed the due date of %s", who, card)
+	}
+	if _, ok := d.Old["start"]; ok {
+		return fmt.Sprintf("%s changed the start date of %s", who, card)
+	}
+	if done, ok := d.Old["dueComplete"].(bool); ok {
+		if done {
+			return fmt.Sprintf("%s marked the due date of %s incomplete", who, card)
+		}
+		return fmt.Sprintf("%s marked the due date of %s complete", who, card)
+	}
+	if _, ok := d.Old["pos"]; ok {
+		list := "its list"
+		if d.List != nil {
+			list = d.List.Name
+		}
+		return fmt.Sprintf("%s reordered %s in %s", who, card, list)
+	}
+	return fmt.Sprintf("%s updated %s", who, card)
+}
+
+// labelName returns a label's name, or its color when unnamed.
+func labelName(name, color string) string {
+	if name != "" {
+		return name
+	}
+	return color
+}
+
+func quoteName(s string) string {
+	return "'" + s + "'"
+}
+
+// oneLine collapses whitespace in s and truncates it to n characters.
+func oneLine(s string, n int) string {
+	return output.Truncate(strings.Join(strings.Fields(s), " "), n)
+}
+
+func init() {
+	activityCmd.Flags().StringVar(&activityBoard, "board", "", "Board context for list/card names and #numbers")
+	activityCmd.Flags().StringVar(&activityModel, "model", "", "Restrict the reference to: board, list, card, member")
+	activityCmd.Flags().StringSliceVar(&activityTypes, "type", nil, "Action types, e.g. commentCard,createCard,updateCard:idList")
+	activityCmd.Flags().StringVar(&activitySince, "since", "", "Only actions after this date (YYYY-MM-DD, ISO-8601) or action ID")
+	activityCmd.Flags().StringVar(&activityBefore, "before", "", "Only actions before this date (YYYY-MM-DD, ISO-8601) or action ID")
+	activityCmd.Flags().StringSliceVar(&activityMembers, "member", nil, "Only actions by this member ID or username (repeatable)")
+	activityCmd.Flags().IntVar(&activityLimit, "limit", 50, "Maximum number of actions to fetch")
+	activityCmd.Flags().BoolVar(&activityAll, "all", false, "Fetch every matching action, paging as needed")
+	activityCmd.Flags().BoolVarP(&activityFollow, "follow", "f", false, "Keep running and print new actions as they happen")
+	activityCmd.Flags().DurationVar(&activityInterval, "interval", 5*time.Second, "Base polling interval for --follow")
+	activityCmd.Flags().BoolVar(&activityResume, "resume", false, "With --follow, continue from the last saved checkpoint")
+	activityCmd.Flags().StringVar(&activityCheckpoint, "checkpoint", "", "Checkpoint file for --follow (default: in the config directory)")
+	activityCmd.Flags().StringVar(&activityFormat, "format", "", "Output format: table, ndjson, or a Go template")
+	rootCmd.AddCommand(activityCmd)
+}
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/template"
+	"time"
+
+	"github.com/the20100/trello-cli/internal/api"
+	"github.com/the20100/trello-cli/internal/config"
+	"github.com/the20100/trello-cli/internal/output"
+)
+
+// maxFollowInterval caps how far polling backs off on a quiet feed.
+const maxFollowInterval = time.Minute
+
+// actionPrinter writes actions one at a time, as a table row, a JSON line,
+// or through a user-supplied template.
+type actionPrinter struct {
+	format string // "table", "ndjson", or "template"
+	tmpl   *template.Template
+	header bool
+	enc    *json.Encoder
+}
+
+// newActionPrinter returns a printer for --format. An empty format means a
+// table on a terminal and NDJSON otherwise.
+func newActionPrinter(format string, jsonOut bool) (*actionPrinter, error) {
+	switch {
+	case format == "" && jsonOut, format == "ndjson", format == "json":
+		return &actionPrinter{format: "ndjson", enc: json.NewEncoder(os.Stdout)}, nil
+	case format == "" || format == "table":
+		return &actionPrinter{format: "table"}, nil
+	}
+	tmpl, err := template.New("format").Funcs(template.FuncMap{
+		"describe": describeAction,
+		"member":   actionMemberName,
+		"time":     output.FormatTime,
+		"json": func(v any) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --format template: %w", err)
+	}
+	return &actionPrinter{format: "template", tmpl: tmpl}, nil
+}
+
+// print writes a single action.
+func (p *actionPrinter) print(a api.Action) error {
+	switch p.format {
+	case "ndjson":
+		return p.enc.Encode(a)
+	case "template":
+		var b strings.Builder
+		if err := p.tmpl.Execute(&b, a); err != nil {
+			return err
+		}
+		fmt.Println(strings.TrimRight(b.String(), "\n"))
+		return nil
+	}
+	width := output.TerminalWidth() - 40
+	if width < 20 {
+		width = 20
+	}
+	if !p.header {
+		fmt.Printf("%s  %s  %s\n", output.PadRight("DATE", 16), output.PadRight("MEMBER", 20), "ACTIVITY")
+		p.header = true
+	}
+	fmt.Printf("%s  %s  %s\n",
+		output.PadRight(output.FormatTime(a.Date), 16),
+		output.PadRight(output.Truncate(actionMemberName(a), 20), 20),
+		output.Truncate(describeAction(a), width))
+	return nil
+}
+
+// followActivity prints the existing backlog, then polls for new actions
+// until interrupted. Actions are printed oldest first, as they happened.
+func followActivity(model, id string, params url.Values, memberIDs []string, p *actionPrinter) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	checkpoint := ""
+	if activityResume || activityCheckpoint != "" {
+		path, err := activityCheckpointPath(model, id)
+		if err != nil {
+			return err
+		}
+		checkpoint = path
+	}
+
+	last := ""
+	if activityResume {
+		data, err := os.ReadFile(checkpoint)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("reading checkpoint: %w", err)
+		}
+		last = strings.TrimSpace(string(data))
+	}
+
+	// Without a checkpoint, show the last --limit actions first; when
+	// resuming, everything since the checkpoint.
+	var backlog []api.Action
+	var err error
+	if last == "" {
+		backlog, err = fetchActions(model, id, params, activityLimit)
+	} else {
+		backlog, err = fetchActionsSince(model, id, params, last)
+	}
+	if err != nil {
+		return err
+	}
+	if last, err = emitActions(backlog, last, memberIDs, p, checkpoint); err != nil {
+		return err
+	}
+	if last == "" {
+		// Empty feed: poll from now on rather than from the beginning.
+		params.Set("since", time.Now().UTC().Format(time.RFC3339))
+	}
+
+	poll := pollInterval{base: activityInterval, cur: activityInterval}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(poll.cur):
+		}
+
+		actions, fetchErr := fetchActionsSince(model, id, params, last)
+		if fetchErr != nil {
+			var te *api.TrelloError
+			if errors.As(fetchErr, &te) && te.StatusCode != 429 && te.StatusCode < 500 {
+				return fetchErr
+			}
+			if ctx.Err() != nil {
+				return nil
+			}
+			fmt.Fprintf(os.Stderr, "warning: %v (retrying)\n", fetchErr)
+		}
+		if last, err = emitActions(actions, last, memberIDs, p, checkpoint); err != nil {
+			return err
+		}
+		poll.adapt(len(actions), client.RateLimit(), fetchErr)
+	}
+}
+
+// fetchActionsSince returns every action newer than the action with ID last
+// (or matching params alone when last is empty), newest first.
+func fetchActionsSince(model, id string, params url.Values, last string) ([]api.Action, error) {
+	if last == "" {
+		return fetchActions(model, id, params, 0)
+	}
+	p := url.Values{}
+	for k, vs := range params {
+		p[k] = vs
+	}
+	p.Set("since", last)
+	return fetchActions(model, id, p, 0)
+}
+
+// emitActions prints a newest-first batch in chronological order, saves the
+// checkpoint, and returns the ID of the newest action seen.
+func emitActions(actions []api.Action, last string, memberIDs []string, p *actionPrinter, checkpoint string) (string, error) {
+	if len(actions) == 0 {
+		return last, nil
+	}
+	for i := len(actions) - 1; i >= 0; i-- {
+		a := actions[i]
+		if len(memberIDs) > 0 && !containsString(memberIDs, a.IDMemberCreator) {
+			continue
+		}
+		if err := p.print(a); err != nil {
+			return last, err
+		}
+	}
+	last = actions[0].ID
+	if checkpoint != "" {
+		if err := os.WriteFile(checkpoint, []byte(last+"\n"), 0600); err != nil {
+			return last, fmt.Errorf("saving checkpoint: %w", err)
+		}
+	}
+	return last, nil
+}
+
+// activityCheckpointPath returns --checkpoint, or a per-feed file in the
+// config directory.
+func activityCheckpointPath(model, id string) (string, error) {
+	if activityCheckpoint != "" {
+		return activityCheckpoint, nil
+	}
+	return config.StatePath(fmt.Sprintf("activity-%s-%s", model, id))
+}
+
+// pollInterval is an adaptive delay between polls: it resets to base when
+// new actions arrive, backs off while the feed is quiet, and waits out the
+// rate-limit window when the token is close to its limit.
+type pollInterval struct {
+	base, cur time.Duration
+}
+
+func (p *pollInterval) adapt(n int, rl api.RateLimit, err error) {
+	var te *api.TrelloError
+	switch {
+	case errors.As(err, &te) && te.StatusCode == 429:
+		p.cur = max(p.cur*2, rl.Interval, 10*time.Second)
+	case err != nil:
+		p.cur *= 2
+	case n > 0:
+		p.cur = p.base
+	default:
+		p.cur += p.cur / 2
+	}
+	if rl.Max > 0 && rl.Remaining < rl.Max/5 {
+		p.cur = max(p.cur, rl.Interval)
+	}
+	p.cur = min(max(p.cur, p.base), max(maxFollowInterval, p.base))
+}
@@ -0,0 +1,496 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/api"
+	"github.com/the20100/trello-cli/internal/boardspec"
+	"github.com/the20100/trello-cli/internal/output"
+	"github.com/the20100/trello-cli/internal/strutil"
+)
+
+var (
+	applyFile        string
+	applyBoard       string
+	applyAutoApprove bool
+)
+
+const boardSpecHelp = `The spec is a board YAML file in the format of "trello boards template
+save", plus:
+
+  board: <id|short link|URL>     pin the spec to a board; otherwise the open
+                                 board with the spec's name is used, and
+                                 created if there is none
+  prefs:                         permissionLevel, voting, comments,
+                                 background, cardAging, selfJoin, cardCovers
+  members:                       - {username: alice, type: admin}
+  id: <list or label id>         on a list or label, to rename it
+
+Lists are kept in spec order; open lists that are not in the spec are
+archived. Labels, custom fields, and members are only managed when the spec
+lists any, and then extra ones are removed. Cards listed under a list are
+pinned: created if missing (matched by name), moved into the list, and given
+the spec's description and labels when set. Other cards are left alone.`
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show the changes needed to make a board match a YAML spec",
+	Long: `Compare a board YAML spec with the live board and print the changes
+"trello apply" would make, without making them.
+
+Exit status is 0 when the board matches the spec, 2 when it has drifted,
+and 1 on error, so CI can detect manual changes.
+
+` + boardSpecHelp + `
+
+Examples:
+  trello plan -f board.yaml
+  trello plan -f board.yaml --board abc123
+  trello plan -f board.yaml --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		spec, live, plan, err := computePlan()
+		if err != nil {
+			return err
+		}
+		if output.IsJSON(cmd) {
+			if err := output.PrintJSON(planReport(spec, live, plan), output.IsPretty(cmd)); err != nil {
+				return err
+			}
+		} else {
+			printPlan(spec, live, plan)
+		}
+		if len(plan.Ops) > 0 {
+			cmd.SilenceErrors = true
+			return &exitError{code: 2}
+		}
+		return nil
+	},
+}
+
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Make a board match a YAML spec",
+	Long: `Compute the changes needed to make the live board match a board YAML
+spec, print them, and make them after confirmation.
+
+Confirmation is read from the terminal; use --auto-approve in scripts.
+
+` + boardSpecHelp + `
+
+Examples:
+  trello apply -f board.yaml
+  trello apply -f board.yaml --auto-approve
+  trello apply -f board.yaml --board abc123`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		spec, live, plan, err := computePlan()
+		if err != nil {
+			return err
+		}
+		jsonOut := output.IsJSON(cmd)
+		if !jsonOut {
+			printPlan(spec, live, plan)
+		}
+		if len(plan.Ops) == 0 {
+			if jsonOut {
+				return output.PrintJSON(planReport(spec, live, plan), output.IsPretty(cmd))
+			}
+			return nil
+		}
+
+		if !applyAutoApprove {
+			if !isatty.IsTerminal(os.Stdin.Fd()) {
+				return fmt.Errorf("refusing to apply without confirmation: pass --auto-approve")
+			}
+			fmt.Print("\nApply these changes? Only 'yes' will be accepted: ")
+			answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			if strings.TrimSpace(answer) != "yes" {
+				return fmt.Errorf("apply cancelled")
+			}
+			fmt.Println()
+		}
+
+		boardID, err := executePlan(spec, live, plan, !jsonOut)
+		if err != nil {
+			return err
+		}
+		if jsonOut {
+			report := planReport(spec, live, plan)
+			report.Board = boardID
+			return output.PrintJSON(report, output.IsPretty(cmd))
+		}
+		add, change, destroy := plan.Counts()
+		fmt.Printf("\nApply complete: %d added, %d changed, %d destroyed.\n", add, change, destroy)
+		return nil
+	},
+}
+
+// exitError makes Execute exit with a specific status. Commands that return
+// it set SilenceErrors when they have already reported the outcome.
+type exitError struct {
+	code int
+}
+
+func (e *exitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
+// computePlan loads the spec, fetches the live board, and diffs them.
+func computePlan() (*boardspec.Spec, *boardspec.Live, *boardspec.Plan, error) {
+	if applyFile == "" {
+		return nil, nil, nil, fmt.Errorf("--file is required")
+	}
+	spec, err := boardspec.Load(applyFile)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	live, err := fetchLiveBoard(spec, strutil.FirstNonEmpty(applyBoard, spec.Board))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return spec, live, boardspec.Diff(spec, live), nil
+}
+
+// fetchLiveBoard fetches the board a spec describes: the board ref, or the
+// open board named like the spec. Live.Board is nil when there is none.
+func fetchLiveBoard(spec *boardspec.Spec, ref string) (*boardspec.Live, error) {
+	me, err := client.GetMember("me", url.Values{"fields": {"username"}})
+	if err != nil {
+		return nil, err
+	}
+	live := &boardspec.Live{Self: me.ID}
+
+	var boardID string
+	if ref != "" {
+		if boardID, err = resolveBoardID(ref); err != nil {
+			return nil, err
+		}
+	} else {
+		boards, err := client.GetMyBoards("open")
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range boards {
+			if !strings.EqualFold(b.Name, spec.Name) {
+				continue
+			}
+			if boardID != "" {
+				return nil, fmt.Errorf("several boards are named %q: set board: in the spec or pass --board", spec.Name)
+			}
+			boardID = b.ID
+		}
+		if boardID == "" {
+			return live, nil
+		}
+	}
+
+	if live.Board, err = client.GetBoard(boardID, nil); err != nil {
+		return nil, err
+	}
+	if live.Lists, err = client.GetBoardLists(boardID, "open"); err != nil {
+		return nil, err
+	}
+	if live.Labels, err = client.GetBoardLabels(boardID); err != nil {
+		return nil, err
+	}
+	if live.Memberships, err = client.GetBoardMemberships(boardID); err != nil {
+		return nil, err
+	}
+	if live.Fields, err = client.GetBoardCustomFields(boardID); err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	live.Cards, err = client.GetBoardCardsWithParams(boardID, url.Values{
+		"filter": {"open"},
+		"fields": {"name,desc,idList,idLabels,pos"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return live, nil
+}
+
+// planReportJSON is the JSON form of a plan.
+type planReportJSON struct {
+	Board   string         `json:"board,omitempty"`
+	Name    string         `json:"name"`
+	Add     int            `json:"add"`
+	Change  int            `json:"change"`
+	Destroy int            `json:"destroy"`
+	Ops     []boardspec.Op `json:"ops"`
+}
+
+func planReport(spec *boardspec.Spec, live *boardspec.Live, plan *boardspec.Plan) *planReportJSON {
+	r := &planReportJSON{Name: spec.Name, Ops: plan.Ops}
+	if live.Board != nil {
+		r.Board = live.Board.ID
+	}
+	if r.Ops == nil {
+		r.Ops = []boardspec.Op{}
+	}
+	r.Add, r.Change, r.Destroy = plan.Counts()
+	return r
+}
+
+// printPlan prints a plan Terraform-style: + create, ~ update, - destroy.
+func printPlan(spec *boardspec.Spec, live *boardspec.Live, plan *boardspec.Plan) {
+	if live.Board == nil {
+		fmt.Printf("Board %s does not exist and will be created.\n\n", quoteName(spec.Name))
+	} else {
+		fmt.Printf("Board %s (%s)\n\n", live.Board.Name, live.Board.ShortURL)
+	}
+	if len(plan.Ops) == 0 {
+		fmt.Println("No changes. The board matches the spec.")
+		return
+	}
+	for _, op := range plan.Ops {
+		fmt.Println("  " + describeOp(op))
+		for _, c := range op.Changes {
+			fmt.Printf("      %s: %s → %s\n", c.Field, displayValue(c.Old), displayValue(c.New))
+		}
+	}
+	add, change, destroy := plan.Counts()
+	fmt.Printf("\nPlan: %d to add, %d to change, %d to destroy.\n", add, change, destroy)
+}
+
+// describeOp returns the one-line summary of an op, with its +/~/- marker.
+func describeOp(op boardspec.Op) string {
+	marker := map[boardspec.Action]string{boardspec.Create: "+", boardspec.Update: "~", boardspec.Delete: "-"}[op.Action]
+	s := marker + " " + op.Kind + " " + quoteName(op.Name)
+	if op.Kind == boardspec.KindOption {
+		s += " of field " + quoteName(op.Parent)
+	}
+	if op.Note != "" {
+		s += " (" + op.Note + ")"
+	}
+	return s
+}
+
+func displayValue(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return strconv.Quote(oneLine(s, 60))
+}
+
+// planExecutor applies a plan op by op, tracking the IDs of created objects.
+type planExecutor struct {
+	spec     *boardspec.Spec
+	boardID  string
+	listIDs  map[int]string
+	labelIDs map[string]string
+}
+
+// executePlan applies the ops of plan in order and returns the board ID.
+// With verbose, each op is printed as it completes.
+func executePlan(spec *boardspec.Spec, live *boardspec.Live, plan *boardspec.Plan, verbose bool) (string, error) {
+	e := &planExecutor{spec: spec, listIDs: map[int]string{}, labelIDs: map[string]string{}}
+	if live.Board != nil {
+		e.boardID = live.Board.ID
+	}
+	for i, id := range plan.ListIDs {
+		e.listIDs[i] = id
+	}
+	for k, id := range plan.LabelIDs {
+		e.labelIDs[k] = id
+	}
+	for _, op := range plan.Ops {
+		if err := e.apply(op); err != nil {
+			return e.boardID, fmt.Errorf("%s: %w", describeOp(op), err)
+		}
+		if verbose {
+			fmt.Println("  " + describeOp(op) + " ... done")
+		}
+	}
+	return e.boardID, nil
+}
+
+func (e *planExecutor) apply(op boardspec.Op) error {
+	switch op.Kind {
+	case boardspec.KindBoard:
+		return e.applyBoard(op)
+	case boardspec.KindMember:
+		return e.applyMember(op)
+	case boardspec.KindLabel:
+		return e.applyLabel(op)
+	case boardspec.KindField, boardspec.KindOption:
+		return e.applyField(op)
+	case boardspec.KindList:
+		return e.applyList(op)
+	case boardspec.KindCard:
+		return e.applyCard(op)
+	}
+	return fmt.Errorf("unknown kind %q", op.Kind)
+}
+
+func (e *planExecutor) applyBoard(op boardspec.Op) error {
+	if op.Action == boardspec.Create {
+		prefs := url.Values{"defaultLists": {"false"}, "defaultLabels": {"false"}}
+		if p := e.spec.Prefs; p != nil {
+			for k, v := range boardCreatePrefs(p) {
+				prefs[k] = v
+			}
+		}
+		board, err := client.CreateBoard(e.spec.Name, e.spec.Description, "", prefs)
+		if err != nil {
+			return err
+		}
+		e.boardID = board.ID
+		return nil
+	}
+	params := url.Values{}
+	for k, v := range op.Params {
+		params.Set(k, v)
+	}
+	_, err := client.UpdateBoard(e.boardID, params)
+	return err
+}
+
+// boardCreatePrefs returns the prefs_* parameters for creating a board.
+func boardCreatePrefs(p *boardspec.Prefs) url.Values {
+	v := buildParams(
+		"prefs_permissionLevel", p.PermissionLevel,
+		"prefs_voting", p.Voting,
+		"prefs_comments", p.Comments,
+		"prefs_background", p.Background,
+		"prefs_cardAging", p.CardAging,
+	)
+	if p.SelfJoin != nil {
+		v.Set("prefs_selfJoin", strconv.FormatBool(*p.SelfJoin))
+	}
+	if p.CardCovers != nil {
+		v.Set("prefs_cardCovers", strconv.FormatBool(*p.CardCovers))
+	}
+	return v
+}
+
+func (e *planExecutor) applyMember(op boardspec.Op) error {
+	switch op.Action {
+	case boardspec.Create:
+		m, err := client.GetMember(strings.TrimPrefix(op.Member.Username, "@"), url.Values{"fields": {"username"}})
+		if err != nil {
+			return err
+		}
+		return client.SetBoardMember(e.boardID, m.ID, op.Member.MemberType())
+	case boardspec.Update:
+		return client.SetBoardMember(e.boardID, op.ID, op.Member.MemberType())
+	}
+	return client.RemoveBoardMember(e.boardID, op.ID)
+}
+
+func (e *planExecutor) applyLabel(op boardspec.Op) error {
+	switch op.Action {
+	case boardspec.Create:
+		l, err := client.CreateLabel(e.boardID, op.Label.Name, op.Label.Color)
+		if err != nil {
+			return err
+		}
+		for ref, i := range e.spec.LabelRefs() {
+			if &e.spec.Labels[i] == op.Label {
+				e.labelIDs[ref] = l.ID
+			}
+		}
+		return nil
+	case boardspec.Update:
+		_, err := client.UpdateLabel(op.ID, url.Values{"name": {op.Label.Name}, "color": {strutil.FirstNonEmpty(op.Label.Color, "null")}})
+		return err
+	}
+	return client.DeleteLabel(op.ID)
+}
+
+func (e *planExecutor) applyField(op boardspec.Op) error {
+	if op.Kind == boardspec.KindOption {
+		if op.Action == boardspec.Create {
+			_, err := client.AddCustomFieldOption(op.ID, op.Name)
+			return err
+		}
+		return client.DeleteCustomFieldOption(op.ID, op.OptionID)
+	}
+	switch op.Action {
+	case boardspec.Create:
+		// Enabling fails harmlessly when the power-up is already on.
+		client.EnableBoardPlugin(e.boardID, api.CustomFieldsPluginID)
+		_, err := client.CreateCustomField(e.boardID, op.Field.Name, op.Field.Type, op.Field.Options, op.Field.CardFront)
+		return err
+	case boardspec.Update:
+		_, err := client.UpdateCustomField(op.ID, map[string]any{
+			"name":              op.Field.Name,
+			"display/cardFront": op.Field.CardFront,
+		})
+		return err
+	}
+	return client.DeleteCustomField(op.ID)
+}
+
+func (e *planExecutor) applyList(op boardspec.Op) error {
+	pos := strconv.FormatFloat(op.Pos, 'f', -1, 64)
+	switch op.Action {
+	case boardspec.Create:
+		l, err := client.CreateList(e.spec.Lists[op.List].Name, e.boardID, pos)
+		if err != nil {
+			return err
+		}
+		e.listIDs[op.List] = l.ID
+		return nil
+	case boardspec.Update:
+		params := url.Values{}
+		for _, c := range op.Changes {
+			switch c.Field {
+			case "name":
+				params.Set("name", c.New)
+			case "position":
+				params.Set("pos", pos)
+			}
+		}
+		_, err := client.UpdateList(op.ID, params)
+		return err
+	}
+	_, err := client.ArchiveList(op.ID, true)
+	return err
+}
+
+func (e *planExecutor) applyCard(op boardspec.Op) error {
+	listID, ok := e.listIDs[op.List]
+	if !ok {
+		return fmt.Errorf("list %s was not created", quoteName(e.spec.Lists[op.List].Name))
+	}
+	if op.Action == boardspec.Create {
+		return createSpecCard(listID, *op.Card, e.labelIDs)
+	}
+	params := url.Values{}
+	for _, c := range op.Changes {
+		switch c.Field {
+		case "list":
+			params.Set("idList", listID)
+			params.Set("pos", "bottom")
+		case "desc":
+			params.Set("desc", op.Card.Desc)
+		case "labels":
+			var ids []string
+			for _, ref := range op.Card.Labels {
+				if id, ok := e.labelIDs[strings.ToLower(ref)]; ok {
+					ids = append(ids, id)
+				}
+			}
+			params.Set("idLabels", strings.Join(ids, ","))
+		}
+	}
+	_, err := client.UpdateCard(op.ID, params)
+	return err
+}
+
+func init() {
+	for _, c := range []*cobra.Command{planCmd, applyCmd} {
+		c.Flags().StringVarP(&applyFile, "file", "f", "", "Board spec YAML file (required)")
+		c.Flags().StringVar(&applyBoard, "board", "", "Board to compare with (overrides board: in the spec)")
+	}
+	applyCmd.Flags().BoolVar(&applyAutoApprove, "auto-approve", false, "Apply without asking for confirmation")
+	rootCmd.AddCommand(planCmd, applyCmd)
+}
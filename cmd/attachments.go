@@ -0,0 +1,304 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/api"
+	"github.com/the20100/trello-cli/internal/output"
+	"github.com/the20100/trello-cli/internal/strutil"
+)
+
+var attachmentsCmd = &cobra.Command{
+	Use:   "attachments",
+	Short: "Upload, download, and delete card attachments",
+	Long: `Manage the attachments of a card. To list them, use "trello cards attachments".
+
+Cards can be given as an ID, short link, URL, or a name or "#<number>" with --board.`,
+}
+
+// ---- attachments add ----
+
+var (
+	attachmentsAddBoard    string
+	attachmentsAddName     string
+	attachmentsAddSetCover bool
+	attachmentsAddMimeType string
+)
+
+var attachmentsAddCmd = &cobra.Command{
+	Use:   "add <card> <file|url>",
+	Short: "Upload a file or attach a link to a card",
+	Long: `Attach a local file or a link to a card.
+
+An argument starting with http:// or https:// is attached as a link;
+anything else is uploaded as a file. Use - to upload stdin (requires --name).
+The MIME type is guessed from the file extension unless --mime-type is set.
+
+Examples:
+  trello attachments add abc123 screenshot.png
+  trello attachments add abc123 report.pdf --name "Q3 report"
+  trello attachments add abc123 cover.jpg --set-cover
+  trello attachments add abc123 https://github.com/org/repo/pull/42
+  pg_dump mydb | gzip | trello attachments add abc123 - --name dump.sql.gz`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		card, err := resolveRef(args[0], "card", attachmentsAddBoard)
+		if err != nil {
+			return err
+		}
+
+		params := url.Values{}
+		if attachmentsAddName != "" {
+			params.Set("name", attachmentsAddName)
+		}
+		if attachmentsAddSetCover {
+			params.Set("setCover", "true")
+		}
+
+		var att *api.Attachment
+		src := args[1]
+		if isLink(src) {
+			att, err = client.AttachURL(card.ID, src, params)
+		} else {
+			att, err = uploadFile(card.ID, src, params)
+		}
+		if err != nil {
+			return err
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(att, output.IsPretty(cmd))
+		}
+		fmt.Printf("Attachment %q added to card %s.\n", att.Name, card.ID)
+		fmt.Printf("ID: %s\n", att.ID)
+		return nil
+	},
+}
+
+// isLink reports whether s should be attached as a URL rather than uploaded.
+func isLink(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
+
+// uploadFile uploads the file at path ("-" for stdin) to a card.
+func uploadFile(cardID, path string, params url.Values) (*api.Attachment, error) {
+	var r io.Reader
+	fileName := filepath.Base(path)
+	if path == "-" {
+		if attachmentsAddName == "" {
+			return nil, fmt.Errorf("--name is required when uploading from stdin")
+		}
+		r = os.Stdin
+		fileName = attachmentsAddName
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		if st, err := f.Stat(); err == nil && st.IsDir() {
+			return nil, fmt.Errorf("%s is a directory", path)
+		}
+		r = f
+	}
+
+	mimeType := attachmentsAddMimeType
+	if mimeType == "" {
+		mimeType = mime.TypeByExtension(filepath.Ext(fileName))
+	}
+	if mimeType != "" {
+		params.Set("mimeType", mimeType)
+	}
+	return client.UploadAttachment(cardID, fileName, r, params)
+}
+
+// ---- attachments download ----
+
+var (
+	attachmentsDownloadBoard string
+	attachmentsDownloadDir   string
+	attachmentsDownloadForce bool
+)
+
+var attachmentsDownloadCmd = &cobra.Command{
+	Use:   "download <card> [<attachment-id>...]",
+	Short: "Download uploaded attachments of a card",
+	Long: `Download attachments uploaded to a card into --dir (default: the current
+directory). Without attachment IDs, every uploaded file is downloaded; links
+are skipped since there is nothing to fetch from Trello.
+
+Files are saved under their original name. Existing files are not
+overwritten unless --force is given.
+
+Examples:
+  trello attachments download abc123
+  trello attachments download abc123 --dir ./assets
+  trello attachments download abc123 5f1a2b3c4d5e6f7a8b9c0d1e --force`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		card, err := resolveRef(args[0], "card", attachmentsDownloadBoard)
+		if err != nil {
+			return err
+		}
+
+		var attachments []api.Attachment
+		if len(args) > 1 {
+			for _, id := range args[1:] {
+				a, err := client.GetCardAttachment(card.ID, id)
+				if err != nil {
+					return err
+				}
+				if !a.IsUpload {
+					return fmt.Errorf("attachment %s is a link, not an uploaded file: %s", a.ID, a.URL)
+				}
+				attachments = append(attachments, *a)
+			}
+		} else {
+			all, err := client.GetCardAttachments(card.ID)
+			if err != nil {
+				return err
+			}
+			for _, a := range all {
+				if a.IsUpload {
+					attachments = append(attachments, a)
+				}
+			}
+		}
+
+		if len(attachments) == 0 {
+			if output.IsJSON(cmd) {
+				return output.PrintJSON([]downloadedAttachment{}, output.IsPretty(cmd))
+			}
+			fmt.Println("No uploaded attachments found.")
+			return nil
+		}
+		if err := os.MkdirAll(attachmentsDownloadDir, 0755); err != nil {
+			return err
+		}
+
+		var results []downloadedAttachment
+		used := map[string]bool{}
+		for _, a := range attachments {
+			name := attachmentFileName(a)
+			if used[name] {
+				name = a.ID + "-" + name
+			}
+			used[name] = true
+
+			path := filepath.Join(attachmentsDownloadDir, name)
+			n, err := downloadAttachment(card.ID, a, path, attachmentsDownloadForce)
+			if err != nil {
+				return fmt.Errorf("downloading %s: %w", a.Name, err)
+			}
+			results = append(results, downloadedAttachment{ID: a.ID, Name: a.Name, Path: path, Bytes: n})
+			if !output.IsJSON(cmd) {
+				fmt.Printf("%s (%s) → %s\n", a.Name, output.FormatBytes(n), path)
+			}
+		}
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(results, output.IsPretty(cmd))
+		}
+		return nil
+	},
+}
+
+// downloadedAttachment describes a saved file in JSON output.
+type downloadedAttachment struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Path  string `json:"path"`
+	Bytes int64  `json:"bytes"`
+}
+
+// attachmentFileName returns a safe local file name for an attachment.
+func attachmentFileName(a api.Attachment) string {
+	name := filepath.Base(strutil.FirstNonEmpty(a.FileName, a.Name))
+	if name == "." || name == string(filepath.Separator) || name == "" {
+		name = a.ID
+	}
+	return name
+}
+
+// downloadAttachment saves an attachment to path via a temporary file, so a
+// failed download never leaves a partial file behind.
+func downloadAttachment(cardID string, a api.Attachment, path string, force bool) (int64, error) {
+	if !force {
+		if _, err := os.Stat(path); err == nil {
+			return 0, fmt.Errorf("%s already exists (use --force to overwrite)", path)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return 0, err
+		}
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".trello-download-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := client.DownloadAttachment(cardID, a.ID, strutil.FirstNonEmpty(a.FileName, a.Name), tmp)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return 0, err
+	}
+	return n, os.Rename(tmp.Name(), path)
+}
+
+// ---- attachments delete ----
+
+var attachmentsDeleteBoard string
+
+var attachmentsDeleteCmd = &cobra.Command{
+	Use:   "delete <card> <attachment-id>...",
+	Short: "Delete attachments from a card",
+	Long: `Permanently delete one or more attachments from a card.
+
+This action cannot be undone.
+
+Examples:
+  trello attachments delete abc123 5f1a2b3c4d5e6f7a8b9c0d1e`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		card, err := resolveRef(args[0], "card", attachmentsDeleteBoard)
+		if err != nil {
+			return err
+		}
+		for _, id := range args[1:] {
+			if err := client.DeleteAttachment(card.ID, id); err != nil {
+				return err
+			}
+			fmt.Printf("Attachment %s deleted.\n", id)
+		}
+		return nil
+	},
+}
+
+func init() {
+	attachmentsAddCmd.Flags().StringVar(&attachmentsAddBoard, "board", "", "Board context for card names and #numbers")
+	attachmentsAddCmd.Flags().StringVar(&attachmentsAddName, "name", "", "Attachment name (default: file name or URL)")
+	attachmentsAddCmd.Flags().BoolVar(&attachmentsAddSetCover, "set-cover", false, "Use the uploaded image as the card cover")
+	attachmentsAddCmd.Flags().StringVar(&attachmentsAddMimeType, "mime-type", "", "MIME type of the upload (default: from the file extension)")
+
+	attachmentsDownloadCmd.Flags().StringVar(&attachmentsDownloadBoard, "board", "", "Board context for card names and #numbers")
+	attachmentsDownloadCmd.Flags().StringVar(&attachmentsDownloadDir, "dir", ".", "Directory to save files into")
+	attachmentsDownloadCmd.Flags().BoolVar(&attachmentsDownloadForce, "force", false, "Overwrite existing files")
+
+	attachmentsDeleteCmd.Flags().StringVar(&attachmentsDeleteBoard, "board", "", "Board context for card names and #numbers")
+
+	attachmentsCmd.AddCommand(
+		attachmentsAddCmd,
+		attachmentsDownloadCmd,
+		attachmentsDeleteCmd,
+	)
+	rootCmd.AddCommand(attachmentsCmd)
+}
@@ -0,0 +1,663 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/api"
+	"github.com/the20100/trello-cli/internal/backup"
+	"github.com/the20100/trello-cli/internal/output"
+	"github.com/the20100/trello-cli/internal/snapshot"
+	"github.com/the20100/trello-cli/internal/strutil"
+)
+
+// ---- backup ----
+
+var (
+	backupWorkspace  string
+	backupOut        string
+	backupSince      string
+	backupMaxActions int
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup [board]",
+	Short: "Back up boards to a versioned archive",
+	Long: `Write a board, or every board of a workspace, to a .tar.gz archive: a
+manifest describing the archive, the full board export (including archived
+lists and cards, checklists, custom fields, comments, and activity), and the
+files uploaded as attachments. Restore it with "trello restore".
+
+With --since, the backup is incremental: it holds the current board
+structure but only the cards changed since the given earlier archive
+(by their last activity and the board's actions), plus attachments
+uploaded since. Restoring an incremental archive reads its chain of
+earlier archives, so keep them together.
+
+Examples:
+  trello backup "My Project"
+  trello backup abc123 --out backups/project-full.tar.gz
+  trello backup abc123 --since backups/project-full.tar.gz --out backups/project-monday.tar.gz
+  trello backup --workspace acme`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if (len(args) == 1) == (backupWorkspace != "") {
+			return fmt.Errorf("give a board or --workspace, not both")
+		}
+
+		var boards []api.Board
+		label := backupWorkspace
+		if backupWorkspace != "" {
+			var err error
+			if boards, err = client.GetOrganizationBoards(backupWorkspace, "all"); err != nil {
+				return err
+			}
+			if len(boards) == 0 {
+				return fmt.Errorf("workspace %s has no boards", backupWorkspace)
+			}
+		} else {
+			boardID, err := resolveBoardID(args[0])
+			if err != nil {
+				return err
+			}
+			board, err := client.GetBoard(boardID, url.Values{"fields": {"name"}})
+			if err != nil {
+				return err
+			}
+			boards, label = []api.Board{*board}, board.Name
+		}
+
+		out := backupOut
+		if out == "" {
+			out = fmt.Sprintf("trello-backup-%s-%s.tar.gz", slugify(label), time.Now().Format("20060102-150405"))
+		}
+
+		var prev *backup.Manifest
+		var base, since string
+		var sinceTime time.Time
+		if backupSince != "" {
+			var err error
+			if prev, err = backup.ReadManifest(backupSince); err != nil {
+				return err
+			}
+			since = prev.CreatedAt
+			if sinceTime, err = time.Parse(time.RFC3339, since); err != nil {
+				return fmt.Errorf("%s: invalid creation time %q", backupSince, since)
+			}
+			base = backupBasePath(out, backupSince)
+		}
+
+		w, err := backup.Create(out, base, since)
+		if err != nil {
+			return err
+		}
+		for _, b := range boards {
+			var snap *snapshot.Board
+			if prev != nil && backupHasBoard(prev, b.ID) {
+				snap, err = fetchBoardChanges(b.ID, since, sinceTime)
+			} else {
+				snap, err = fetchBoardSnapshot(b.ID, true, backupMaxActions)
+			}
+			if err == nil {
+				err = w.AddBoard(snap)
+			}
+			if err == nil {
+				err = backupAttachments(w, snap, sinceTime)
+			}
+			if err != nil {
+				w.Abort()
+				return fmt.Errorf("backing up %s: %w", strutil.FirstNonEmpty(b.Name, b.ID), err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			os.Remove(out)
+			return err
+		}
+
+		m := w.Manifest()
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(map[string]any{"archive": out, "manifest": m}, output.IsPretty(cmd))
+		}
+		rows := make([][]string, 0, len(m.Boards))
+		for _, b := range m.Boards {
+			rows = append(rows, []string{
+				output.Truncate(b.Name, 40),
+				fmt.Sprintf("%d", b.Lists),
+				fmt.Sprintf("%d", b.Cards),
+				fmt.Sprintf("%d", b.Actions),
+				fmt.Sprintf("%d", b.Attachments),
+				output.FormatBytes(b.Bytes),
+			})
+		}
+		output.PrintTable([]string{"BOARD", "LISTS", "CARDS", "ACTIONS", "FILES", "SIZE"}, rows)
+		if m.Incremental() {
+			fmt.Printf("\nIncremental backup written to %s (changes since %s, base %s).\n", out, m.Since, m.Base)
+		} else {
+			fmt.Printf("\nBackup written to %s.\n", out)
+		}
+		return nil
+	},
+}
+
+// backupBasePath returns the path of the base archive relative to the
+// directory of the new one, as recorded in its manifest.
+func backupBasePath(out, base string) string {
+	absOut, err1 := filepath.Abs(out)
+	absBase, err2 := filepath.Abs(base)
+	if err1 != nil || err2 != nil {
+		return base
+	}
+	if rel, err := filepath.Rel(filepath.Dir(absOut), absBase); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return absBase
+}
+
+func backupHasBoard(m *backup.Manifest, boardID string) bool {
+	for _, b := range m.Boards {
+		if b.ID == boardID {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchBoardChanges fetches a board's structure, the actions since since,
+// and in full only the cards active since then or named by those actions.
+func fetchBoardChanges(boardID, since string, sinceTime time.Time) (*snapshot.Board, error) {
+	snap, err := fetchBoardStructure(boardID, "all")
+	if err != nil {
+		return nil, err
+	}
+	cards, err := fetchBoardCards(boardID, url.Values{
+		"filter": {"all"},
+		"fields": {"id,dateLastActivity"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if snap.Actions, err = fetchActions("boards", boardID, url.Values{"filter": {"all"}, "since": {since}}, 0); err != nil {
+		return nil, err
+	}
+
+	onBoard := map[string]bool{}
+	changed := map[string]bool{}
+	var ids []string
+	mark := func(id string) {
+		if onBoard[id] && !changed[id] {
+			changed[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, c := range cards {
+		onBoard[c.ID] = true
+	}
+	for _, c := range cards {
+		if isAfter(c.DateLastActivity, sinceTime) {
+			mark(c.ID)
+		}
+	}
+	for _, a := range snap.Actions {
+		if a.Data.Card != nil {
+			mark(a.Data.Card.ID)
+		}
+	}
+
+	for start := 0; start < len(ids); start += api.MaxBatch {
+		end := min(start+api.MaxBatch, len(ids))
+		paths := make([]string, 0, end-start)
+		for _, id := range ids[start:end] {
+			paths = append(paths, "/cards/"+id+"?attachments=true&customFieldItems=true&checklists=all")
+		}
+		results, errs, err := client.Batch(paths)
+		if err != nil {
+			return nil, err
+		}
+		for i := range paths {
+			if errs[i] != nil {
+				// Deleted between listing and fetching.
+				if isNotFound(errs[i]) {
+					continue
+				}
+				return nil, errs[i]
+			}
+			var c api.Card
+			if err := json.Unmarshal(results[i], &c); err != nil {
+				return nil, fmt.Errorf("decoding card: %w", err)
+			}
+			snap.Cards = append(snap.Cards, c)
+		}
+	}
+	liftChecklists(snap)
+	snap.Sort()
+	return snap, nil
+}
+
+// isAfter reports whether the Trello timestamp ts is later than t. A zero t
+// is before everything.
+func isAfter(ts string, t time.Time) bool {
+	if t.IsZero() {
+		return true
+	}
+	parsed, err := time.Parse(time.RFC3339, ts)
+	return err != nil || parsed.After(t)
+}
+
+// backupAttachments adds the uploaded attachments of a board's cards to the
+// archive, only those uploaded after since unless it is zero.
+func backupAttachments(w *backup.Writer, snap *snapshot.Board, since time.Time) error {
+	for _, c := range snap.Cards {
+		for _, a := range c.Attachments {
+			if !a.IsUpload || !isAfter(a.Date, since) {
+				continue
+			}
+			if err := backupAttachment(w, snap.ID, c.ID, a); err != nil {
+				return fmt.Errorf("attachment %s on %s: %w", a.Name, quoteName(c.Name), err)
+			}
+		}
+	}
+	return nil
+}
+
+// backupAttachment downloads one attachment to a temporary file, whose size
+// the archive needs before the content, and adds it.
+func backupAttachment(w *backup.Writer, boardID, cardID string, a api.Attachment) error {
+	tmp, err := os.CreateTemp("", "trello-backup-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	fileName := strutil.FirstNonEmpty(a.FileName, a.Name)
+	n, err := client.DownloadAttachment(cardID, a.ID, fileName, tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return w.AddAttachment(boardID, cardID, a.ID, fileName, n, tmp)
+}
+
+// ---- restore ----
+
+var (
+	restoreInto      string
+	restoreBoard     string
+	restoreWorkspace string
+	restorePrivacy   string
+	restoreMembers   bool
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <archive>",
+	Short: "Restore a board from a backup archive into a new board",
+	Long: `Rebuild a board from an archive written by "trello backup": a new board
+with the labels, custom fields, and lists of the original (archived ones
+archived again), and its cards with their descriptions, dates, labels,
+members, custom field values, checklists, comments, and attachments.
+
+Comments are re-posted by you, as text naming their original author and
+date. Card members are restored for members of the new board; with
+--members the original board members are added to it first. An
+incremental archive is restored together with its chain of earlier
+archives, which must be where its manifest says.
+
+Examples:
+  trello restore trello-backup-my-project-20260301-090000.tar.gz
+  trello restore backups/project-monday.tar.gz --into "My Project (restored)"
+  trello restore backups/acme.tar.gz --board "Roadmap" --workspace acme --members`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		prefs, err := boardPrivacyPrefs(restorePrivacy)
+		if err != nil {
+			return err
+		}
+		archive, err := backup.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer archive.Close()
+
+		boardID, err := archiveBoardID(archive, restoreBoard)
+		if err != nil {
+			return err
+		}
+		snap, _ := archive.Board(boardID)
+
+		prefs.Set("defaultLists", "false")
+		prefs.Set("defaultLabels", "false")
+		board, err := client.CreateBoard(strutil.FirstNonEmpty(restoreInto, snap.Name), snap.Desc, restoreWorkspace, prefs)
+		if err != nil {
+			return err
+		}
+		r := &restorer{archive: archive, snap: snap, res: &restoreResult{Board: board.ID, BoardURL: board.ShortURL, Source: snap.Name}}
+		if err := r.run(board.ID); err != nil {
+			return fmt.Errorf("restoring into %s (left in place): %w", board.ShortURL, err)
+		}
+
+		res := r.res
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(res, output.IsPretty(cmd))
+		}
+		for _, w := range res.Warnings {
+			fmt.Printf("warning: %s\n", w)
+		}
+		fmt.Printf("Restored %s into %s: %d lists, %d labels, %d custom fields, %d cards, %d checklists, %d comments, %d attachments.\n",
+			res.Source, res.BoardURL, res.Lists, res.Labels, res.CustomFields, res.Cards, res.Checklists, res.Comments, res.Attachments)
+		return nil
+	},
+}
+
+// archiveBoardID picks the board to restore: the only one, or the one
+// named by ref (ID or name).
+func archiveBoardID(a *backup.Archive, ref string) (string, error) {
+	ids := a.BoardIDs()
+	if ref == "" {
+		if len(ids) == 1 {
+			return ids[0], nil
+		}
+		names := make([]string, len(ids))
+		for i, id := range ids {
+			names[i] = a.Boards[id].Name
+		}
+		return "", fmt.Errorf("archive holds %d boards (%s); choose one with --board", len(ids), strings.Join(names, ", "))
+	}
+	for _, id := range ids {
+		if id == ref || strings.EqualFold(a.Boards[id].Name, ref) {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("no board %q in %s", ref, a.Path)
+}
+
+// restoreResult reports what a restore did.
+type restoreResult struct {
+	Board        string   `json:"board"`
+	BoardURL     string   `json:"boardUrl"`
+	Source       string   `json:"source"`
+	Lists        int      `json:"lists"`
+	Labels       int      `json:"labels"`
+	CustomFields int      `json:"customFields"`
+	Cards        int      `json:"cards"`
+	Checklists   int      `json:"checklists"`
+	Comments     int      `json:"comments"`
+	Attachments  int      `json:"attachments"`
+	Warnings     []string `json:"warnings,omitempty"`
+}
+
+// restorer rebuilds an archived board on a new board, mapping the IDs of
+// the original to the created ones.
+type restorer struct {
+	archive *backup.Archive
+	snap    *snapshot.Board
+	res     *restoreResult
+
+	boardID string
+	labels  map[string]string
+	fields  map[string]string
+	options map[string]string
+	lists   map[string]string
+	members map[string]bool
+}
+
+func (r *restorer) warn(format string, args ...any) {
+	r.res.Warnings = append(r.res.Warnings, fmt.Sprintf(format, args...))
+}
+
+func (r *restorer) run(boardID string) error {
+	r.boardID = boardID
+	r.labels, r.fields, r.options, r.lists = map[string]string{}, map[string]string{}, map[string]string{}, map[string]string{}
+
+	if err := r.restoreMembers(); err != nil {
+		return err
+	}
+	for _, l := range r.snap.Labels {
+		created, err := client.CreateLabel(boardID, l.Name, l.Color)
+		if err != nil {
+			return fmt.Errorf("creating label %s: %w", labelName(l.Name, l.Color), err)
+		}
+		r.labels[l.ID] = created.ID
+		r.res.Labels++
+	}
+	if err := r.restoreFields(); err != nil {
+		return err
+	}
+	for _, l := range r.snap.Lists {
+		created, err := client.CreateList(l.Name, boardID, "bottom")
+		if err != nil {
+			return fmt.Errorf("creating list %s: %w", l.Name, err)
+		}
+		r.lists[l.ID] = created.ID
+		r.res.Lists++
+	}
+
+	for _, l := range r.snap.Lists {
+		for _, c := range r.snap.ListCards(l.ID) {
+			if err := r.restoreCard(c); err != nil {
+				return err
+			}
+		}
+	}
+	for _, c := range r.snap.Cards {
+		if _, ok := r.lists[c.IDList]; !ok {
+			r.warn("card %s skipped: its list is not in the backup", quoteName(c.Name))
+		}
+	}
+
+	for _, l := range r.snap.Lists {
+		if l.Closed {
+			if _, err := client.ArchiveList(r.lists[l.ID], true); err != nil {
+				return fmt.Errorf("archiving list %s: %w", l.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// restoreMembers records who can be assigned to cards on the new board,
+// adding the original members first with --members.
+func (r *restorer) restoreMembers() error {
+	r.members = map[string]bool{}
+	if restoreMembers {
+		for _, m := range r.snap.Members {
+			if err := client.SetBoardMember(r.boardID, m.ID, "normal"); err != nil {
+				r.warn("adding member @%s: %v", m.Username, err)
+			}
+		}
+	}
+	members, err := client.GetBoardMembers(r.boardID)
+	if err != nil {
+		return err
+	}
+	for _, m := range members {
+		r.members[m.ID] = true
+	}
+	return nil
+}
+
+func (r *restorer) restoreFields() error {
+	if len(r.snap.CustomFields) == 0 {
+		return nil
+	}
+	// Enabling fails harmlessly when the power-up is already on; a real
+	// problem surfaces when the first field is created.
+	client.EnableBoardPlugin(r.boardID, api.CustomFieldsPluginID)
+	for _, f := range r.snap.CustomFields {
+		options := make([]string, len(f.Options))
+		for i, o := range f.Options {
+			options[i] = o.Value.Text
+		}
+		created, err := client.CreateCustomField(r.boardID, f.Name, f.Type, options, f.Display.CardFront)
+		if err != nil {
+			return fmt.Errorf("creating custom field %s: %w", f.Name, err)
+		}
+		r.fields[f.ID] = created.ID
+		for _, o := range f.Options {
+			for _, co := range created.Options {
+				if co.Value.Text == o.Value.Text {
+					r.options[o.ID] = co.ID
+					break
+				}
+			}
+		}
+		r.res.CustomFields++
+	}
+	return nil
+}
+
+func (r *restorer) restoreCard(c api.Card) error {
+	params := url.Values{}
+	params.Set("pos", "bottom")
+	if c.Due != nil {
+		params.Set("due", *c.Due)
+		params.Set("dueComplete", fmt.Sprintf("%t", c.DueComplete))
+	}
+	if c.Start != nil {
+		params.Set("start", *c.Start)
+	}
+	var labelIDs, memberIDs []string
+	for _, id := range c.IDLabels {
+		if mapped, ok := r.labels[id]; ok {
+			labelIDs = append(labelIDs, mapped)
+		}
+	}
+	for _, id := range c.IDMembers {
+		if r.members[id] {
+			memberIDs = append(memberIDs, id)
+		} else if m, ok := r.snap.Member(id); ok {
+			r.warn("%s: @%s is not a member of the new board", quoteName(c.Name), m.Username)
+		}
+	}
+	if len(labelIDs) > 0 {
+		params.Set("idLabels", strings.Join(labelIDs, ","))
+	}
+	if len(memberIDs) > 0 {
+		params.Set("idMembers", strings.Join(memberIDs, ","))
+	}
+	card, err := client.CreateCard(r.lists[c.IDList], c.Name, c.Desc, params)
+	if err != nil {
+		return fmt.Errorf("creating card %s: %w", quoteName(c.Name), err)
+	}
+	r.res.Cards++
+
+	for _, item := range c.CustomFieldItems {
+		fieldID, ok := r.fields[item.IDCustomField]
+		if !ok {
+			continue
+		}
+		var idValue string
+		if item.IDValue != "" {
+			if idValue, ok = r.options[item.IDValue]; !ok {
+				continue
+			}
+		}
+		if err := client.SetCardCustomField(card.ID, fieldID, item.Value, idValue); err != nil {
+			r.warn("%s: setting custom field: %v", quoteName(c.Name), err)
+		}
+	}
+
+	for _, cl := range r.snap.CardChecklists(c.ID) {
+		checklist, err := client.CreateChecklist(card.ID, cl.Name)
+		if err != nil {
+			return fmt.Errorf("creating checklist %s on %s: %w", cl.Name, quoteName(c.Name), err)
+		}
+		for _, it := range cl.CheckItems {
+			item, err := client.CreateCheckItem(checklist.ID, it.Name)
+			if err != nil {
+				return fmt.Errorf("adding checklist item to %s: %w", quoteName(c.Name), err)
+			}
+			if it.State == "complete" {
+				if _, err := client.UpdateCheckItem(card.ID, checklist.ID, item.ID, "complete"); err != nil {
+					return fmt.Errorf("completing checklist item on %s: %w", quoteName(c.Name), err)
+				}
+			}
+		}
+		r.res.Checklists++
+	}
+
+	for _, a := range r.snap.CardComments(c.ID) {
+		if _, err := client.AddComment(card.ID, restoredComment(a)); err != nil {
+			return fmt.Errorf("adding comment to %s: %w", quoteName(c.Name), err)
+		}
+		r.res.Comments++
+	}
+
+	attachments := append([]api.Attachment(nil), c.Attachments...)
+	sort.SliceStable(attachments, func(i, j int) bool { return attachments[i].Date < attachments[j].Date })
+	for _, a := range attachments {
+		if err := r.restoreAttachment(c, card.ID, a); err != nil {
+			r.warn("%s: attachment %s: %v", quoteName(c.Name), a.Name, err)
+			continue
+		}
+		r.res.Attachments++
+	}
+
+	if c.Closed {
+		if _, err := client.UpdateCard(card.ID, url.Values{"closed": {"true"}}); err != nil {
+			return fmt.Errorf("archiving card %s: %w", quoteName(c.Name), err)
+		}
+	}
+	return nil
+}
+
+// restoreAttachment uploads an archived file, or attaches a link.
+func (r *restorer) restoreAttachment(c api.Card, cardID string, a api.Attachment) error {
+	if !a.IsUpload {
+		_, err := client.AttachURL(cardID, a.URL, url.Values{"name": {a.Name}})
+		return err
+	}
+	fileName := strutil.FirstNonEmpty(a.FileName, a.Name)
+	path, ok := r.archive.AttachmentFile(r.snap.ID, c.ID, a.ID, fileName)
+	if !ok {
+		return fmt.Errorf("file not in the backup")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	params := url.Values{"name": {a.Name}}
+	if a.MimeType != "" {
+		params.Set("mimeType", a.MimeType)
+	}
+	_, err = client.UploadAttachment(cardID, fileName, f, params)
+	return err
+}
+
+// restoredComment renders a comment with its original author and date.
+func restoredComment(a api.Action) string {
+	author := "**" + actionMemberName(a) + "**"
+	if m := a.MemberCreator; m != nil && m.Username != "" && m.Username != actionMemberName(a) {
+		author += " (@" + m.Username + ")"
+	}
+	date := a.Date
+	if t, err := time.Parse(time.RFC3339, a.Date); err == nil {
+		date = t.UTC().Format("2006-01-02 15:04 UTC")
+	}
+	return fmt.Sprintf("%s on %s:\n\n%s", author, date, a.Data.Text)
+}
+
+func init() {
+	backupCmd.Flags().StringVar(&backupWorkspace, "workspace", "", "Back up every board of this workspace (ID or name)")
+	backupCmd.Flags().StringVar(&backupOut, "out", "", "Archive path (default: trello-backup-<name>-<time>.tar.gz)")
+	backupCmd.Flags().StringVar(&backupSince, "since", "", "Earlier archive to make an incremental backup against")
+	backupCmd.Flags().IntVar(&backupMaxActions, "max-actions", 0, "Newest actions to keep besides comments in a full backup (0 = all)")
+	rootCmd.AddCommand(backupCmd)
+
+	restoreCmd.Flags().StringVar(&restoreInto, "into", "", "Name of the new board (default: the original name)")
+	restoreCmd.Flags().StringVar(&restoreBoard, "board", "", "Board to restore from a multi-board archive (ID or name)")
+	restoreCmd.Flags().StringVar(&restoreWorkspace, "workspace", "", "Workspace/organization ID to create the board in")
+	restoreCmd.Flags().StringVar(&restorePrivacy, "privacy", "private", "Board visibility: private, public, or org")
+	restoreCmd.Flags().BoolVar(&restoreMembers, "members", false, "Add the original board members to the new board")
+	rootCmd.AddCommand(restoreCmd)
+}
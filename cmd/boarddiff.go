@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/backup"
+	"github.com/the20100/trello-cli/internal/boarddiff"
+	"github.com/the20100/trello-cli/internal/output"
+	"github.com/the20100/trello-cli/internal/snapshot"
+	"github.com/the20100/trello-cli/internal/textdiff"
+)
+
+var (
+	boardsDiffArchived bool
+	boardsDiffBoard    string
+)
+
+var boardsDiffCmd = &cobra.Command{
+	Use:   "diff <a> <b>",
+	Short: "Compare two boards, or a board and a snapshot",
+	Long: `Compare lists, labels, and cards between two boards. Each side is a board
+(ID, short link, URL, or name), a board.json written by "trello boards
+export", or a backup archive from "trello backup" (pick the board of a
+multi-board archive with --board).
+
+Lists and labels are matched by ID, then by name. Cards are matched by ID,
+then by the external-ID marker left by "trello boards import", then by name
+(preferring the same list). Reported per card: added, removed, moved, and
+changes to the name, description, dates, labels, members, archived state,
+and checklist items. Archived lists and cards are compared with --archived.
+
+Exit status is 0 when the boards match, 2 when they differ, and 1 on error.
+
+Examples:
+  trello boards diff "Sprint 11" "Sprint 12"
+  trello boards diff abc123 ./export/board.json
+  trello boards diff backups/project.tar.gz abc123 --archived
+  trello boards diff abc123 def456 --json`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		a, err := loadDiffSide(args[0])
+		if err != nil {
+			return err
+		}
+		b, err := loadDiffSide(args[1])
+		if err != nil {
+			return err
+		}
+		res := boarddiff.Compare(a, b, boarddiff.Options{Archived: boardsDiffArchived})
+
+		if output.IsJSON(cmd) {
+			err = output.PrintJSON(map[string]any{
+				"a":      map[string]string{"id": a.ID, "name": a.Name, "source": args[0]},
+				"b":      map[string]string{"id": b.ID, "name": b.Name, "source": args[1]},
+				"lists":  res.Lists,
+				"labels": res.Labels,
+				"cards":  res.Cards,
+			}, output.IsPretty(cmd))
+			if err != nil {
+				return err
+			}
+		} else {
+			printBoardDiff(a, b, args, res)
+		}
+		if !res.Empty() {
+			cmd.SilenceErrors = true
+			return &exitError{code: 2}
+		}
+		return nil
+	},
+}
+
+// loadDiffSide loads one side of a diff: a snapshot file, a backup
+// archive, or a live board.
+func loadDiffSide(ref string) (*snapshot.Board, error) {
+	info, err := os.Stat(ref)
+	if err != nil || info.IsDir() {
+		boardID, err := resolveBoardID(ref)
+		if err != nil {
+			return nil, err
+		}
+		return fetchBoardContent(boardID, boardsDiffArchived)
+	}
+	if strings.HasSuffix(ref, ".tar.gz") || strings.HasSuffix(ref, ".tgz") {
+		archive, err := backup.Open(ref)
+		if err != nil {
+			return nil, err
+		}
+		defer archive.Close()
+		id, err := archiveBoardID(archive, boardsDiffBoard)
+		if err != nil {
+			return nil, err
+		}
+		b, _ := archive.Board(id)
+		return b, nil
+	}
+	return snapshot.Load(ref)
+}
+
+// printBoardDiff prints a diff as unified text: "+" added, "-" removed,
+// ">" moved, "~" changed, with field changes indented below.
+func printBoardDiff(a, b *snapshot.Board, args []string, res *boarddiff.Result) {
+	fmt.Printf("--- %s (%s)\n", a.Name, args[0])
+	fmt.Printf("+++ %s (%s)\n", b.Name, args[1])
+	if res.Empty() {
+		fmt.Println("\nNo differences.")
+		return
+	}
+	section := func(title, kind string, entries []boarddiff.Entry) {
+		if len(entries) == 0 {
+			return
+		}
+		fmt.Printf("\n%s:\n", title)
+		for _, e := range entries {
+			fmt.Println("  " + describeDiffEntry(kind, e))
+			for _, f := range e.Fields {
+				printDiffField(f)
+			}
+		}
+	}
+	section("Lists", "list", res.Lists)
+	section("Labels", "label", res.Labels)
+	section("Cards", "card", res.Cards)
+
+	counts := map[string]int{}
+	for _, e := range res.Cards {
+		counts[e.Change]++
+	}
+	fmt.Printf("\n%d lists, %d labels differ; cards: %d added, %d removed, %d moved, %d changed.\n",
+		len(res.Lists), len(res.Labels), counts[boarddiff.Added], counts[boarddiff.Removed],
+		counts[boarddiff.Moved], counts[boarddiff.Changed])
+}
+
+func describeDiffEntry(kind string, e boarddiff.Entry) string {
+	marker := map[string]string{
+		boarddiff.Added:   "+",
+		boarddiff.Removed: "-",
+		boarddiff.Moved:   ">",
+		boarddiff.Changed: "~",
+	}[e.Change]
+	s := marker + " " + kind + " " + quoteName(e.Name)
+	switch {
+	case e.Change == boarddiff.Moved:
+		s += " moved " + quoteName(e.FromList) + " → " + quoteName(e.List)
+	case e.List != "":
+		s += " in " + quoteName(e.List)
+	}
+	return s
+}
+
+func printDiffField(f boarddiff.Field) {
+	if f.Field != "desc" {
+		fmt.Printf("      %s: %s → %s\n", f.Field, displayValue(f.Old), displayValue(f.New))
+		return
+	}
+	fmt.Println("      desc:")
+	for _, line := range textdiff.Unified(f.Old, f.New, 2) {
+		fmt.Println("        " + line)
+	}
+}
+
+func init() {
+	boardsDiffCmd.Flags().BoolVar(&boardsDiffArchived, "archived", false, "Compare archived lists and cards too")
+	boardsDiffCmd.Flags().StringVar(&boardsDiffBoard, "board", "", "Board to use from a multi-board backup archive (ID or name)")
+	boardsCmd.AddCommand(boardsDiffCmd)
+}
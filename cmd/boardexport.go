@@ -0,0 +1,544 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/api"
+	"github.com/the20100/trello-cli/internal/output"
+	"github.com/the20100/trello-cli/internal/snapshot"
+)
+
+var (
+	boardsExportFormat     string
+	boardsExportOut        string
+	boardsExportArchived   bool
+	boardsExportMaxActions int
+)
+
+var boardsExportCmd = &cobra.Command{
+	Use:   "export <board>",
+	Short: "Export a board to JSON, Markdown, or CSV files",
+	Long: `Snapshot a board into a directory: its lists, cards with descriptions,
+checklists, comments, attachment metadata, labels, members, custom fields,
+and activity.
+
+Formats:
+  json       board.json, in the shape of Trello's own JSON export
+  markdown   README.md with the board overview, and one file per list with
+             a section per card — readable and diffable in a Git repo
+  csv        cards.csv (one row per card, a column per custom field),
+             checklists.csv, and comments.csv
+
+Archived lists and cards are included with --archived. Comments are always
+exported in full; other activity is limited to the newest --max-actions
+actions (0 for all).
+
+Examples:
+  trello boards export "My Project"
+  trello boards export abc123 --format markdown --out ./docs/board
+  trello boards export abc123 --format csv --out /tmp/export
+  trello boards export abc123 --archived --max-actions 0`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch boardsExportFormat {
+		case "json", "markdown", "csv":
+		default:
+			return fmt.Errorf("invalid --format %q: use json, markdown, or csv", boardsExportFormat)
+		}
+		boardID, err := resolveBoardID(args[0])
+		if err != nil {
+			return err
+		}
+		snap, err := fetchBoardSnapshot(boardID, boardsExportArchived, boardsExportMaxActions)
+		if err != nil {
+			return err
+		}
+
+		dir := boardsExportOut
+		if dir == "" {
+			dir = slugify(snap.Name)
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		var files []string
+		switch boardsExportFormat {
+		case "json":
+			path := filepath.Join(dir, "board.json")
+			files = []string{path}
+			err = snap.Save(path)
+		case "markdown":
+			files, err = writeMarkdownExport(snap, dir)
+		case "csv":
+			files, err = writeCSVExport(snap, dir)
+		}
+		if err != nil {
+			return err
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(map[string]any{"board": snap.ID, "dir": dir, "files": files}, output.IsPretty(cmd))
+		}
+		fmt.Printf("Exported %s to %s: %d lists, %d cards, %d actions.\n",
+			snap.Name, dir, len(snap.Lists), len(snap.Cards), len(snap.Actions))
+		for _, f := range files {
+			fmt.Printf("  %s\n", f)
+		}
+		return nil
+	},
+}
+
+// maxCardsPage is the largest page the board cards endpoint returns.
+const maxCardsPage = 1000
+
+// fetchBoardSnapshot fetches everything on a board: the structure in one
+// batch request, then cards and actions page by page. With archived, closed
+// lists and cards are included. Comments are always fetched in full; other
+// actions are limited to the newest maxActions (0 for all).
+func fetchBoardSnapshot(boardID string, archived bool, maxActions int) (*snapshot.Board, error) {
+	snap, err := fetchBoardContent(boardID, archived)
+	if err != nil {
+		return nil, err
+	}
+	if snap.Actions, err = fetchBoardActions(boardID, maxActions); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// fetchBoardContent fetches a board's structure and its cards with their
+// attachments, custom field values, and checklists, but no actions.
+func fetchBoardContent(boardID string, archived bool) (*snapshot.Board, error) {
+	filter := "open"
+	if archived {
+		filter = "all"
+	}
+	snap, err := fetchBoardStructure(boardID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	snap.Cards, err = fetchBoardCards(boardID, url.Values{
+		"filter":           {filter},
+		"attachments":      {"true"},
+		"customFieldItems": {"true"},
+		"checklists":       {"all"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	liftChecklists(snap)
+	snap.Sort()
+	return snap, nil
+}
+
+// liftChecklists moves the checklists fetched with cards to the board, as
+// in Trello's export format.
+func liftChecklists(snap *snapshot.Board) {
+	for i := range snap.Cards {
+		c := &snap.Cards[i]
+		for _, cl := range c.Checklists {
+			cl.IDCard = c.ID
+			snap.Checklists = append(snap.Checklists, cl)
+		}
+		c.Checklists = nil
+	}
+}
+
+// fetchBoardStructure fetches a board with its lists (open or all, per
+// filter), labels, members, and custom fields in one batch request.
+func fetchBoardStructure(boardID, filter string) (*snapshot.Board, error) {
+	snap := &snapshot.Board{ExportVersion: snapshot.Version, ExportedAt: time.Now().UTC().Format(time.RFC3339)}
+
+	base := "/boards/" + boardID
+	results, errs, err := client.Batch([]string{
+		base,
+		base + "/lists?filter=" + filter,
+		base + "/labels?limit=1000",
+		base + "/members",
+		base + "/customFields",
+	})
+	if err != nil {
+		return nil, err
+	}
+	targets := []any{&snap.Board, &snap.Lists, &snap.Labels, &snap.Members, &snap.CustomFields}
+	for i, target := range targets {
+		if errs[i] != nil {
+			// Boards without the Custom Fields power-up answer 404 or 400.
+			if i == 4 && isNotFound(errs[i]) {
+				continue
+			}
+			return nil, errs[i]
+		}
+		if err := json.Unmarshal(results[i], target); err != nil {
+			return nil, fmt.Errorf("decoding board data: %w", err)
+		}
+	}
+	return snap, nil
+}
+
+// fetchBoardCards pages through the cards of a board with the given query
+// parameters.
+func fetchBoardCards(boardID string, query url.Values) ([]api.Card, error) {
+	params := url.Values{"limit": {fmt.Sprintf("%d", maxCardsPage)}}
+	for k, v := range query {
+		params[k] = v
+	}
+	var all []api.Card
+	for {
+		cards, err := client.GetBoardCardsWithParams(boardID, params)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, cards...)
+		if len(cards) < maxCardsPage {
+			return all, nil
+		}
+		// Card IDs grow with creation time; continue before the oldest.
+		oldest := cards[0].ID
+		for _, c := range cards {
+			if c.ID < oldest {
+				oldest = c.ID
+			}
+		}
+		params.Set("before", oldest)
+	}
+}
+
+// fetchBoardActions returns all comments plus the newest maxActions actions
+// of any type (all of them when maxActions is 0), newest first.
+func fetchBoardActions(boardID string, maxActions int) ([]api.Action, error) {
+	actions, err := fetchActions("boards", boardID, url.Values{"filter": {"all"}}, maxActions)
+	if err != nil {
+		return nil, err
+	}
+	if maxActions == 0 || len(actions) < maxActions {
+		return actions, nil
+	}
+	comments, err := fetchActions("boards", boardID, url.Values{"filter": {"commentCard"}}, 0)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	for _, a := range actions {
+		seen[a.ID] = true
+	}
+	for _, c := range comments {
+		if !seen[c.ID] {
+			actions = append(actions, c)
+		}
+	}
+	sort.SliceStable(actions, func(i, j int) bool { return actions[i].Date > actions[j].Date })
+	return actions, nil
+}
+
+// slugify turns a name into a lowercase file name: letters and digits, with
+// runs of anything else replaced by a single dash.
+func slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	s := strings.TrimSuffix(b.String(), "-")
+	if s == "" {
+		return "board"
+	}
+	return s
+}
+
+// ---- Markdown ----
+
+// writeMarkdownExport writes README.md and one NN-list-name.md per list.
+func writeMarkdownExport(snap *snapshot.Board, dir string) ([]string, error) {
+	var files []string
+	var index strings.Builder
+	fmt.Fprintf(&index, "# %s\n\n", snap.Name)
+	if d := strings.TrimSpace(snap.Desc); d != "" {
+		fmt.Fprintf(&index, "%s\n\n", d)
+	}
+	fmt.Fprintf(&index, "Board: %s\n\n## Lists\n\n", snap.ShortURL)
+
+	for i, l := range snap.Lists {
+		name := fmt.Sprintf("%02d-%s.md", i+1, slugify(l.Name))
+		cards := snap.ListCards(l.ID)
+		suffix := ""
+		if l.Closed {
+			suffix = " (archived)"
+		}
+		fmt.Fprintf(&index, "%d. [%s](%s)%s — %d cards\n", i+1, l.Name, name, suffix, len(cards))
+
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(markdownList(snap, l, cards)), 0644); err != nil {
+			return files, err
+		}
+		files = append(files, path)
+	}
+
+	if len(snap.Labels) > 0 {
+		index.WriteString("\n## Labels\n\n")
+		for _, l := range snap.Labels {
+			if l.Name != "" && l.Color != "" {
+				fmt.Fprintf(&index, "- %s (%s)\n", l.Name, l.Color)
+			} else {
+				fmt.Fprintf(&index, "- %s\n", labelName(l.Name, l.Color))
+			}
+		}
+	}
+	if len(snap.Members) > 0 {
+		index.WriteString("\n## Members\n\n")
+		for _, m := range snap.Members {
+			fmt.Fprintf(&index, "- %s (@%s)\n", m.FullName, m.Username)
+		}
+	}
+	if len(snap.CustomFields) > 0 {
+		index.WriteString("\n## Custom fields\n\n")
+		for _, f := range snap.CustomFields {
+			var opts []string
+			for _, o := range f.Options {
+				opts = append(opts, o.Value.Text)
+			}
+			if len(opts) > 0 {
+				fmt.Fprintf(&index, "- %s (%s: %s)\n", f.Name, f.Type, strings.Join(opts, ", "))
+			} else {
+				fmt.Fprintf(&index, "- %s (%s)\n", f.Name, f.Type)
+			}
+		}
+	}
+
+	path := filepath.Join(dir, "README.md")
+	if err := os.WriteFile(path, []byte(index.String()), 0644); err != nil {
+		return files, err
+	}
+	return append([]string{path}, files...), nil
+}
+
+// markdownList renders a list as a document with one section per card.
+func markdownList(snap *snapshot.Board, l api.TrelloList, cards []api.Card) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", l.Name)
+	if len(cards) == 0 {
+		b.WriteString("\nNo cards.\n")
+	}
+	for _, c := range cards {
+		heading := c.Name
+		if c.Closed {
+			heading += " (archived)"
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n", heading)
+
+		for _, kv := range cardMetadata(snap, c) {
+			fmt.Fprintf(&b, "- **%s:** %s\n", kv[0], kv[1])
+		}
+		if d := strings.TrimSpace(c.Desc); d != "" {
+			fmt.Fprintf(&b, "\n%s\n", d)
+		}
+
+		for _, cl := range snap.CardChecklists(c.ID) {
+			fmt.Fprintf(&b, "\n### %s\n\n", cl.Name)
+			for _, it := range cl.CheckItems {
+				mark := " "
+				if it.State == "complete" {
+					mark = "x"
+				}
+				fmt.Fprintf(&b, "- [%s] %s\n", mark, it.Name)
+			}
+		}
+
+		if len(c.Attachments) > 0 {
+			b.WriteString("\n### Attachments\n\n")
+			for _, a := range c.Attachments {
+				if a.Bytes > 0 {
+					fmt.Fprintf(&b, "- [%s](%s) (%s)\n", a.Name, a.URL, output.FormatBytes(a.Bytes))
+				} else {
+					fmt.Fprintf(&b, "- [%s](%s)\n", a.Name, a.URL)
+				}
+			}
+		}
+
+		if comments := snap.CardComments(c.ID); len(comments) > 0 {
+			b.WriteString("\n### Comments\n")
+			for _, a := range comments {
+				b.WriteString("\n")
+				for _, line := range strings.Split(strings.TrimSpace(a.Data.Text), "\n") {
+					fmt.Fprintf(&b, "> %s\n", line)
+				}
+				fmt.Fprintf(&b, ">\n> — %s, %s\n", actionMemberName(a), output.FormatTime(a.Date))
+			}
+		}
+	}
+	return b.String()
+}
+
+// cardMetadata returns the label/value pairs shown for a card: labels,
+// members, dates, custom fields, and URL.
+func cardMetadata(snap *snapshot.Board, c api.Card) [][2]string {
+	var rows [][2]string
+	if names := cardLabelNames(snap, c); len(names) > 0 {
+		rows = append(rows, [2]string{"Labels", strings.Join(names, ", ")})
+	}
+	if names := cardMemberNames(snap, c); len(names) > 0 {
+		rows = append(rows, [2]string{"Members", strings.Join(names, ", ")})
+	}
+	if c.Start != nil && *c.Start != "" {
+		rows = append(rows, [2]string{"Start", output.FormatTime(*c.Start)})
+	}
+	if c.Due != nil && *c.Due != "" {
+		due := output.FormatTime(*c.Due)
+		if c.DueComplete {
+			due += " (complete)"
+		}
+		rows = append(rows, [2]string{"Due", due})
+	}
+	for _, f := range snap.CustomFields {
+		if it, ok := customFieldItem(c, f); ok {
+			rows = append(rows, [2]string{f.Name, formatCustomFieldValue(f, it)})
+		}
+	}
+	if c.ShortURL != "" {
+		rows = append(rows, [2]string{"URL", c.ShortURL})
+	}
+	return rows
+}
+
+func cardLabelNames(snap *snapshot.Board, c api.Card) []string {
+	var names []string
+	for _, l := range c.Labels {
+		names = append(names, labelName(l.Name, l.Color))
+	}
+	if len(names) == 0 {
+		for _, id := range c.IDLabels {
+			if l, ok := snap.Label(id); ok {
+				names = append(names, labelName(l.Name, l.Color))
+			}
+		}
+	}
+	return names
+}
+
+func cardMemberNames(snap *snapshot.Board, c api.Card) []string {
+	var names []string
+	for _, id := range c.IDMembers {
+		if m, ok := snap.Member(id); ok {
+			names = append(names, "@"+m.Username)
+		} else {
+			names = append(names, id)
+		}
+	}
+	return names
+}
+
+// ---- CSV ----
+
+// writeCSVExport writes cards.csv, checklists.csv, and comments.csv.
+func writeCSVExport(snap *snapshot.Board, dir string) ([]string, error) {
+	listNames := map[string]string{}
+	for _, l := range snap.Lists {
+		listNames[l.ID] = l.Name
+	}
+
+	header := []string{"id", "number", "list", "name", "description", "labels", "members", "start", "due", "due_complete", "archived", "attachments", "url"}
+	for _, f := range snap.CustomFields {
+		header = append(header, f.Name)
+	}
+	cards := [][]string{header}
+	for _, c := range snap.Cards {
+		row := []string{
+			c.ID,
+			fmt.Sprintf("%d", c.IDShort),
+			listNames[c.IDList],
+			c.Name,
+			c.Desc,
+			strings.Join(cardLabelNames(snap, c), ", "),
+			strings.Join(cardMemberNames(snap, c), ", "),
+			csvDate(c.Start),
+			csvDate(c.Due),
+			fmt.Sprintf("%t", c.DueComplete),
+			fmt.Sprintf("%t", c.Closed),
+			fmt.Sprintf("%d", len(c.Attachments)),
+			c.ShortURL,
+		}
+		for _, f := range snap.CustomFields {
+			value := ""
+			if it, ok := customFieldItem(c, f); ok {
+				value = formatCustomFieldValue(f, it)
+			}
+			row = append(row, value)
+		}
+		cards = append(cards, row)
+	}
+
+	checklists := [][]string{{"card_id", "card", "checklist", "item", "complete"}}
+	comments := [][]string{{"card_id", "card", "date", "author", "text"}}
+	for _, c := range snap.Cards {
+		for _, cl := range snap.CardChecklists(c.ID) {
+			for _, it := range cl.CheckItems {
+				checklists = append(checklists, []string{c.ID, c.Name, cl.Name, it.Name, fmt.Sprintf("%t", it.State == "complete")})
+			}
+		}
+		for _, a := range snap.CardComments(c.ID) {
+			comments = append(comments, []string{c.ID, c.Name, a.Date, actionMemberName(a), a.Data.Text})
+		}
+	}
+
+	var files []string
+	for _, f := range []struct {
+		name string
+		rows [][]string
+	}{
+		{"cards.csv", cards},
+		{"checklists.csv", checklists},
+		{"comments.csv", comments},
+	} {
+		path := filepath.Join(dir, f.name)
+		if err := writeCSVFile(path, f.rows); err != nil {
+			return files, err
+		}
+		files = append(files, path)
+	}
+	return files, nil
+}
+
+func writeCSVFile(path string, rows [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	if err := w.WriteAll(rows); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func csvDate(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func init() {
+	boardsExportCmd.Flags().StringVar(&boardsExportFormat, "format", "json", "Export format: json, markdown, or csv")
+	boardsExportCmd.Flags().StringVar(&boardsExportOut, "out", "", "Output directory (default: the board name as a slug)")
+	boardsExportCmd.Flags().BoolVar(&boardsExportArchived, "archived", false, "Include archived lists and cards")
+	boardsExportCmd.Flags().IntVar(&boardsExportMaxActions, "max-actions", 1000, "Newest actions to export besides comments (0 = all)")
+	boardsCmd.AddCommand(boardsExportCmd)
+}
@@ -0,0 +1,347 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/api"
+	"github.com/the20100/trello-cli/internal/importer"
+	"github.com/the20100/trello-cli/internal/output"
+	"github.com/the20100/trello-cli/internal/snapshot"
+	"github.com/the20100/trello-cli/internal/strutil"
+)
+
+var (
+	boardsImportFrom        string
+	boardsImportBoard       string
+	boardsImportName        string
+	boardsImportFormat      string
+	boardsImportMap         map[string]string
+	boardsImportDefaultList string
+	boardsImportArchived    bool
+	boardsImportUpdate      bool
+)
+
+var boardsImportCmd = &cobra.Command{
+	Use:   "import --from <file|dir> --board <board|new>",
+	Short: "Import cards from a Trello JSON export, CSV, or Markdown",
+	Long: `Create lists, labels, and cards on a board from another source. Use
+--board new to create a board, named by --name or by the source.
+
+Sources (detected from the file extension, or set with --format):
+  trello     a board JSON export, from Trello or "trello boards export"
+  csv        a header row and one card per row; columns are matched by name
+             (name/title, desc/description, list/status, labels, due,
+             members/assignee, checklist, id), or mapped with --map
+  markdown   a .md file or a directory of them, one list per file: task list
+             items ("- [ ] Fix login @alice #bug due:2026-05-01") or
+             "## Card" sections as written by "boards export --format markdown"
+
+Imports are idempotent: each card's description ends with an invisible
+external-ID marker ([//]: # (external-id: ...)) — the source card ID, the
+CSV id column, or a hash of list and card name — and cards whose marker is
+already on the board are skipped; with --update their name, description,
+list, labels, members, and due date are refreshed. Lists and labels are
+matched by name and only created when missing.
+
+Examples:
+  trello boards import --from export.json --board new
+  trello boards import --from tasks.csv --board "Roadmap" --map name=Summary --map list=Status
+  trello boards import --from ./notes --board abc123
+  trello boards import --from tasks.csv --board abc123 --default-list Inbox
+  trello boards import --from tasks.csv --board abc123 --update`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if boardsImportFrom == "" || boardsImportBoard == "" {
+			return fmt.Errorf("--from and --board are required")
+		}
+		doc, err := loadImportDoc(boardsImportFrom, boardsImportFormat)
+		if err != nil {
+			return err
+		}
+		if len(doc.Cards) == 0 && len(doc.Lists) == 0 {
+			return fmt.Errorf("nothing to import in %s", boardsImportFrom)
+		}
+
+		res := &importResult{}
+		var boardID string
+		if boardsImportBoard == "new" {
+			name := strutil.FirstNonEmpty(boardsImportName, doc.Name,
+				strings.TrimSuffix(filepath.Base(boardsImportFrom), filepath.Ext(boardsImportFrom)))
+			board, err := client.CreateBoard(name, "", "", url.Values{
+				"defaultLists":  {"false"},
+				"defaultLabels": {"false"},
+			})
+			if err != nil {
+				return err
+			}
+			boardID, res.BoardURL = board.ID, board.ShortURL
+			res.Created = append(res.Created, "board "+board.Name)
+		} else if boardID, err = resolveBoardID(boardsImportBoard); err != nil {
+			return err
+		}
+		res.Board = boardID
+
+		if err := importDoc(boardID, doc, boardsImportUpdate, res); err != nil {
+			return err
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(res, output.IsPretty(cmd))
+		}
+		for _, c := range res.Created {
+			fmt.Printf("created %s\n", c)
+		}
+		for _, w := range res.Warnings {
+			fmt.Printf("warning: %s\n", w)
+		}
+		fmt.Printf("Imported into %s: %d cards created, %d updated, %d already present.\n",
+			strutil.FirstNonEmpty(res.BoardURL, boardID), res.CardsCreated, res.CardsUpdated, res.CardsSkipped)
+		return nil
+	},
+}
+
+// importResult reports what an import did.
+type importResult struct {
+	Board        string   `json:"board"`
+	BoardURL     string   `json:"boardUrl,omitempty"`
+	Created      []string `json:"created,omitempty"`
+	CardsCreated int      `json:"cardsCreated"`
+	CardsUpdated int      `json:"cardsUpdated"`
+	CardsSkipped int      `json:"cardsSkipped"`
+	Warnings     []string `json:"warnings,omitempty"`
+}
+
+func (r *importResult) warn(format string, args ...any) {
+	w := fmt.Sprintf(format, args...)
+	if !containsString(r.Warnings, w) {
+		r.Warnings = append(r.Warnings, w)
+	}
+}
+
+// loadImportDoc reads a source in the given format, or detects the format
+// from the path when format is empty or "auto".
+func loadImportDoc(path, format string) (*importer.Doc, error) {
+	if format == "" || format == "auto" {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		switch ext := strings.ToLower(filepath.Ext(path)); {
+		case info.IsDir() || ext == ".md" || ext == ".markdown":
+			format = "markdown"
+		case ext == ".json":
+			format = "trello"
+		case ext == ".csv":
+			format = "csv"
+		default:
+			return nil, fmt.Errorf("cannot tell the format of %s: pass --format trello, csv, or markdown", path)
+		}
+	}
+
+	switch format {
+	case "trello":
+		b, err := snapshot.Load(path)
+		if err != nil {
+			return nil, err
+		}
+		return importer.FromTrello(b, boardsImportArchived), nil
+	case "csv":
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return importer.FromCSV(f, boardsImportMap, boardsImportDefaultList)
+	case "markdown":
+		return importer.FromMarkdown(path)
+	}
+	return nil, fmt.Errorf("invalid --format %q: use auto, trello, csv, or markdown", format)
+}
+
+// importDoc creates the lists, labels, and cards of doc on a board, skipping
+// (or with update, refreshing) cards whose external ID is already there.
+func importDoc(boardID string, doc *importer.Doc, update bool, res *importResult) error {
+	lists, err := client.GetBoardLists(boardID, "open")
+	if err != nil {
+		return err
+	}
+	listIDs := map[string]string{}
+	for _, l := range lists {
+		if _, ok := listIDs[strings.ToLower(l.Name)]; !ok {
+			listIDs[strings.ToLower(l.Name)] = l.ID
+		}
+	}
+	for _, name := range doc.Lists {
+		if _, ok := listIDs[strings.ToLower(name)]; ok {
+			continue
+		}
+		l, err := client.CreateList(name, boardID, "bottom")
+		if err != nil {
+			return fmt.Errorf("creating list %s: %w", name, err)
+		}
+		listIDs[strings.ToLower(name)] = l.ID
+		res.Created = append(res.Created, "list "+name)
+	}
+
+	labels, err := client.GetBoardLabels(boardID)
+	if err != nil {
+		return err
+	}
+	for _, l := range doc.Labels {
+		if _, ok := matchLabel(labels, api.Label{Name: l.Name, Color: l.Color}); ok {
+			continue
+		}
+		created, err := client.CreateLabel(boardID, l.Name, l.Color)
+		if err != nil {
+			return fmt.Errorf("creating label %s: %w", labelName(l.Name, l.Color), err)
+		}
+		labels = append(labels, *created)
+		res.Created = append(res.Created, "label "+labelName(l.Name, l.Color))
+	}
+
+	members, err := client.GetBoardMembers(boardID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := client.GetBoardCardsWithParams(boardID, url.Values{
+		"filter": {"all"},
+		"fields": {"name,desc,idList,closed"},
+	})
+	if err != nil {
+		return err
+	}
+	byExternalID := map[string]api.Card{}
+	for _, c := range existing {
+		if id := importer.ExternalID(c.Desc); id != "" {
+			byExternalID[id] = c
+		}
+	}
+
+	for _, c := range doc.Cards {
+		params, err := importCardParams(c, listIDs, labels, members, res)
+		if err != nil {
+			return err
+		}
+		if have, ok := byExternalID[c.ExternalID]; ok {
+			if !update {
+				res.CardsSkipped++
+				continue
+			}
+			params.Set("name", c.Name)
+			if _, err := client.UpdateCard(have.ID, params); err != nil {
+				return fmt.Errorf("updating card %s: %w", quoteName(c.Name), err)
+			}
+			res.CardsUpdated++
+			continue
+		}
+
+		listID := params.Get("idList")
+		params.Del("idList")
+		params.Set("pos", "bottom")
+		desc := params.Get("desc")
+		params.Del("desc")
+		card, err := client.CreateCard(listID, c.Name, desc, params)
+		if err != nil {
+			return fmt.Errorf("creating card %s: %w", quoteName(c.Name), err)
+		}
+		if err := importChecklists(card.ID, c); err != nil {
+			return err
+		}
+		byExternalID[c.ExternalID] = *card
+		res.CardsCreated++
+	}
+	return nil
+}
+
+// importCardParams returns the card fields of an imported card: idList,
+// desc with the external-ID marker, idLabels, idMembers, and due.
+func importCardParams(c importer.Card, listIDs map[string]string, labels []api.Label, members []api.Member, res *importResult) (url.Values, error) {
+	params := url.Values{}
+	listID, ok := listIDs[strings.ToLower(c.List)]
+	if !ok {
+		return nil, fmt.Errorf("card %s: no list %s", quoteName(c.Name), quoteName(c.List))
+	}
+	params.Set("idList", listID)
+	params.Set("desc", importer.WithMarker(c.Desc, c.ExternalID))
+
+	var labelIDs []string
+	for _, ref := range c.Labels {
+		if l, ok := findLabel(labels, ref); ok {
+			labelIDs = append(labelIDs, l.ID)
+		}
+	}
+	params.Set("idLabels", strings.Join(labelIDs, ","))
+
+	var memberIDs []string
+	for _, ref := range c.Members {
+		if m, ok := findMember(members, ref); ok {
+			memberIDs = append(memberIDs, m.ID)
+		} else {
+			res.warn("member @%s is not on the board; not assigned", strings.TrimPrefix(ref, "@"))
+		}
+	}
+	params.Set("idMembers", strings.Join(memberIDs, ","))
+
+	if c.Due != "" {
+		due, err := parseImportDate(c.Due)
+		if err != nil {
+			res.warn("card %s: ignoring due date %q", quoteName(c.Name), c.Due)
+		} else {
+			params.Set("due", due.UTC().Format(time.RFC3339))
+			params.Set("dueComplete", fmt.Sprintf("%t", c.DueComplete))
+		}
+	}
+	return params, nil
+}
+
+// parseImportDate parses the date formats found in imports: ISO-8601,
+// "YYYY-MM-DD HH:MM" (UTC, as exported), and YYYY-MM-DD.
+func parseImportDate(s string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02T15:04:05.000Z", "2006-01-02 15:04"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return parseDateFlag(s)
+}
+
+// importChecklists creates the checklists of an imported card.
+func importChecklists(cardID string, c importer.Card) error {
+	for _, cl := range c.Checklists {
+		checklist, err := client.CreateChecklist(cardID, cl.Name)
+		if err != nil {
+			return fmt.Errorf("creating checklist %s on %s: %w", cl.Name, quoteName(c.Name), err)
+		}
+		for _, it := range cl.Items {
+			item, err := client.CreateCheckItem(checklist.ID, it.Name)
+			if err != nil {
+				return fmt.Errorf("adding checklist item to %s: %w", quoteName(c.Name), err)
+			}
+			if it.Complete {
+				if _, err := client.UpdateCheckItem(cardID, checklist.ID, item.ID, "complete"); err != nil {
+					return fmt.Errorf("completing checklist item on %s: %w", quoteName(c.Name), err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func init() {
+	boardsImportCmd.Flags().StringVar(&boardsImportFrom, "from", "", "Source file or directory (required)")
+	boardsImportCmd.Flags().StringVar(&boardsImportBoard, "board", "", "Target board, or \"new\" to create one (required)")
+	boardsImportCmd.Flags().StringVar(&boardsImportName, "name", "", "Name of the new board with --board new")
+	boardsImportCmd.Flags().StringVar(&boardsImportFormat, "format", "auto", "Source format: auto, trello, csv, markdown")
+	boardsImportCmd.Flags().StringToStringVar(&boardsImportMap, "map", nil, "CSV column for a field, e.g. name=Summary (fields: id, name, desc, list, labels, due, members, checklist)")
+	boardsImportCmd.Flags().StringVar(&boardsImportDefaultList, "default-list", "To Do", "List for CSV rows without a list")
+	boardsImportCmd.Flags().BoolVar(&boardsImportArchived, "archived", false, "Also import archived lists and cards of a Trello export")
+	boardsImportCmd.Flags().BoolVar(&boardsImportUpdate, "update", false, "Refresh cards imported before instead of skipping them")
+	boardsCmd.AddCommand(boardsImportCmd)
+}
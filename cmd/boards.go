@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"net/url"
+	"sort"
 
 	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/boardspec"
 	"github.com/the20100/trello-cli/internal/output"
 )
 
@@ -92,12 +95,67 @@ Examples:
 	},
 }
 
+// ---- boards show ----
+
+var (
+	boardsShowLists    []string
+	boardsShowMaxCards int
+)
+
+var boardsShowCmd = &cobra.Command{
+	Use:   "show <board-id>",
+	Short: "Render a board as kanban columns",
+	Long: `Render a Trello board in the terminal as side-by-side columns, one per
+open list in board order. Each card shows its name, label chips, a due marker
+(⏰ upcoming, ! overdue, ✓ complete) and member initials.
+
+Column widths adapt to the terminal size; columns that do not fit wrap below.
+
+Examples:
+  trello boards show abc123
+  trello boards show abc123 --lists "To Do,Doing"
+  trello boards show abc123 --max-cards 5
+  trello boards show abc123 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lists, err := client.GetBoardLists(args[0], "open")
+		if err != nil {
+			return err
+		}
+		cards, err := client.GetBoardCardsWithParams(args[0], url.Values{
+			"filter":        {"open"},
+			"members":       {"true"},
+			"member_fields": {"fullName,username,initials"},
+		})
+		if err != nil {
+			return err
+		}
+
+		sort.SliceStable(lists, func(i, j int) bool { return lists[i].Pos < lists[j].Pos })
+		sort.SliceStable(cards, func(i, j int) bool { return cards[i].Pos < cards[j].Pos })
+
+		cols, err := buildKanbanColumns(lists, cards, boardsShowLists, boardsShowMaxCards)
+		if err != nil {
+			return err
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(cols, output.IsPretty(cmd))
+		}
+
+		fmt.Print(renderKanban(cols, output.TerminalWidth()))
+		return nil
+	},
+}
+
 // ---- boards create ----
 
 var (
-	boardsCreateDesc string
-	boardsCreateOrg  string
-	boardsCreatePriv string
+	boardsCreateDesc     string
+	boardsCreateOrg      string
+	boardsCreatePriv     string
+	boardsCreateDescFile string
+	boardsCreateTemplate string
 )
 
 var boardsCreateCmd = &cobra.Command{
@@ -109,13 +167,46 @@ Examples:
   trello boards create "My Project"
   trello boards create "My Project" --desc "Project description"
   trello boards create "My Project" --workspace abc123
-  trello boards create "My Project" --privacy private`,
+  trello boards create "My Project" --privacy private
+  trello boards create "Sprint 12" --template sprint
+  trello boards create "Onboarding: ACME" --template ./boards/onboarding.yaml
+
+--template builds the board from a template saved with "trello boards
+template save" (by name or path): its lists, labels, custom fields, and seed
+cards. The template's description is used unless --desc is given.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		board, err := client.CreateBoard(args[0], boardsCreateDesc, boardsCreateOrg, nil)
+		desc, err := resolveText(boardsCreateDesc, boardsCreateDescFile, "desc", false)
+		if err != nil {
+			return err
+		}
+
+		prefs, err := boardPrivacyPrefs(boardsCreatePriv)
+		if err != nil {
+			return err
+		}
+
+		var spec *boardspec.Spec
+		if boardsCreateTemplate != "" {
+			if spec, err = loadTemplate(boardsCreateTemplate); err != nil {
+				return err
+			}
+			if desc == "" {
+				desc = spec.Description
+			}
+			prefs.Set("defaultLists", "false")
+			prefs.Set("defaultLabels", "false")
+		}
+
+		board, err := client.CreateBoard(args[0], desc, boardsCreateOrg, prefs)
 		if err != nil {
 			return err
 		}
+		if spec != nil {
+			if err := instantiateSpec(board.ID, spec); err != nil {
+				return fmt.Errorf("board %s created, but applying the template failed: %w", board.ShortURL, err)
+			}
+		}
 
 		if output.IsJSON(cmd) {
 			return output.PrintJSON(board, output.IsPretty(cmd))
@@ -131,9 +222,10 @@ Examples:
 // ---- boards update ----
 
 var (
-	boardsUpdateName   string
-	boardsUpdateDesc   string
-	boardsUpdateClosed bool
+	boardsUpdateName     string
+	boardsUpdateDesc     string
+	boardsUpdateClosed   bool
+	boardsUpdateDescFile string
 )
 
 var boardsUpdateCmd = &cobra.Command{
@@ -147,9 +239,14 @@ Examples:
   trello boards update abc123 --closed`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		desc, err := resolveText(boardsUpdateDesc, boardsUpdateDescFile, "desc", false)
+		if err != nil {
+			return err
+		}
+
 		params := buildParams(
 			"name", boardsUpdateName,
-			"desc", boardsUpdateDesc,
+			"desc", desc,
 		)
 		if cmd.Flags().Changed("closed") {
 			params.Set("closed", output.FormatBool(boardsUpdateClosed))
@@ -272,19 +369,27 @@ func init() {
 	// boards list flags
 	boardsListCmd.Flags().StringVar(&boardsListFilter, "filter", "open", "Filter boards: open, closed, all, members, organization, public, starred")
 
+	// boards show flags
+	boardsShowCmd.Flags().StringSliceVar(&boardsShowLists, "lists", nil, "Lists to show, by name or ID (comma-separated, in display order)")
+	boardsShowCmd.Flags().IntVar(&boardsShowMaxCards, "max-cards", 0, "Maximum cards per column (0 = no limit)")
+
 	// boards create flags
 	boardsCreateCmd.Flags().StringVar(&boardsCreateDesc, "desc", "", "Board description")
 	boardsCreateCmd.Flags().StringVar(&boardsCreateOrg, "workspace", "", "Workspace/organization ID to create the board in")
 	boardsCreateCmd.Flags().StringVar(&boardsCreatePriv, "privacy", "private", "Privacy level: private, public, org")
+	boardsCreateCmd.Flags().StringVar(&boardsCreateDescFile, "desc-file", "", "Read the description from a file (- for stdin)")
+	boardsCreateCmd.Flags().StringVar(&boardsCreateTemplate, "template", "", "Build the board from a template (name or YAML file)")
 
 	// boards update flags
 	boardsUpdateCmd.Flags().StringVar(&boardsUpdateName, "name", "", "New board name")
 	boardsUpdateCmd.Flags().StringVar(&boardsUpdateDesc, "desc", "", "New board description")
 	boardsUpdateCmd.Flags().BoolVar(&boardsUpdateClosed, "closed", false, "Archive the board")
+	boardsUpdateCmd.Flags().StringVar(&boardsUpdateDescFile, "desc-file", "", "Read the description from a file (- for stdin)")
 
 	boardsCmd.AddCommand(
 		boardsListCmd,
 		boardsGetCmd,
+		boardsShowCmd,
 		boardsCreateCmd,
 		boardsUpdateCmd,
 		boardsDeleteCmd,
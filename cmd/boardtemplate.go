@@ -0,0 +1,387 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/api"
+	"github.com/the20100/trello-cli/internal/boardspec"
+	"github.com/the20100/trello-cli/internal/config"
+	"github.com/the20100/trello-cli/internal/output"
+	"github.com/the20100/trello-cli/internal/strutil"
+)
+
+// ---- boards copy ----
+
+var (
+	boardsCopyOrg     string
+	boardsCopyPriv    string
+	boardsCopyNoCards bool
+)
+
+var boardsCopyCmd = &cobra.Command{
+	Use:   "copy <board> <new-name>",
+	Short: "Create a new board as a copy of an existing one",
+	Long: `Create a new board from an existing board: its lists, labels, and
+power-ups, and its cards unless --no-cards is given.
+
+Examples:
+  trello boards copy "Sprint 11" "Sprint 12"
+  trello boards copy abc123 "Client B onboarding" --workspace <ws-id> --privacy org
+  trello boards copy abc123 "Empty copy" --no-cards`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		srcID, err := resolveBoardID(args[0])
+		if err != nil {
+			return err
+		}
+		prefs, err := boardPrivacyPrefs(boardsCopyPriv)
+		if err != nil {
+			return err
+		}
+		prefs.Set("idBoardSource", srcID)
+		if boardsCopyNoCards {
+			prefs.Set("keepFromSource", "none")
+		} else {
+			prefs.Set("keepFromSource", "cards")
+		}
+
+		board, err := client.CreateBoard(args[1], "", boardsCopyOrg, prefs)
+		if err != nil {
+			return err
+		}
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(board, output.IsPretty(cmd))
+		}
+		fmt.Printf("Board copied: %s\n", board.Name)
+		fmt.Printf("ID:  %s\n", board.ID)
+		fmt.Printf("URL: %s\n", board.ShortURL)
+		return nil
+	},
+}
+
+// boardPrivacyPrefs validates a --privacy value and returns it as board prefs.
+func boardPrivacyPrefs(privacy string) (url.Values, error) {
+	switch privacy {
+	case "private", "public", "org":
+		return url.Values{"prefs_permissionLevel": {privacy}}, nil
+	}
+	return nil, fmt.Errorf("invalid --privacy %q: use private, public, or org", privacy)
+}
+
+// ---- boards template ----
+
+var boardsTemplateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Save boards as reusable YAML templates",
+	Long: `Board templates are YAML files describing a board's lists, labels, custom
+fields, and seed cards with their checklists. Saved by name they live in the
+templates directory next to the config file; given a path (anything ending
+in .yaml/.yml or containing a slash) they can live in a repository and be
+version-controlled.
+
+Create a board from a template with "trello boards create <name> --template <template>".`,
+}
+
+var boardsTemplateSaveNoCards bool
+
+var boardsTemplateSaveCmd = &cobra.Command{
+	Use:   "save <board> <name|file.yaml>",
+	Short: "Save a board's structure as a template",
+	Long: `Save the lists, labels, custom fields, and open cards (with descriptions,
+labels, and checklists) of a board as a YAML template.
+
+Examples:
+  trello boards template save "Sprint 11" sprint
+  trello boards template save abc123 ./boards/onboarding.yaml
+  trello boards template save abc123 kanban --no-cards`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		boardID, err := resolveBoardID(args[0])
+		if err != nil {
+			return err
+		}
+		spec, err := specFromBoard(boardID, !boardsTemplateSaveNoCards)
+		if err != nil {
+			return err
+		}
+		path, err := templatePath(args[1])
+		if err != nil {
+			return err
+		}
+		if err := spec.Save(path); err != nil {
+			return err
+		}
+
+		cards := 0
+		for _, l := range spec.Lists {
+			cards += len(l.Cards)
+		}
+		fmt.Printf("Template saved to %s (%d lists, %d labels, %d custom fields, %d cards).\n",
+			path, len(spec.Lists), len(spec.Labels), len(spec.CustomFields), cards)
+		return nil
+	},
+}
+
+var boardsTemplateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved templates",
+	Long: `List the templates saved by name in the templates directory.
+
+Examples:
+  trello boards template list`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := config.TemplatesDir()
+		if err != nil {
+			return err
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+		var names []string
+		for _, e := range entries {
+			if name, ok := strings.CutSuffix(e.Name(), ".yaml"); ok && !e.IsDir() {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(names, output.IsPretty(cmd))
+		}
+		if len(names) == 0 {
+			fmt.Printf("No templates found in %s.\n", dir)
+			return nil
+		}
+		headers := []string{"NAME", "LISTS", "CARDS", "PATH"}
+		var rows [][]string
+		for _, name := range names {
+			path := filepath.Join(dir, name+".yaml")
+			lists, cards := "?", "?"
+			if spec, err := boardspec.Load(path); err == nil {
+				n := 0
+				for _, l := range spec.Lists {
+					n += len(l.Cards)
+				}
+				lists, cards = fmt.Sprintf("%d", len(spec.Lists)), fmt.Sprintf("%d", n)
+			}
+			rows = append(rows, []string{name, lists, cards, path})
+		}
+		output.PrintTable(headers, rows)
+		return nil
+	},
+}
+
+// isTemplateFile reports whether a template reference is a path rather than
+// the name of a saved template.
+func isTemplateFile(ref string) bool {
+	return strings.ContainsRune(ref, '/') || strings.ContainsRune(ref, filepath.Separator) ||
+		strings.HasSuffix(ref, ".yaml") || strings.HasSuffix(ref, ".yml")
+}
+
+// templatePath returns the file a template reference points to.
+func templatePath(ref string) (string, error) {
+	if isTemplateFile(ref) {
+		return ref, nil
+	}
+	dir, err := config.TemplatesDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, ref+".yaml"), nil
+}
+
+// loadTemplate loads a template by name or path.
+func loadTemplate(ref string) (*boardspec.Spec, error) {
+	path, err := templatePath(ref)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := boardspec.Load(path)
+	if errors.Is(err, os.ErrNotExist) && !isTemplateFile(ref) {
+		return nil, fmt.Errorf("no template named %q (see \"trello boards template list\")", ref)
+	}
+	return spec, err
+}
+
+// specFromBoard describes a live board as a spec. Open lists and, with
+// withCards, their open cards are included in board order.
+func specFromBoard(boardID string, withCards bool) (*boardspec.Spec, error) {
+	board, err := client.GetBoard(boardID, url.Values{"fields": {"name,desc"}})
+	if err != nil {
+		return nil, err
+	}
+	spec := &boardspec.Spec{Name: board.Name, Description: board.Desc}
+
+	labels, err := client.GetBoardLabels(boardID)
+	if err != nil {
+		return nil, err
+	}
+	// Labels that share a name are kept apart by color; cards then
+	// reference them as "name (color)".
+	seen := map[string]bool{}
+	shared := map[string]int{}
+	for _, l := range labels {
+		sl := boardspec.Label{Name: l.Name, Color: l.Color}
+		if sl.Key() == "" || seen[sl.Ref()] {
+			continue
+		}
+		seen[sl.Ref()] = true
+		shared[sl.Key()]++
+		spec.Labels = append(spec.Labels, sl)
+	}
+
+	fields, err := client.GetBoardCustomFields(boardID)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	for _, f := range fields {
+		sf := boardspec.CustomField{Name: f.Name, Type: f.Type, CardFront: f.Display.CardFront}
+		for _, o := range f.Options {
+			sf.Options = append(sf.Options, o.Value.Text)
+		}
+		spec.CustomFields = append(spec.CustomFields, sf)
+	}
+
+	lists, err := client.GetBoardLists(boardID, "open")
+	if err != nil {
+		return nil, err
+	}
+	var cards []api.Card
+	if withCards {
+		cards, err = client.GetBoardCardsWithParams(boardID, url.Values{"filter": {"open"}, "checklists": {"all"}})
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(cards, func(i, j int) bool { return cards[i].Pos < cards[j].Pos })
+	}
+	for _, l := range lists {
+		sl := boardspec.List{Name: l.Name}
+		for _, c := range cards {
+			if c.IDList == l.ID {
+				sl.Cards = append(sl.Cards, specCard(c, shared))
+			}
+		}
+		spec.Lists = append(spec.Lists, sl)
+	}
+	return spec, spec.Validate()
+}
+
+// specCard describes a card as a seed card. shared counts the board's
+// labels by key, so that a name used by several labels gets its color.
+func specCard(c api.Card, shared map[string]int) boardspec.Card {
+	sc := boardspec.Card{Name: c.Name, Desc: c.Desc}
+	for _, l := range c.Labels {
+		sl := boardspec.Label{Name: l.Name, Color: l.Color}
+		switch {
+		case sl.Key() == "":
+		case shared[sl.Key()] > 1:
+			sc.Labels = append(sc.Labels, sl.String())
+		default:
+			sc.Labels = append(sc.Labels, strutil.FirstNonEmpty(l.Name, l.Color))
+		}
+	}
+	checklists := append([]api.Checklist(nil), c.Checklists...)
+	sort.SliceStable(checklists, func(i, j int) bool { return checklists[i].Pos < checklists[j].Pos })
+	for _, cl := range checklists {
+		scl := boardspec.Checklist{Name: cl.Name}
+		items := append([]api.CheckItem(nil), cl.CheckItems...)
+		sort.SliceStable(items, func(i, j int) bool { return items[i].Pos < items[j].Pos })
+		for _, it := range items {
+			scl.Items = append(scl.Items, it.Name)
+		}
+		sc.Checklists = append(sc.Checklists, scl)
+	}
+	return sc
+}
+
+// instantiateSpec builds the structure of spec on an empty board: labels,
+// custom fields, then lists with their seed cards, in order.
+func instantiateSpec(boardID string, spec *boardspec.Spec) error {
+	created := make([]string, len(spec.Labels))
+	for i, l := range spec.Labels {
+		label, err := client.CreateLabel(boardID, l.Name, l.Color)
+		if err != nil {
+			return fmt.Errorf("creating label %s: %w", labelName(l.Name, l.Color), err)
+		}
+		created[i] = label.ID
+	}
+	labelIDs := map[string]string{}
+	for ref, i := range spec.LabelRefs() {
+		labelIDs[ref] = created[i]
+	}
+
+	if len(spec.CustomFields) > 0 {
+		// Enabling fails harmlessly when the power-up is already on; a real
+		// problem surfaces when the first field is created.
+		client.EnableBoardPlugin(boardID, api.CustomFieldsPluginID)
+		for _, f := range spec.CustomFields {
+			if _, err := client.CreateCustomField(boardID, f.Name, f.Type, f.Options, f.CardFront); err != nil {
+				return fmt.Errorf("creating custom field %s: %w", f.Name, err)
+			}
+		}
+	}
+
+	for _, l := range spec.Lists {
+		list, err := client.CreateList(l.Name, boardID, "bottom")
+		if err != nil {
+			return fmt.Errorf("creating list %s: %w", l.Name, err)
+		}
+		for _, c := range l.Cards {
+			if err := createSpecCard(list.ID, c, labelIDs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// createSpecCard creates a seed card with its labels and checklists.
+func createSpecCard(listID string, c boardspec.Card, labelIDs map[string]string) error {
+	params := url.Values{}
+	params.Set("pos", "bottom")
+	var ids []string
+	for _, ref := range c.Labels {
+		if id, ok := labelIDs[strings.ToLower(ref)]; ok {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > 0 {
+		params.Set("idLabels", strings.Join(ids, ","))
+	}
+	card, err := client.CreateCard(listID, c.Name, c.Desc, params)
+	if err != nil {
+		return fmt.Errorf("creating card %s: %w", quoteName(c.Name), err)
+	}
+	for _, cl := range c.Checklists {
+		checklist, err := client.CreateChecklist(card.ID, cl.Name)
+		if err != nil {
+			return fmt.Errorf("creating checklist %s on %s: %w", cl.Name, quoteName(c.Name), err)
+		}
+		for _, item := range cl.Items {
+			if _, err := client.CreateCheckItem(checklist.ID, item); err != nil {
+				return fmt.Errorf("adding checklist item to %s: %w", quoteName(c.Name), err)
+			}
+		}
+	}
+	return nil
+}
+
+func init() {
+	boardsCopyCmd.Flags().StringVar(&boardsCopyOrg, "workspace", "", "Workspace/organization ID to create the board in")
+	boardsCopyCmd.Flags().StringVar(&boardsCopyPriv, "privacy", "private", "Privacy level: private, public, org")
+	boardsCopyCmd.Flags().BoolVar(&boardsCopyNoCards, "no-cards", false, "Copy only the structure, without cards")
+
+	boardsTemplateSaveCmd.Flags().BoolVar(&boardsTemplateSaveNoCards, "no-cards", false, "Save only lists, labels, and custom fields")
+
+	boardsTemplateCmd.AddCommand(boardsTemplateSaveCmd, boardsTemplateListCmd)
+	boardsCmd.AddCommand(boardsCopyCmd, boardsTemplateCmd)
+}
@@ -0,0 +1,425 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/api"
+	"github.com/the20100/trello-cli/internal/cardexpr"
+	"github.com/the20100/trello-cli/internal/output"
+	"github.com/the20100/trello-cli/internal/strutil"
+)
+
+// bulkFlags selects the cards a card command acts on: IDs given as
+// arguments, "-" to read them from stdin, or a --where expression over the
+// cards of a board. Each command owns its own instance.
+type bulkFlags struct {
+	where    string
+	in       string
+	parallel int
+}
+
+// addBulkFlags registers the card selection flags on cmd.
+func addBulkFlags(cmd *cobra.Command, f *bulkFlags) {
+	cmd.Flags().StringVar(&f.where, "where", "", "Act on the cards of --in matching this filter expression")
+	cmd.Flags().StringVar(&f.in, "in", "", "Board whose cards --where selects from (ID, short link, URL, or name)")
+	cmd.Flags().IntVar(&f.parallel, "parallel", 4, "Number of cards to process at once")
+}
+
+// bulkHelp documents card selection in the long help of bulk commands.
+const bulkHelp = `Several cards can be given at once. Use - to read card IDs from stdin,
+one per line, or NDJSON cards with an "id" field (as printed by
+"trello cards list --json | jq -c '.[]'"). With --where the command acts on
+the cards of the --in board matching a filter expression, e.g.
+
+  list = "In Progress" and label = bug and due < +3d
+
+Fields: name, desc, list (=, !=, ~ regex, !~); label, member (=, != by
+name, color, username, or ID, or none; ~, !~); due, start, activity (=, !=,
+<, <=, >, >= with YYYY-MM-DD, today, now, +3d, -2w, -12h, or none);
+complete, archived, overdue (bare, or = true|false); items, unchecked
+(checklist item counts). Combine with and, or, not, and parentheses.
+
+With more than one card, cards are processed --parallel at a time,
+slowing down as the API rate limit runs low, and a per-card summary is
+printed. The exit status is 1 if any card failed.`
+
+// bulkTarget is a card selected for a bulk command. Name is known when the
+// card was selected by --where or from NDJSON.
+type bulkTarget struct {
+	ID   string
+	Name string
+}
+
+// targets collects the selected cards from args, stdin, and --where. bulk
+// is false for a single card given as an argument, which commands report
+// in their usual single-card format.
+func (f *bulkFlags) targets(args []string) (targets []bulkTarget, bulk bool, err error) {
+	if f.where != "" {
+		if len(args) > 0 {
+			return nil, false, fmt.Errorf("give card IDs or --where, not both")
+		}
+		targets, err = whereTargets(f.where, f.in)
+		return targets, true, err
+	}
+	if f.in != "" {
+		return nil, false, fmt.Errorf("--in is only used with --where")
+	}
+	if len(args) == 0 {
+		return nil, false, fmt.Errorf("provide card IDs, - to read them from stdin, or --where")
+	}
+
+	seen := map[string]bool{}
+	add := func(t bulkTarget) {
+		if !seen[t.ID] {
+			seen[t.ID] = true
+			targets = append(targets, t)
+		}
+	}
+	stdin := false
+	for _, a := range args {
+		if a != "-" {
+			add(bulkTarget{ID: a})
+			continue
+		}
+		if stdin {
+			return nil, false, fmt.Errorf("- can only be given once")
+		}
+		stdin = true
+		read, err := readBulkTargets(os.Stdin)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, t := range read {
+			add(t)
+		}
+	}
+	return targets, stdin || len(args) > 1, nil
+}
+
+// readBulkTargets reads card IDs, one per line, or NDJSON cards from r.
+func readBulkTargets(r io.Reader) ([]bulkTarget, error) {
+	var out []bulkTarget
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "{") {
+			out = append(out, bulkTarget{ID: strings.Fields(line)[0]})
+			continue
+		}
+		var c struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal([]byte(line), &c); err != nil {
+			return nil, fmt.Errorf("stdin line %d: %w", n, err)
+		}
+		if c.ID == "" {
+			return nil, fmt.Errorf("stdin line %d: no \"id\" field", n)
+		}
+		out = append(out, bulkTarget{ID: c.ID, Name: c.Name})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("reading stdin: %w", err)
+	}
+	return out, nil
+}
+
+// whereTargets returns the cards of board matching the expression where.
+// Archived cards are only considered when the expression tests archived.
+func whereTargets(where, board string) ([]bulkTarget, error) {
+	if board == "" {
+		return nil, fmt.Errorf("--where needs --in <board>")
+	}
+	expr, err := cardexpr.Parse(where)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --where: %w", err)
+	}
+	boardID, err := resolveBoardID(board)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := "open"
+	if expr.Uses("archived") {
+		filter = "all"
+	}
+	cards, err := client.GetBoardCards(boardID, filter)
+	if err != nil {
+		return nil, err
+	}
+	lists, err := client.GetBoardLists(boardID, "all")
+	if err != nil {
+		return nil, err
+	}
+	listNames := map[string]string{}
+	for _, l := range lists {
+		listNames[l.ID] = l.Name
+	}
+	members := map[string]cardexpr.Names{}
+	if expr.Uses("member") {
+		ms, err := client.GetBoardMembers(boardID)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range ms {
+			members[m.ID] = cardexpr.Names{m.ID, m.Username, m.FullName}
+		}
+	}
+
+	var out []bulkTarget
+	for _, c := range cards {
+		if expr.Match(exprCard(c, listNames, members)) {
+			out = append(out, bulkTarget{ID: c.ID, Name: c.Name})
+		}
+	}
+	return out, nil
+}
+
+// exprCard converts c to the form filter expressions are evaluated on.
+func exprCard(c api.Card, listNames map[string]string, members map[string]cardexpr.Names) cardexpr.Card {
+	parse := func(s *string) time.Time {
+		if s == nil {
+			return time.Time{}
+		}
+		t, _ := time.Parse(time.RFC3339, *s)
+		return t
+	}
+	ec := cardexpr.Card{
+		Name:              c.Name,
+		Desc:              c.Desc,
+		List:              listNames[c.IDList],
+		Due:               parse(c.Due),
+		Start:             parse(c.Start),
+		Activity:          parse(&c.DateLastActivity),
+		Complete:          c.DueComplete,
+		Archived:          c.Closed,
+		CheckItems:        c.Badges.CheckItems,
+		CheckItemsChecked: c.Badges.CheckItemsChecked,
+	}
+	for _, l := range c.Labels {
+		ec.Labels = append(ec.Labels, cardexpr.Names{l.ID, l.Name, l.Color})
+	}
+	for _, id := range c.IDMembers {
+		names, ok := members[id]
+		if !ok {
+			names = cardexpr.Names{id}
+		}
+		ec.Members = append(ec.Members, names)
+	}
+	return ec
+}
+
+// bulkResult is the outcome of a bulk command on one card.
+type bulkResult struct {
+	ID     string `json:"id"`
+	Name   string `json:"name,omitempty"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// bulkOp acts on one card and returns its name (when known) and a short
+// description of what was done. It sends every API request through do,
+// which paces it and retries it after a 429, so an op that makes several
+// requests never repeats one that already succeeded.
+type bulkOp func(id string, do func(req func() error) error) (name, detail string, err error)
+
+// bulkRetries is how many times a request is retried after a 429 response.
+const bulkRetries = 3
+
+// runBulk runs op on every target with a bounded worker pool, shows a
+// progress bar on a terminal, and prints a per-card summary. verb is the
+// past tense used in the summary ("moved", "archived", ...).
+func runBulk(cmd *cobra.Command, f *bulkFlags, targets []bulkTarget, verb string, op bulkOp) error {
+	if len(targets) == 0 {
+		if output.IsJSON(cmd) {
+			return output.PrintJSON([]bulkResult{}, output.IsPretty(cmd))
+		}
+		fmt.Println("No cards selected.")
+		return nil
+	}
+	workers := min(max(f.parallel, 1), len(targets))
+
+	results := make([]bulkResult, len(targets))
+	bar := newProgressBar(len(targets), verb)
+	throttle := &bulkThrottle{reserve: workers}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				t := targets[i]
+				name, detail, err := op(t.ID, throttle.do)
+				results[i] = bulkResult{ID: t.ID, Name: strutil.FirstNonEmpty(name, t.Name), OK: err == nil, Detail: detail}
+				if err != nil {
+					results[i].Error = err.Error()
+				}
+				bar.advance(err != nil)
+			}
+		}()
+	}
+	for i := range targets {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	bar.done()
+
+	failed := 0
+	for _, r := range results {
+		if !r.OK {
+			failed++
+		}
+	}
+
+	if output.IsJSON(cmd) {
+		if err := output.PrintJSON(results, output.IsPretty(cmd)); err != nil {
+			return err
+		}
+	} else {
+		rows := make([][]string, len(results))
+		for i, r := range results {
+			result := "ok"
+			if r.Detail != "" {
+				result = r.Detail
+			}
+			if !r.OK {
+				result = "FAILED: " + oneLine(r.Error, 60)
+			}
+			rows[i] = []string{r.ID, output.Truncate(r.Name, 40), result}
+		}
+		output.PrintTable([]string{"CARD", "NAME", "RESULT"}, rows)
+		fmt.Printf("\n%d of %d cards %s", len(results)-failed, len(results), verb)
+		if failed > 0 {
+			fmt.Printf(", %d failed", failed)
+		}
+		fmt.Println(".")
+	}
+
+	if failed > 0 {
+		cmd.SilenceErrors = true
+		return &exitError{code: 1}
+	}
+	return nil
+}
+
+// bulkThrottle pauses all workers when the token's rate limit runs low or
+// the API answers 429, so a large batch does not fail halfway through.
+type bulkThrottle struct {
+	reserve int // requests kept in hand, one per worker
+
+	mu    sync.Mutex
+	until time.Time
+}
+
+// do sends req once the rate limit allows, retrying it after a 429.
+func (t *bulkThrottle) do(req func() error) error {
+	for attempt := 0; ; attempt++ {
+		t.wait()
+		err := req()
+		if !t.observe(err) || attempt == bulkRetries {
+			return err
+		}
+	}
+}
+
+func (t *bulkThrottle) wait() {
+	t.mu.Lock()
+	d := time.Until(t.until)
+	t.mu.Unlock()
+	if d > 0 {
+		time.Sleep(d)
+	}
+}
+
+// observe records the outcome of a request and reports whether it hit the
+// rate limit and should be retried.
+func (t *bulkThrottle) observe(err error) bool {
+	rl := client.RateLimit()
+	interval := rl.Interval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	var te *api.TrelloError
+	limited := errors.As(err, &te) && te.StatusCode == 429
+	if !limited && (rl.Max == 0 || rl.Remaining > t.reserve) {
+		return false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if until := time.Now().Add(interval); until.After(t.until) {
+		t.until = until
+	}
+	return limited
+}
+
+// progressBar draws a one-line progress bar on stderr when it is a terminal.
+type progressBar struct {
+	total  int
+	label  string
+	active bool
+
+	mu        sync.Mutex
+	completed int
+	failed    int
+}
+
+func newProgressBar(total int, label string) *progressBar {
+	// A dry run prints its operations to stderr instead.
+	p := &progressBar{
+		total:  total,
+		label:  label,
+		active: total > 1 && !client.DryRun() && (isatty.IsTerminal(os.Stderr.Fd()) || isatty.IsCygwinTerminal(os.Stderr.Fd())),
+	}
+	p.draw()
+	return p
+}
+
+func (p *progressBar) advance(failed bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.completed++
+	if failed {
+		p.failed++
+	}
+	p.draw()
+}
+
+// draw renders the bar; callers hold p.mu or own p exclusively.
+func (p *progressBar) draw() {
+	if !p.active {
+		return
+	}
+	const width = 30
+	filled := width * p.completed / p.total
+	line := fmt.Sprintf("\r[%s%s] %d/%d %s", strings.Repeat("#", filled), strings.Repeat(".", width-filled), p.completed, p.total, p.label)
+	if p.failed > 0 {
+		line += fmt.Sprintf(", %d failed", p.failed)
+	}
+	fmt.Fprint(os.Stderr, line)
+}
+
+// done erases the bar.
+func (p *progressBar) done() {
+	if p.active {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+	}
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+const testCardID2 = "5f0000000000000000000c02"
+
+// TestBulkRetriesEachRequest checks that a 429 on one request of a bulk op
+// retries that request only, not the requests before it.
+func TestBulkRetriesEachRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		addPath    string
+		removePath string
+	}{
+		{
+			name:       "label",
+			args:       []string{"cards", "label", testCardID, testCardID2, "--add", "lab-new", "--remove", "lab-old"},
+			addPath:    "/cards/%s/idLabels",
+			removePath: "/cards/%s/idLabels/lab-old",
+		},
+		{
+			name:       "member",
+			args:       []string{"cards", "member", testCardID, testCardID2, "--add", "mem-new", "--remove", "mem-old"},
+			addPath:    "/cards/%s/idMembers",
+			removePath: "/cards/%s/idMembers/mem-old",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeTrello(t)
+			f.limited["DELETE "+fmt.Sprintf(tt.removePath, testCardID)] = 2
+
+			out, err := runCommand(t, f, append(tt.args, "--json")...)
+			if err != nil {
+				t.Fatalf("run: %v\n%s", err, out)
+			}
+			var results []bulkResult
+			if err := json.Unmarshal([]byte(out), &results); err != nil {
+				t.Fatalf("output %q: %v", out, err)
+			}
+			for _, r := range results {
+				if !r.OK {
+					t.Errorf("card %s failed: %s", r.ID, r.Error)
+				}
+			}
+
+			for _, id := range []string{testCardID, testCardID2} {
+				if n := len(f.requestsTo("POST", fmt.Sprintf(tt.addPath, id))); n != 1 {
+					t.Errorf("card %s: sent the add %d times, want 1", id, n)
+				}
+			}
+			if n := len(f.requestsTo("DELETE", fmt.Sprintf(tt.removePath, testCardID))); n != 3 {
+				t.Errorf("sent the rate-limited remove %d times, want 3", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/api"
+	"github.com/the20100/trello-cli/internal/output"
+	"github.com/the20100/trello-cli/internal/strutil"
+)
+
+var (
+	cardsCopyList         string
+	cardsCopyBoard        string
+	cardsCopyName         string
+	cardsCopyPos          string
+	cardsCopyCreateLabels bool
+)
+
+var cardsCopyCmd = &cobra.Command{
+	Use:   "copy <card>",
+	Short: "Copy a card to a list, on the same or another board",
+	Long: `Copy a card into a list. The card can be an ID, short link, or URL; the
+destination list an ID, or a name with --board.
+
+Checklists, attachments, dates, and stickers are always copied. Within the
+same board, labels, members, and custom field values are kept as they are.
+Across boards, where IDs differ:
+
+  - labels are matched by name (or color, for unnamed labels) on the
+    destination board; missing ones are created with --create-labels
+  - members who are not on the destination board are dropped
+  - custom field values are copied to fields of the same name and type
+
+Everything that could not be carried over is reported.
+
+Examples:
+  trello cards copy abc123 --list <list-id>
+  trello cards copy abc123 --list "Backlog" --board "Other Project"
+  trello cards copy https://trello.com/c/AbCd1234 --list "To Do" --board "Q3" --create-labels
+  trello cards copy abc123 --list <list-id> --name "Copy of release checklist" --pos top`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if cardsCopyList == "" {
+			return fmt.Errorf("--list is required")
+		}
+		srcRef, err := resolveRef(args[0], "card", "")
+		if err != nil {
+			return err
+		}
+		src, err := client.GetCard(srcRef.ID, url.Values{"customFieldItems": {"true"}})
+		if err != nil {
+			return err
+		}
+		dest, err := resolveRef(cardsCopyList, "list", cardsCopyBoard)
+		if err != nil {
+			return err
+		}
+
+		res, err := copyCard(*src, dest.ID, dest.IDBoard, cardsCopyName, cardsCopyPos, cardsCopyCreateLabels)
+		if err != nil {
+			return err
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(res, output.IsPretty(cmd))
+		}
+		fmt.Printf("Card copied: %s\n", res.Card.Name)
+		fmt.Printf("ID:  %s\n", res.Card.ID)
+		fmt.Printf("#%d  %s\n", res.Card.IDShort, res.Card.ShortURL)
+		for _, c := range res.Created {
+			fmt.Printf("created %s\n", c)
+		}
+		if len(res.NotCopied) > 0 {
+			fmt.Println("Not carried over:")
+			for _, n := range res.NotCopied {
+				fmt.Printf("  - %s\n", n)
+			}
+		}
+		return nil
+	},
+}
+
+// cardCopyResult is the outcome of copyCard.
+type cardCopyResult struct {
+	Card      *api.Card `json:"card"`
+	Created   []string  `json:"created,omitempty"`
+	NotCopied []string  `json:"notCopied,omitempty"`
+}
+
+// copyCard copies src into the list destList on destBoard, letting Trello
+// copy the board-independent parts (idCardSource) and remapping labels,
+// members, and custom fields itself when the boards differ.
+func copyCard(src api.Card, destList, destBoard, name, pos string, createLabels bool) (*cardCopyResult, error) {
+	sameBoard := src.IDBoard == destBoard
+	params := url.Values{}
+	params.Set("idCardSource", src.ID)
+	if sameBoard {
+		params.Set("keepFromSource", "all")
+	} else {
+		params.Set("keepFromSource", "attachments,checklists,due,start,stickers")
+	}
+	if pos != "" {
+		params.Set("pos", pos)
+	}
+	card, err := client.CreateCard(destList, strutil.FirstNonEmpty(name, src.Name), "", params)
+	if err != nil {
+		return nil, err
+	}
+	res := &cardCopyResult{Card: card}
+	if sameBoard {
+		return res, nil
+	}
+
+	update := url.Values{}
+	labelIDs, err := mapLabels(src.Labels, destBoard, createLabels, res)
+	if err != nil {
+		return res, err
+	}
+	if len(labelIDs) > 0 {
+		update.Set("idLabels", strings.Join(labelIDs, ","))
+	}
+	memberIDs, err := mapMembers(src.IDMembers, destBoard, res)
+	if err != nil {
+		return res, err
+	}
+	if len(memberIDs) > 0 {
+		update.Set("idMembers", strings.Join(memberIDs, ","))
+	}
+	if len(update) > 0 {
+		updated, err := client.UpdateCard(card.ID, update)
+		if err != nil {
+			return res, err
+		}
+		res.Card = updated
+	}
+
+	if err := copyCustomFields(src, card.ID, destBoard, res); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// mapLabels returns the destination board's IDs for labels, matching by
+// name (and color when several share a name) or, for unnamed labels, by color.
+func mapLabels(labels []api.Label, destBoard string, create bool, res *cardCopyResult) ([]string, error) {
+	if len(labels) == 0 {
+		return nil, nil
+	}
+	destLabels, err := client.GetBoardLabels(destBoard)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, l := range labels {
+		if m, ok := matchLabel(destLabels, l); ok {
+			ids = append(ids, m.ID)
+			continue
+		}
+		if !create {
+			res.NotCopied = append(res.NotCopied, fmt.Sprintf("label %s (not on the destination board; use --create-labels)", labelName(l.Name, l.Color)))
+			continue
+		}
+		created, err := client.CreateLabel(destBoard, l.Name, l.Color)
+		if err != nil {
+			return nil, fmt.Errorf("creating label %s: %w", labelName(l.Name, l.Color), err)
+		}
+		destLabels = append(destLabels, *created)
+		res.Created = append(res.Created, "label "+labelName(l.Name, l.Color))
+		ids = append(ids, created.ID)
+	}
+	return ids, nil
+}
+
+// matchLabel finds the label in labels that corresponds to l on another board.
+func matchLabel(labels []api.Label, l api.Label) (api.Label, bool) {
+	var byName []api.Label
+	for _, d := range labels {
+		if l.Name == "" {
+			if d.Name == "" && d.Color == l.Color {
+				return d, true
+			}
+			continue
+		}
+		if strings.EqualFold(d.Name, l.Name) {
+			byName = append(byName, d)
+		}
+	}
+	for _, d := range byName {
+		if d.Color == l.Color {
+			return d, true
+		}
+	}
+	if len(byName) > 0 {
+		return byName[0], true
+	}
+	return api.Label{}, false
+}
+
+// mapMembers keeps the members that also belong to destBoard.
+func mapMembers(memberIDs []string, destBoard string, res *cardCopyResult) ([]string, error) {
+	if len(memberIDs) == 0 {
+		return nil, nil
+	}
+	members, err := client.GetBoardMembers(destBoard)
+	if err != nil {
+		return nil, err
+	}
+	var kept []string
+	for _, id := range memberIDs {
+		found := false
+		for _, m := range members {
+			if m.ID == id {
+				found = true
+				break
+			}
+		}
+		if found {
+			kept = append(kept, id)
+		} else {
+			res.NotCopied = append(res.NotCopied, fmt.Sprintf("member %s (not on the destination board)", memberDisplay(id)))
+		}
+	}
+	return kept, nil
+}
+
+// memberDisplay returns "@username" for a member ID, or the ID if unknown.
+func memberDisplay(id string) string {
+	m, err := client.GetMember(id, url.Values{"fields": {"username"}})
+	if err != nil || m.Username == "" {
+		return id
+	}
+	return "@" + m.Username
+}
+
+// copyCustomFields sets the custom field values of src on the card destID,
+// using the destination board's fields of the same name and type.
+func copyCustomFields(src api.Card, destID, destBoard string, res *cardCopyResult) error {
+	if len(src.CustomFieldItems) == 0 {
+		return nil
+	}
+	srcFields, err := client.GetBoardCustomFields(src.IDBoard)
+	if err != nil {
+		return err
+	}
+	destFields, err := client.GetBoardCustomFields(destBoard)
+	if err != nil {
+		return err
+	}
+
+	for _, it := range src.CustomFieldItems {
+		var sf api.CustomField
+		for _, f := range srcFields {
+			if f.ID == it.IDCustomField {
+				sf = f
+			}
+		}
+		if sf.ID == "" {
+			continue
+		}
+		df, ok := findCustomField(destFields, sf.Name)
+		if !ok || df.Type != sf.Type {
+			res.NotCopied = append(res.NotCopied, fmt.Sprintf("custom field %s = %s (no %s field of that name on the destination board)",
+				sf.Name, formatCustomFieldValue(sf, it), sf.Type))
+			continue
+		}
+
+		var value *api.CustomFieldValue
+		var idValue string
+		if sf.Type == "list" {
+			text := formatCustomFieldValue(sf, it)
+			if _, idValue, err = parseCustomFieldValue(df, text); err != nil {
+				res.NotCopied = append(res.NotCopied, fmt.Sprintf("custom field %s = %s (no such option on the destination board)", sf.Name, text))
+				continue
+			}
+		} else {
+			value = it.Value
+		}
+		if value == nil && idValue == "" {
+			continue
+		}
+		if err := client.SetCardCustomField(destID, df.ID, value, idValue); err != nil {
+			return fmt.Errorf("setting custom field %s: %w", df.Name, err)
+		}
+	}
+	return nil
+}
+
+func init() {
+	cardsCopyCmd.Flags().StringVar(&cardsCopyList, "list", "", "Destination list ID, or name with --board (required)")
+	cardsCopyCmd.Flags().StringVar(&cardsCopyBoard, "board", "", "Destination board, to look up --list by name")
+	cardsCopyCmd.Flags().StringVar(&cardsCopyName, "name", "", "Name of the copy (default: the source card's name)")
+	cardsCopyCmd.Flags().StringVar(&cardsCopyPos, "pos", "", "Position in the list: top, bottom, or a number")
+	cardsCopyCmd.Flags().BoolVar(&cardsCopyCreateLabels, "create-labels", false, "Create labels missing on the destination board")
+	cardsCmd.AddCommand(cardsCopyCmd)
+}
@@ -0,0 +1,474 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/api"
+	"github.com/the20100/trello-cli/internal/output"
+	"gopkg.in/yaml.v3"
+)
+
+// ---- cards edit ----
+
+var cardsEditForce bool
+
+var cardsEditCmd = &cobra.Command{
+	Use:   "edit <card-id>",
+	Short: "Edit a card in $EDITOR",
+	Long: `Open a card in $VISUAL / $EDITOR as a Markdown document with YAML front-matter:
+
+  ---
+  name: Fix the login bug
+  due: 2024-12-31T17:00:00.000Z
+  start: ""
+  labels: [bug, urgent]
+  members: [alice]
+  list: Doing
+  ---
+  The description, as Markdown.
+
+On save, only the fields that changed are sent: card fields via one update,
+labels and members via add/remove, and a move when the list changes. Labels
+are matched by name, color, or ID; members by username or ID. Clear a date by
+leaving it empty.
+
+If the card was modified on Trello while you were editing (its last activity
+changed), nothing is applied and your edited file is kept; re-run with --force
+to apply it anyway.
+
+Examples:
+  trello cards edit abc123
+  EDITOR="code --wait" trello cards edit abc123`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		card, err := client.GetCard(args[0], nil)
+		if err != nil {
+			return err
+		}
+		ctx, err := loadCardEditContext(card.IDBoard)
+		if err != nil {
+			return err
+		}
+
+		original := ctx.document(card)
+		text, err := renderCardDocument(original)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.CreateTemp("", "trello-card-*.md")
+		if err != nil {
+			return fmt.Errorf("creating temp file: %w", err)
+		}
+		path := f.Name()
+		_, err = f.Write(text)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("writing temp file: %w", err)
+		}
+
+		if err := launchEditor(path); err != nil {
+			os.Remove(path)
+			return err
+		}
+		edited, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading edited file: %w", err)
+		}
+		if bytes.Equal(edited, text) {
+			os.Remove(path)
+			fmt.Println("No changes.")
+			return nil
+		}
+
+		doc, err := parseCardDocument(edited)
+		if err != nil {
+			return fmt.Errorf("%w (your edits are saved in %s)", err, path)
+		}
+
+		changes, err := ctx.diff(card, original, doc)
+		if err != nil {
+			return fmt.Errorf("%w (your edits are saved in %s)", err, path)
+		}
+		if changes.empty() {
+			os.Remove(path)
+			fmt.Println("No changes.")
+			return nil
+		}
+
+		if !cardsEditForce {
+			current, err := client.GetCard(card.ID, url.Values{"fields": {"dateLastActivity"}})
+			if err != nil {
+				return fmt.Errorf("%w (your edits are saved in %s)", err, path)
+			}
+			if current.DateLastActivity != card.DateLastActivity {
+				return fmt.Errorf("card was modified on Trello while you were editing (last activity %s)\nyour edits are saved in %s; re-run with --force to apply them anyway",
+					output.FormatTime(current.DateLastActivity), path)
+			}
+		}
+
+		updated, err := changes.apply(card.ID)
+		if err != nil {
+			return fmt.Errorf("%w (your edits are saved in %s)", err, path)
+		}
+		os.Remove(path)
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(updated, output.IsPretty(cmd))
+		}
+		fmt.Printf("Card updated: %s\n", updated.Name)
+		for _, line := range changes.summary {
+			fmt.Printf("  %s\n", line)
+		}
+		return nil
+	},
+}
+
+// cardDocument is the front-matter of the card editing document.
+type cardDocument struct {
+	Name    string   `yaml:"name"`
+	Due     string   `yaml:"due"`
+	Start   string   `yaml:"start"`
+	Labels  []string `yaml:"labels,flow"`
+	Members []string `yaml:"members,flow"`
+	List    string   `yaml:"list"`
+	Desc    string   `yaml:"-"`
+}
+
+// renderCardDocument writes doc as YAML front-matter followed by the description.
+func renderCardDocument(doc cardDocument) ([]byte, error) {
+	if doc.Labels == nil {
+		doc.Labels = []string{}
+	}
+	if doc.Members == nil {
+		doc.Members = []string{}
+	}
+	fm, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	b.WriteString("---\n")
+	b.Write(fm)
+	b.WriteString("---\n")
+	b.WriteString(doc.Desc)
+	if doc.Desc != "" && !strings.HasSuffix(doc.Desc, "\n") {
+		b.WriteString("\n")
+	}
+	return b.Bytes(), nil
+}
+
+// parseCardDocument splits an edited document into front-matter and description.
+func parseCardDocument(data []byte) (cardDocument, error) {
+	var doc cardDocument
+	fm, body, err := splitFrontMatter(string(data))
+	if err != nil {
+		return doc, err
+	}
+	if err := yaml.Unmarshal([]byte(fm), &doc); err != nil {
+		return doc, fmt.Errorf("parsing front-matter: %w", err)
+	}
+	doc.Desc = strings.TrimRight(body, "\n")
+	return doc, nil
+}
+
+// splitFrontMatter returns the YAML between the leading "---" fences and the rest of the text.
+func splitFrontMatter(s string) (string, string, error) {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	if !strings.HasPrefix(s, "---\n") {
+		return "", "", fmt.Errorf("missing front-matter: the document must start with a --- line")
+	}
+	rest := s[len("---\n"):]
+	if strings.HasPrefix(rest, "---\n") {
+		return "", rest[len("---\n"):], nil
+	}
+	end := strings.Index(rest, "\n---\n")
+	if end < 0 {
+		if strings.HasSuffix(rest, "\n---") {
+			return rest[:len(rest)-len("\n---")], "", nil
+		}
+		return "", "", fmt.Errorf("unterminated front-matter: missing closing --- line")
+	}
+	return rest[:end], rest[end+len("\n---\n"):], nil
+}
+
+// cardEditContext holds the board data needed to map names to IDs.
+type cardEditContext struct {
+	lists   []api.TrelloList
+	labels  []api.Label
+	members []api.Member
+}
+
+func loadCardEditContext(boardID string) (*cardEditContext, error) {
+	lists, err := client.GetBoardLists(boardID, "open")
+	if err != nil {
+		return nil, err
+	}
+	labels, err := client.GetBoardLabels(boardID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := client.GetBoardMembers(boardID)
+	if err != nil {
+		return nil, err
+	}
+	return &cardEditContext{lists: lists, labels: labels, members: members}, nil
+}
+
+// document builds the editable document for card.
+func (ctx *cardEditContext) document(card *api.Card) cardDocument {
+	doc := cardDocument{
+		Name: card.Name,
+		Desc: card.Desc,
+		List: card.IDList,
+	}
+	if card.Due != nil {
+		doc.Due = *card.Due
+	}
+	if card.Start != nil {
+		doc.Start = *card.Start
+	}
+	for _, l := range ctx.lists {
+		if l.ID == card.IDList {
+			doc.List = l.Name
+		}
+	}
+	for _, l := range card.Labels {
+		doc.Labels = append(doc.Labels, labelDisplayName(l))
+	}
+	for _, id := range card.IDMembers {
+		name := id
+		for _, m := range ctx.members {
+			if m.ID == id {
+				name = m.Username
+			}
+		}
+		doc.Members = append(doc.Members, name)
+	}
+	return doc
+}
+
+// cardChanges is the set of API operations needed to apply an edit.
+type cardChanges struct {
+	params        url.Values
+	listID        string
+	addLabels     []string
+	removeLabels  []string
+	addMembers    []string
+	removeMembers []string
+	summary       []string
+}
+
+func (c *cardChanges) empty() bool {
+	return len(c.params) == 0 && c.listID == "" &&
+		len(c.addLabels)+len(c.removeLabels)+len(c.addMembers)+len(c.removeMembers) == 0
+}
+
+// diff compares the original and edited documents and resolves names to IDs.
+func (ctx *cardEditContext) diff(card *api.Card, before, after cardDocument) (*cardChanges, error) {
+	ch := &cardChanges{params: url.Values{}}
+
+	if strings.TrimSpace(after.Name) == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+	if after.Name != before.Name {
+		ch.params.Set("name", after.Name)
+		ch.summary = append(ch.summary, fmt.Sprintf("name → %q", after.Name))
+	}
+	if after.Desc != strings.TrimRight(before.Desc, "\n") {
+		ch.params.Set("desc", after.Desc)
+		ch.summary = append(ch.summary, "description updated")
+	}
+	for _, f := range []struct{ key, before, after string }{
+		{"due", before.Due, after.Due},
+		{"start", before.Start, after.Start},
+	} {
+		if f.after == f.before {
+			continue
+		}
+		if f.after == "" {
+			ch.params.Set(f.key, "null")
+			ch.summary = append(ch.summary, f.key+" cleared")
+		} else {
+			ch.params.Set(f.key, f.after)
+			ch.summary = append(ch.summary, fmt.Sprintf("%s → %s", f.key, f.after))
+		}
+	}
+
+	if after.List != before.List {
+		l, ok := findList(ctx.lists, after.List)
+		if !ok {
+			return nil, fmt.Errorf("list %q not found on board", after.List)
+		}
+		if l.ID != card.IDList {
+			ch.listID = l.ID
+			ch.summary = append(ch.summary, fmt.Sprintf("moved to %s", l.Name))
+		}
+	}
+
+	wantLabels := map[string]bool{}
+	for _, name := range after.Labels {
+		// Prefer the card's own labels, so a kept label is never swapped for
+		// another one with the same name.
+		l, ok := findLabel(card.Labels, name)
+		if !ok {
+			l, ok = findLabel(ctx.labels, name)
+		}
+		if !ok {
+			return nil, fmt.Errorf("label %q not found on board", name)
+		}
+		wantLabels[l.ID] = true
+		if !containsString(card.IDLabels, l.ID) {
+			ch.addLabels = append(ch.addLabels, l.ID)
+			ch.summary = append(ch.summary, "label added: "+labelDisplayName(l))
+		}
+	}
+	for _, l := range card.Labels {
+		if !wantLabels[l.ID] {
+			ch.removeLabels = append(ch.removeLabels, l.ID)
+			ch.summary = append(ch.summary, "label removed: "+labelDisplayName(l))
+		}
+	}
+
+	wantMembers := map[string]bool{}
+	for _, ref := range after.Members {
+		m, ok := findMember(ctx.members, ref)
+		if !ok {
+			return nil, fmt.Errorf("member %q is not on this board", ref)
+		}
+		wantMembers[m.ID] = true
+		if !containsString(card.IDMembers, m.ID) {
+			ch.addMembers = append(ch.addMembers, m.ID)
+			ch.summary = append(ch.summary, "member added: "+m.Username)
+		}
+	}
+	for _, id := range card.IDMembers {
+		if !wantMembers[id] {
+			name := id
+			if m, ok := findMember(ctx.members, id); ok {
+				name = m.Username
+			}
+			ch.removeMembers = append(ch.removeMembers, id)
+			ch.summary = append(ch.summary, "member removed: "+name)
+		}
+	}
+	return ch, nil
+}
+
+// apply sends the changes and returns the updated card.
+func (c *cardChanges) apply(cardID string) (*api.Card, error) {
+	if c.listID != "" {
+		c.params.Set("idList", c.listID)
+	}
+	if len(c.params) > 0 {
+		if _, err := client.UpdateCard(cardID, c.params); err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range c.addLabels {
+		if err := client.AddLabelToCard(cardID, id); err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range c.removeLabels {
+		if err := client.RemoveLabelFromCard(cardID, id); err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range c.addMembers {
+		if err := client.AddMemberToCard(cardID, id); err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range c.removeMembers {
+		if err := client.RemoveMemberFromCard(cardID, id); err != nil {
+			return nil, err
+		}
+	}
+	return client.GetCard(cardID, nil)
+}
+
+// findLabel returns the label matching ref by ID, name, or (for unnamed
+// labels) color. Unnamed labels are matched by color before named labels by
+// name, so "red" is the red label even when another label is named "Red";
+// exact matches win over case-insensitive ones, so "Red" is still that label.
+func findLabel(labels []api.Label, ref string) (api.Label, bool) {
+	for _, l := range labels {
+		if l.ID == ref {
+			return l, true
+		}
+	}
+	for _, equal := range []func(a, b string) bool{
+		func(a, b string) bool { return a == b },
+		strings.EqualFold,
+	} {
+		for _, l := range labels {
+			if l.Name == "" && equal(l.Color, ref) {
+				return l, true
+			}
+		}
+		for _, l := range labels {
+			if l.Name != "" && equal(l.Name, ref) {
+				return l, true
+			}
+		}
+	}
+	return api.Label{}, false
+}
+
+// findMember returns the member matching ref by ID or username (with or without @).
+func findMember(members []api.Member, ref string) (api.Member, bool) {
+	ref = strings.TrimPrefix(ref, "@")
+	for _, m := range members {
+		if m.ID == ref || strings.EqualFold(m.Username, ref) {
+			return m, true
+		}
+	}
+	return api.Member{}, false
+}
+
+// labelDisplayName returns a label's name, or its color when unnamed.
+func labelDisplayName(l api.Label) string {
+	return labelName(l.Name, l.Color)
+}
+
+// launchEditor opens path in $VISUAL or $EDITOR and waits for it to exit.
+func launchEditor(path string) error {
+	// A blank variable counts as unset, so it cannot leave no command to run.
+	editor := defaultEditor()
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if v := strings.TrimSpace(os.Getenv(name)); v != "" {
+			editor = v
+			break
+		}
+	}
+	fields := strings.Fields(editor)
+	c := exec.Command(fields[0], append(fields[1:], path)...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("running editor %q: %w", editor, err)
+	}
+	return nil
+}
+
+// defaultEditor is the editor used when $VISUAL and $EDITOR are unset or blank.
+func defaultEditor() string {
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+func init() {
+	cardsEditCmd.Flags().BoolVar(&cardsEditForce, "force", false, "Apply edits even if the card changed on Trello meanwhile")
+	cardsCmd.AddCommand(cardsEditCmd)
+}
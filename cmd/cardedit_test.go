@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/the20100/trello-cli/internal/api"
+)
+
+func TestFindLabel(t *testing.T) {
+	labels := []api.Label{
+		{ID: "lab-named-red", Name: "red", Color: "blue"},
+		{ID: "lab-Red", Name: "Red", Color: "purple"},
+		{ID: "lab-red", Color: "red"},
+		{ID: "lab-bug", Name: "Bug", Color: "orange"},
+		{ID: "lab-green", Color: "green"},
+	}
+	tests := []struct {
+		ref    string
+		wantID string
+	}{
+		{"lab-bug", "lab-bug"},
+		{"red", "lab-red"},     // unnamed by color before named by name
+		{"Red", "lab-Red"},     // exact name before case-insensitive color
+		{"RED", "lab-red"},     // case-insensitive, unnamed first
+		{"bug", "lab-bug"},     // case-insensitive name
+		{"Green", "lab-green"}, // case-insensitive color
+		{"orange", ""},         // named labels do not match by color
+		{"nope", ""},
+	}
+	for _, tt := range tests {
+		l, ok := findLabel(labels, tt.ref)
+		if ok != (tt.wantID != "") || l.ID != tt.wantID {
+			t.Errorf("findLabel(%q) = %q, %v; want %q", tt.ref, l.ID, ok, tt.wantID)
+		}
+	}
+}
+
+// TestCardEditDiffLabels checks that an unedited labels line changes nothing,
+// even when labels share a name or a name is a color word.
+func TestCardEditDiffLabels(t *testing.T) {
+	ctx := &cardEditContext{
+		labels: []api.Label{
+			{ID: "lab-named-red", Name: "red", Color: "blue"},
+			{ID: "lab-bug-1", Name: "Bug", Color: "orange"},
+			{ID: "lab-red", Color: "red"},
+			{ID: "lab-bug-2", Name: "Bug", Color: "yellow"},
+		},
+	}
+	card := &api.Card{
+		ID:       testCardID,
+		Name:     "Fix login",
+		IDLabels: []string{"lab-red", "lab-bug-2"},
+		Labels:   []api.Label{{ID: "lab-red", Color: "red"}, {ID: "lab-bug-2", Name: "Bug", Color: "yellow"}},
+	}
+	doc := ctx.document(card)
+	ch, err := ctx.diff(card, doc, doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ch.empty() {
+		t.Errorf("changes = %+v, want none", ch)
+	}
+
+	// Removing the unnamed red label removes that one only.
+	edited := doc
+	edited.Labels = []string{"Bug"}
+	if ch, err = ctx.diff(card, doc, edited); err != nil {
+		t.Fatal(err)
+	}
+	if len(ch.addLabels) != 0 || len(ch.removeLabels) != 1 || ch.removeLabels[0] != "lab-red" {
+		t.Errorf("add %v, remove %v; want only lab-red removed", ch.addLabels, ch.removeLabels)
+	}
+}
+
+func TestLaunchEditorBlankVisual(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the editor")
+	}
+	dir := t.TempDir()
+	editor := filepath.Join(dir, "editor.sh")
+	if err := os.WriteFile(editor, []byte("#!/bin/sh\necho edited > \"$1\"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	doc := filepath.Join(dir, "card.md")
+	if err := os.WriteFile(doc, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("VISUAL", "   ")
+	t.Setenv("EDITOR", editor)
+	if err := launchEditor(doc); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := os.ReadFile(doc); string(b) != "edited\n" {
+		t.Errorf("document = %q, want it written by $EDITOR", b)
+	}
+}
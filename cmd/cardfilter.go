@@ -0,0 +1,320 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/api"
+)
+
+// cardFilter holds the client-side sorting and filtering options shared by
+// every card-listing command. Each command owns its own instance so the
+// flags can be bound independently.
+type cardFilter struct {
+	sortBy              string
+	reverse             bool
+	labels              []string
+	members             []string
+	dueBefore           string
+	dueAfter            string
+	overdue             bool
+	noDue               bool
+	hasChecklist        bool
+	incompleteChecklist bool
+	nameMatch           string
+}
+
+// addCardFilterFlags registers the shared card filter flags on cmd.
+func addCardFilterFlags(cmd *cobra.Command, f *cardFilter) {
+	cmd.Flags().StringVar(&f.sortBy, "sort", "", "Sort by: due, name, pos, activity, list")
+	cmd.Flags().BoolVar(&f.reverse, "reverse", false, "Reverse the sort order")
+	cmd.Flags().StringSliceVar(&f.labels, "label", nil, "Only cards with this label name, color, or ID (repeatable)")
+	cmd.Flags().StringSliceVar(&f.members, "member", nil, "Only cards assigned to this member ID or username (repeatable)")
+	cmd.Flags().StringVar(&f.dueBefore, "due-before", "", "Only cards due before this date (YYYY-MM-DD or ISO-8601)")
+	cmd.Flags().StringVar(&f.dueAfter, "due-after", "", "Only cards due after this date (YYYY-MM-DD or ISO-8601)")
+	cmd.Flags().BoolVar(&f.overdue, "overdue", false, "Only cards past their due date and not marked complete")
+	cmd.Flags().BoolVar(&f.noDue, "no-due", false, "Only cards without a due date")
+	cmd.Flags().BoolVar(&f.hasChecklist, "has-checklist", false, "Only cards with at least one checklist item")
+	cmd.Flags().BoolVar(&f.incompleteChecklist, "incomplete-checklist", false, "Only cards with unchecked checklist items")
+	cmd.Flags().StringVar(&f.nameMatch, "name-match", "", "Only cards whose name matches this regular expression")
+}
+
+// apply filters and sorts cards according to f.
+func (f *cardFilter) apply(cards []api.Card) ([]api.Card, error) {
+	match, err := f.matcher()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]api.Card, 0, len(cards))
+	for _, c := range cards {
+		if match(c) {
+			out = append(out, c)
+		}
+	}
+
+	if err := f.sort(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// matcher compiles the filter flags into a single predicate.
+func (f *cardFilter) matcher() (func(api.Card) bool, error) {
+	var preds []func(api.Card) bool
+
+	if f.nameMatch != "" {
+		re, err := regexp.Compile(f.nameMatch)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --name-match: %w", err)
+		}
+		preds = append(preds, func(c api.Card) bool { return re.MatchString(c.Name) })
+	}
+
+	if len(f.labels) > 0 {
+		wanted := f.labels
+		preds = append(preds, func(c api.Card) bool {
+			for _, w := range wanted {
+				if !cardHasLabel(c, w) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+
+	if len(f.members) > 0 {
+		ids, err := resolveMemberIDs(f.members)
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, func(c api.Card) bool {
+			for _, id := range ids {
+				if !containsString(c.IDMembers, id) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+
+	if f.dueBefore != "" {
+		t, err := parseDateFlag(f.dueBefore)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --due-before: %w", err)
+		}
+		preds = append(preds, func(c api.Card) bool {
+			due, ok := cardDue(c)
+			return ok && due.Before(t)
+		})
+	}
+
+	if f.dueAfter != "" {
+		t, err := parseDateFlag(f.dueAfter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --due-after: %w", err)
+		}
+		preds = append(preds, func(c api.Card) bool {
+			due, ok := cardDue(c)
+			return ok && due.After(t)
+		})
+	}
+
+	if f.overdue {
+		now := time.Now()
+		preds = append(preds, func(c api.Card) bool {
+			due, ok := cardDue(c)
+			return ok && !c.DueComplete && due.Before(now)
+		})
+	}
+
+	if f.noDue {
+		preds = append(preds, func(c api.Card) bool {
+			_, ok := cardDue(c)
+			return !ok
+		})
+	}
+
+	if f.hasChecklist {
+		preds = append(preds, func(c api.Card) bool { return c.Badges.CheckItems > 0 })
+	}
+
+	if f.incompleteChecklist {
+		preds = append(preds, func(c api.Card) bool {
+			return c.Badges.CheckItemsChecked < c.Badges.CheckItems
+		})
+	}
+
+	return func(c api.Card) bool {
+		for _, p := range preds {
+			if !p(c) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// sort orders cards in place according to f.sortBy and f.reverse.
+func (f *cardFilter) sort(cards []api.Card) error {
+	var less func(a, b api.Card) bool
+
+	switch f.sortBy {
+	case "":
+		if f.reverse {
+			for i, j := 0, len(cards)-1; i < j; i, j = i+1, j-1 {
+				cards[i], cards[j] = cards[j], cards[i]
+			}
+		}
+		return nil
+	case "name":
+		less = func(a, b api.Card) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) }
+	case "pos":
+		less = func(a, b api.Card) bool { return a.Pos < b.Pos }
+	case "activity":
+		less = func(a, b api.Card) bool { return a.DateLastActivity < b.DateLastActivity }
+	case "due":
+		// Cards without a due date always sort last.
+		less = func(a, b api.Card) bool {
+			da, okA := cardDue(a)
+			db, okB := cardDue(b)
+			if okA != okB {
+				return okA
+			}
+			return da.Before(db)
+		}
+	case "list":
+		listPos, err := listPositions(cards)
+		if err != nil {
+			return err
+		}
+		less = func(a, b api.Card) bool {
+			if a.IDBoard != b.IDBoard {
+				return a.IDBoard < b.IDBoard
+			}
+			if a.IDList != b.IDList {
+				return listPos[a.IDList] < listPos[b.IDList]
+			}
+			return a.Pos < b.Pos
+		}
+	default:
+		return fmt.Errorf("invalid --sort %q: use due, name, pos, activity, or list", f.sortBy)
+	}
+
+	sort.SliceStable(cards, func(i, j int) bool {
+		if f.reverse {
+			return less(cards[j], cards[i])
+		}
+		return less(cards[i], cards[j])
+	})
+	return nil
+}
+
+// listPositions fetches the lists of every board the cards belong to and
+// returns a map of list ID to its position on the board.
+func listPositions(cards []api.Card) (map[string]float64, error) {
+	pos := map[string]float64{}
+	seen := map[string]bool{}
+	for _, c := range cards {
+		if c.IDBoard == "" || seen[c.IDBoard] {
+			continue
+		}
+		seen[c.IDBoard] = true
+		lists, err := client.GetBoardLists(c.IDBoard, "all")
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range lists {
+			pos[l.ID] = l.Pos
+		}
+	}
+	return pos, nil
+}
+
+// cardHasLabel reports whether c carries a label matching want by ID, name,
+// or color. A color name matches every label of that color, named or not.
+func cardHasLabel(c api.Card, want string) bool {
+	color := isLabelColor(want)
+	for _, l := range c.Labels {
+		if l.ID == want || strings.EqualFold(l.Name, want) || (color && strings.EqualFold(l.Color, want)) {
+			return true
+		}
+	}
+	return containsString(c.IDLabels, want)
+}
+
+// labelColors are the colors Trello offers for labels; each also comes in
+// _dark and _light variants.
+var labelColors = []string{"green", "yellow", "orange", "red", "purple", "blue", "sky", "lime", "pink", "black"}
+
+// isLabelColor reports whether s names a Trello label color, such as red or
+// red_dark.
+func isLabelColor(s string) bool {
+	s = strings.ToLower(s)
+	s = strings.TrimSuffix(strings.TrimSuffix(s, "_dark"), "_light")
+	return containsString(labelColors, s)
+}
+
+// cardDue returns the parsed due date of c, if set.
+func cardDue(c api.Card) (time.Time, bool) {
+	if c.Due == nil || *c.Due == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, *c.Due)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// parseDateFlag parses a date flag given as YYYY-MM-DD or a full ISO-8601 timestamp.
+func parseDateFlag(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02", s, time.Local)
+}
+
+// resolveMemberIDs maps member IDs, usernames, or "me" to member IDs.
+func resolveMemberIDs(refs []string) ([]string, error) {
+	ids := make([]string, 0, len(refs))
+	for _, r := range refs {
+		if isTrelloID(r) {
+			ids = append(ids, r)
+			continue
+		}
+		m, err := client.GetMember(strings.TrimPrefix(r, "@"), nil)
+		if err != nil {
+			return nil, fmt.Errorf("resolving member %q: %w", r, err)
+		}
+		ids = append(ids, m.ID)
+	}
+	return ids, nil
+}
+
+// isTrelloID reports whether s looks like a 24-character hex Trello object ID.
+func isTrelloID(s string) bool {
+	if len(s) != 24 {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsString reports whether ss contains s.
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -3,8 +3,12 @@ package cmd
 import (
 	"fmt"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/api"
 	"github.com/the20100/trello-cli/internal/output"
 )
 
@@ -19,6 +23,8 @@ var (
 	cardsListBoardID string
 	cardsListListID  string
 	cardsListFilter  string
+	cardsListCards   cardFilter
+	cardsListFields  []string
 )
 
 var cardsListCmd = &cobra.Command{
@@ -30,49 +36,87 @@ Examples:
   trello cards list --board <board-id>
   trello cards list --list <list-id>
   trello cards list --board <board-id> --filter all
+  trello cards list --board <board-id> --sort due
+  trello cards list --board <board-id> --label urgent --member me --overdue
+  trello cards list --list <list-id> --name-match "^\[bug\]" --sort name --reverse
+  trello cards list --board <board-id> --field "Story points" --field Priority
   trello cards list --board <board-id> --json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if cardsListListID == "" && cardsListBoardID == "" {
 			return fmt.Errorf("provide --board <board-id> or --list <list-id>")
 		}
 
-		if cardsListListID != "" {
-			c, err := client.GetListCards(cardsListListID, cardsListFilter)
-			if err != nil {
-				return err
-			}
-			if output.IsJSON(cmd) {
-				return output.PrintJSON(c, output.IsPretty(cmd))
-			}
-			printAPICardsTable(c)
-			return nil
+		params := buildParams("filter", cardsListFilter)
+		if len(cardsListFields) > 0 {
+			params.Set("customFieldItems", "true")
 		}
 
-		c, err := client.GetBoardCards(cardsListBoardID, cardsListFilter)
+		var c []api.Card
+		var err error
+		boardID := cardsListBoardID
+		if cardsListListID != "" {
+			c, err = client.GetListCardsWithParams(cardsListListID, params)
+		} else {
+			c, err = client.GetBoardCardsWithParams(cardsListBoardID, params)
+		}
+		if err != nil {
+			return err
+		}
+		c, err = cardsListCards.apply(c)
 		if err != nil {
 			return err
 		}
 		if output.IsJSON(cmd) {
 			return output.PrintJSON(c, output.IsPretty(cmd))
 		}
-		printAPICardsTable(c)
+
+		var fields []api.CustomField
+		if len(cardsListFields) > 0 {
+			if boardID == "" {
+				l, err := client.GetList(cardsListListID)
+				if err != nil {
+					return err
+				}
+				boardID = l.IDBoard
+			}
+			if fields, err = customFieldColumns(boardID, cardsListFields); err != nil {
+				return err
+			}
+		}
+		printAPICardsTable(c, fields...)
 		return nil
 	},
 }
 
 // ---- cards get ----
 
+var cardsGetComments int
+
 var cardsGetCmd = &cobra.Command{
 	Use:   "get <card-id>",
 	Short: "Get details of a specific card",
 	Long: `Get full details of a Trello card by its ID or short link.
 
+--comments N also shows the card's N most recent comments (included as
+"actions" in JSON output).
+
 Examples:
   trello cards get abc123
+  trello cards get abc123 --comments 5
   trello cards get abc123 --pretty`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		card, err := client.GetCard(args[0], nil)
+		params := url.Values{}
+		params.Set("customFieldItems", "true")
+		if cardsGetComments > 0 {
+			if cardsGetComments > maxActionsPage {
+				return fmt.Errorf("--comments can be at most %d", maxActionsPage)
+			}
+			params.Set("actions", "commentCard")
+			params.Set("actions_limit", fmt.Sprintf("%d", cardsGetComments))
+			params.Set("action_memberCreator_fields", "fullName,username")
+		}
+		card, err := client.GetCard(args[0], params)
 		if err != nil {
 			return err
 		}
@@ -95,7 +139,7 @@ Examples:
 			checklistSummary = fmt.Sprintf("%d/%d", card.Badges.CheckItemsChecked, card.Badges.CheckItems)
 		}
 
-		output.PrintKeyValue([][]string{
+		rows := [][]string{
 			{"ID", card.ID},
 			{"#", fmt.Sprintf("%d", card.IDShort)},
 			{"Name", card.Name},
@@ -111,7 +155,23 @@ Examples:
 			{"Comments", fmt.Sprintf("%d", card.Badges.Comments)},
 			{"Last Activity", output.FormatTime(card.DateLastActivity)},
 			{"Closed", output.FormatBool(card.Closed)},
-		})
+		}
+		if len(card.CustomFieldItems) > 0 {
+			fields, err := client.GetBoardCustomFields(card.IDBoard)
+			if err != nil {
+				return err
+			}
+			for _, f := range fields {
+				if it, ok := customFieldItem(*card, f); ok {
+					rows = append(rows, []string{f.Name, formatCustomFieldValue(f, it)})
+				}
+			}
+		}
+		output.PrintKeyValue(rows)
+		if cardsGetComments > 0 && len(card.Actions) > 0 {
+			fmt.Println()
+			printComments(card.Actions)
+		}
 		return nil
 	},
 }
@@ -119,13 +179,27 @@ Examples:
 // ---- cards create ----
 
 var (
-	cardsCreateListID string
-	cardsCreateDesc   string
-	cardsCreateDue    string
-	cardsCreateLabels string
-	cardsCreatePos    string
+	cardsCreateListID     string
+	cardsCreateDesc       string
+	cardsCreateDue        string
+	cardsCreateLabels     []string
+	cardsCreateMembers    []string
+	cardsCreateStart      string
+	cardsCreateReminder   string
+	cardsCreateURLSource  string
+	cardsCreateCopyFrom   string
+	cardsCreateKeep       []string
+	cardsCreatePos        string
+	cardsCreateDescFile   string
+	cardsCreatePreprocess bool
 )
 
+// keepFromSourceValues are the parts of a source card --keep can copy.
+var keepFromSourceValues = []string{
+	"all", "attachments", "checklists", "comments", "customFields",
+	"due", "start", "labels", "members", "stickers",
+}
+
 var cardsCreateCmd = &cobra.Command{
 	Use:   "create <name>",
 	Short: "Create a new card",
@@ -135,22 +209,49 @@ Examples:
   trello cards create "Fix the bug" --list <list-id>
   trello cards create "Deploy v2" --list <list-id> --desc "Deploy new version"
   trello cards create "Review PR" --list <list-id> --due 2024-12-31
-  trello cards create "Task" --list <list-id> --pos top`,
+  trello cards create "Task" --list <list-id> --pos top
+  trello cards create "Release notes" --list <list-id> --desc-file NOTES.md
+  generate-notes | trello cards create "Release" --list <list-id> --desc-file -
+  trello cards create "Build {{.Date}}" --list <list-id> --desc @build.md --preprocess
+  trello cards create "Triage" --list <list-id> --labels urgent,bug --members me,@alice
+  trello cards create "Launch" --list <list-id> --start 2024-09-01 --due 2024-09-30 --due-reminder 1d
+  trello cards create "Incident" --list <list-id> --url-source https://status.example.com/123
+  trello cards create "Sprint 12 retro" --list <list-id> --copy-from <card-id> --keep checklists,labels
+
+Labels are given by ID, or by name or color from the list's board; members by
+ID, username, or "me". --due-reminder is the number of minutes before the due
+date (or a duration such as 2h or 1d, or "none").
+
+--copy-from creates the card as a copy of another card (a template). --keep
+selects what is copied: all, or any of attachments, checklists, comments,
+customFields, due, start, labels, members, stickers (default: all).`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if cardsCreateListID == "" {
 			return fmt.Errorf("--list is required")
 		}
+		if cardsCreateReminder != "" && cardsCreateDue == "" {
+			return fmt.Errorf("--due-reminder requires --due")
+		}
+		if len(cardsCreateKeep) > 0 && cardsCreateCopyFrom == "" {
+			return fmt.Errorf("--keep requires --copy-from")
+		}
 
-		extra := url.Values{}
-		if cardsCreateDue != "" {
-			extra.Set("due", cardsCreateDue)
+		name, err := resolveArgText(args[0], "", "name", cardsCreatePreprocess)
+		if err != nil {
+			return err
 		}
-		if cardsCreatePos != "" {
-			extra.Set("pos", cardsCreatePos)
+		desc, err := resolveText(cardsCreateDesc, cardsCreateDescFile, "desc", cardsCreatePreprocess)
+		if err != nil {
+			return err
 		}
 
-		card, err := client.CreateCard(cardsCreateListID, args[0], cardsCreateDesc, extra)
+		extra, err := cardCreateParams()
+		if err != nil {
+			return err
+		}
+
+		card, err := client.CreateCard(cardsCreateListID, name, desc, extra)
 		if err != nil {
 			return err
 		}
@@ -166,42 +267,199 @@ Examples:
 	},
 }
 
+// cardCreateParams builds the optional CreateCard params from the flags of
+// cards create, resolving label, member, and card references.
+func cardCreateParams() (url.Values, error) {
+	extra := url.Values{}
+	if cardsCreateDue != "" {
+		extra.Set("due", cardsCreateDue)
+	}
+	if cardsCreatePos != "" {
+		extra.Set("pos", cardsCreatePos)
+	}
+	if cardsCreateStart != "" {
+		t, err := parseDateFlag(cardsCreateStart)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --start: %w", err)
+		}
+		extra.Set("start", t.UTC().Format(time.RFC3339))
+	}
+	if cardsCreateReminder != "" {
+		minutes, err := parseDueReminder(cardsCreateReminder)
+		if err != nil {
+			return nil, err
+		}
+		extra.Set("dueReminder", fmt.Sprintf("%d", minutes))
+	}
+	if cardsCreateURLSource != "" {
+		if !isLink(cardsCreateURLSource) {
+			return nil, fmt.Errorf("invalid --url-source %q: must be an http(s) URL", cardsCreateURLSource)
+		}
+		extra.Set("urlSource", cardsCreateURLSource)
+	}
+
+	if len(cardsCreateLabels) > 0 {
+		ids, err := resolveLabelIDs(cardsCreateListID, cardsCreateLabels)
+		if err != nil {
+			return nil, err
+		}
+		extra.Set("idLabels", strings.Join(ids, ","))
+	}
+	if len(cardsCreateMembers) > 0 {
+		ids, err := resolveMemberIDs(cardsCreateMembers)
+		if err != nil {
+			return nil, err
+		}
+		extra.Set("idMembers", strings.Join(ids, ","))
+	}
+
+	if cardsCreateCopyFrom != "" {
+		src, err := resolveRef(cardsCreateCopyFrom, "card", "")
+		if err != nil {
+			return nil, fmt.Errorf("--copy-from: %w", err)
+		}
+		extra.Set("idCardSource", src.ID)
+		keep := cardsCreateKeep
+		if len(keep) == 0 {
+			keep = []string{"all"}
+		}
+		for _, k := range keep {
+			if !containsString(keepFromSourceValues, k) {
+				return nil, fmt.Errorf("invalid --keep value %q: use %s", k, strings.Join(keepFromSourceValues, ", "))
+			}
+		}
+		extra.Set("keepFromSource", strings.Join(keep, ","))
+	}
+	return extra, nil
+}
+
+// resolveLabelIDs maps label IDs, names, or colors to IDs on the board of listID.
+func resolveLabelIDs(listID string, refs []string) ([]string, error) {
+	var labels []api.Label
+	ids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		if isTrelloID(ref) {
+			ids = append(ids, ref)
+			continue
+		}
+		if labels == nil {
+			list, err := client.GetList(listID)
+			if err != nil {
+				return nil, err
+			}
+			if labels, err = client.GetBoardLabels(list.IDBoard); err != nil {
+				return nil, err
+			}
+		}
+		l, ok := findLabel(labels, ref)
+		if !ok {
+			return nil, fmt.Errorf("no label %q on the list's board", ref)
+		}
+		ids = append(ids, l.ID)
+	}
+	return ids, nil
+}
+
+// parseDueReminder converts --due-reminder to minutes before the due date.
+// It accepts a number of minutes, a duration such as 90m, 2h, or 1d, or
+// "none" (-1, no reminder).
+func parseDueReminder(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "none" {
+		return -1, nil
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 {
+		return n, nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return n * 24 * 60, nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return int(d.Minutes()), nil
+	}
+	return 0, fmt.Errorf("invalid --due-reminder %q: use minutes, a duration like 2h or 1d, or none", s)
+}
+
 // ---- cards update ----
 
 var (
-	cardsUpdateName    string
-	cardsUpdateDesc    string
-	cardsUpdateDue     string
-	cardsUpdateClosed  bool
+	cardsUpdateName        string
+	cardsUpdateDesc        string
+	cardsUpdateDue         string
+	cardsUpdateClosed      bool
 	cardsUpdateDueComplete bool
+	cardsUpdateDescFile    string
+	cardsUpdatePreprocess  bool
+	cardsUpdateBulk        bulkFlags
 )
 
 var cardsUpdateCmd = &cobra.Command{
-	Use:   "update <card-id>",
-	Short: "Update a card",
+	Use:   "update <card-id>...",
+	Short: "Update cards",
 	Long: `Update a Trello card's name, description, due date, or state.
 
+` + bulkHelp + `
+
 Examples:
   trello cards update abc123 --name "New title"
   trello cards update abc123 --desc "Updated description"
   trello cards update abc123 --due 2024-12-31
   trello cards update abc123 --due-complete
-  trello cards update abc123 --closed`,
-	Args: cobra.ExactArgs(1),
+  trello cards update abc123 --closed
+  trello cards update abc123 --desc-file README.md
+  trello cards update abc123 --desc @notes.md --preprocess
+  trello cards update abc123 def456 --due-complete
+  trello cards update --in "Sprint 12" --where 'list = Done and not complete' --due-complete`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if containsString(args, "-") && cardsUpdateDescFile == "-" {
+			return fmt.Errorf("stdin can be used for card IDs or --desc-file, not both")
+		}
+		name, err := resolveText(cardsUpdateName, "", "name", cardsUpdatePreprocess)
+		if err != nil {
+			return err
+		}
+		desc, err := resolveText(cardsUpdateDesc, cardsUpdateDescFile, "desc", cardsUpdatePreprocess)
+		if err != nil {
+			return err
+		}
+
 		params := buildParams(
-			"name", cardsUpdateName,
-			"desc", cardsUpdateDesc,
+			"name", name,
+			"desc", desc,
 			"due", cardsUpdateDue,
 		)
 		if cmd.Flags().Changed("closed") {
-			params.Set("closed", output.FormatBool(cardsUpdateClosed))
+			params.Set("closed", strconv.FormatBool(cardsUpdateClosed))
 		}
 		if cmd.Flags().Changed("due-complete") {
-			params.Set("dueComplete", output.FormatBool(cardsUpdateDueComplete))
+			params.Set("dueComplete", strconv.FormatBool(cardsUpdateDueComplete))
+		}
+
+		if len(params) == 0 {
+			return fmt.Errorf("nothing to update: provide --name, --desc, --due, --closed, or --due-complete")
 		}
 
-		card, err := client.UpdateCard(args[0], params)
+		targets, bulk, err := cardsUpdateBulk.targets(args)
+		if err != nil {
+			return err
+		}
+		if bulk {
+			return runBulk(cmd, &cardsUpdateBulk, targets, "updated", func(id string, do func(func() error) error) (string, string, error) {
+				var card *api.Card
+				err := do(func() (err error) {
+					card, err = client.UpdateCard(id, params)
+					return err
+				})
+				if err != nil {
+					return "", "", err
+				}
+				return card.Name, "", nil
+			})
+		}
+
+		card, err := client.UpdateCard(targets[0].ID, params)
 		if err != nil {
 			return err
 		}
@@ -218,21 +476,36 @@ Examples:
 
 // ---- cards delete ----
 
+var cardsDeleteBulk bulkFlags
+
 var cardsDeleteCmd = &cobra.Command{
-	Use:   "delete <card-id>",
-	Short: "Delete a card",
+	Use:   "delete <card-id>...",
+	Short: "Delete cards",
 	Long: `Permanently delete a Trello card.
 
 This action cannot be undone.
 
+` + bulkHelp + `
+
 Examples:
-  trello cards delete abc123`,
-	Args: cobra.ExactArgs(1),
+  trello cards delete abc123
+  trello cards delete abc123 def456
+  trello cards delete --in "Sprint 12" --where 'archived and activity < -90d'`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if err := client.DeleteCard(args[0]); err != nil {
+		targets, bulk, err := cardsDeleteBulk.targets(args)
+		if err != nil {
+			return err
+		}
+		if bulk {
+			return runBulk(cmd, &cardsDeleteBulk, targets, "deleted", func(id string, do func(func() error) error) (string, string, error) {
+				return "", "", do(func() error { return client.DeleteCard(id) })
+			})
+		}
+
+		if err := client.DeleteCard(targets[0].ID); err != nil {
 			return err
 		}
-		fmt.Printf("Card %s deleted.\n", args[0])
+		fmt.Printf("Card %s deleted.\n", targets[0].ID)
 		return nil
 	},
 }
@@ -240,25 +513,48 @@ Examples:
 // ---- cards move ----
 
 var (
-	cardsMoveListID  string
-	cardsMoveBoard   string
+	cardsMoveListID string
+	cardsMoveBoard  string
+	cardsMoveBulk   bulkFlags
 )
 
 var cardsMoveCmd = &cobra.Command{
-	Use:   "move <card-id>",
-	Short: "Move a card to a different list",
+	Use:   "move <card-id>...",
+	Short: "Move cards to a different list",
 	Long: `Move a Trello card to a different list (and optionally a different board).
 
+` + bulkHelp + `
+
 Examples:
   trello cards move abc123 --list <list-id>
-  trello cards move abc123 --list <list-id> --board <board-id>`,
-	Args: cobra.ExactArgs(1),
+  trello cards move abc123 --list <list-id> --board <board-id>
+  trello cards move abc123 def456 ghi789 --list <list-id>
+  trello cards list --board <board-id> --overdue --json | jq -r '.[].id' | trello cards move - --list <list-id>
+  trello cards move --in "Sprint 12" --where 'list = Review and label = approved' --list <list-id>`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if cardsMoveListID == "" {
 			return fmt.Errorf("--list is required")
 		}
 
-		card, err := client.MoveCard(args[0], cardsMoveListID, cardsMoveBoard)
+		targets, bulk, err := cardsMoveBulk.targets(args)
+		if err != nil {
+			return err
+		}
+		if bulk {
+			return runBulk(cmd, &cardsMoveBulk, targets, "moved", func(id string, do func(func() error) error) (string, string, error) {
+				var card *api.Card
+				err := do(func() (err error) {
+					card, err = client.MoveCard(id, cardsMoveListID, cardsMoveBoard)
+					return err
+				})
+				if err != nil {
+					return "", "", err
+				}
+				return card.Name, "", nil
+			})
+		}
+
+		card, err := client.MoveCard(targets[0].ID, cardsMoveListID, cardsMoveBoard)
 		if err != nil {
 			return err
 		}
@@ -275,17 +571,40 @@ Examples:
 
 // ---- cards archive ----
 
+var cardsArchiveBulk bulkFlags
+
 var cardsArchiveCmd = &cobra.Command{
-	Use:   "archive <card-id>",
-	Short: "Archive a card",
+	Use:   "archive <card-id>...",
+	Short: "Archive cards",
 	Long: `Archive (close) a Trello card.
 
+` + bulkHelp + `
+
 Examples:
-  trello cards archive abc123`,
-	Args: cobra.ExactArgs(1),
+  trello cards archive abc123
+  trello cards archive abc123 def456
+  trello cards archive --in "Sprint 12" --where 'list = Done and activity < -30d'`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		params := buildParams("closed", "true")
-		card, err := client.UpdateCard(args[0], params)
+		targets, bulk, err := cardsArchiveBulk.targets(args)
+		if err != nil {
+			return err
+		}
+		if bulk {
+			return runBulk(cmd, &cardsArchiveBulk, targets, "archived", func(id string, do func(func() error) error) (string, string, error) {
+				var card *api.Card
+				err := do(func() (err error) {
+					card, err = client.UpdateCard(id, params)
+					return err
+				})
+				if err != nil {
+					return "", "", err
+				}
+				return card.Name, "", nil
+			})
+		}
+
+		card, err := client.UpdateCard(targets[0].ID, params)
 		if err != nil {
 			return err
 		}
@@ -301,16 +620,70 @@ Examples:
 
 // ---- cards comment ----
 
+var (
+	cardsCommentFile       string
+	cardsCommentPreprocess bool
+	cardsCommentBulk       bulkFlags
+)
+
 var cardsCommentCmd = &cobra.Command{
-	Use:   "comment <card-id> <text>",
-	Short: "Add a comment to a card",
+	Use:   "comment <card-id>... [text]",
+	Short: "Add a comment to cards",
 	Long: `Add a comment to a Trello card.
 
+The text can be given as the last argument (taken literally, so "@bob" is a
+mention) or with --comment-file (use - to read stdin); with --comment-file
+every argument is a card. With
+--preprocess the text is rendered as a template: {{.Date}}, {{.Time}},
+{{.User}}, {{.Env.NAME}}, {{include "file"}} (the file as is),
+{{includeTemplate "file"}} (the file rendered as a template too),
+{{tail N "text"}} and {{code "text"}} are available.
+
+` + bulkHelp + `
+
 Examples:
-  trello cards comment abc123 "This is a comment"`,
-	Args: cobra.ExactArgs(2),
+  trello cards comment abc123 "This is a comment"
+  trello cards comment abc123 --comment-file review.md
+  make test 2>&1 | trello cards comment abc123 --comment-file -
+  trello cards comment abc123 --preprocess 'Build {{.Env.CI_JOB_ID}} on {{.Date}}:
+{{include "build.log" | tail 50 | code}}'
+  trello cards comment abc123 def456 "Moved to next sprint"
+  trello cards comment --in "Sprint 12" --where overdue "This is overdue, please update"`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		action, err := client.AddComment(args[0], args[1])
+		cards, value := args, ""
+		if cardsCommentFile == "" && len(args) > 0 && (len(args) > 1 || cardsCommentBulk.where != "") {
+			cards, value = args[:len(args)-1], args[len(args)-1]
+		}
+		if containsString(cards, "-") && cardsCommentFile == "-" {
+			return fmt.Errorf("stdin can be used for card IDs or the comment, not both")
+		}
+		text, err := resolveArgText(value, cardsCommentFile, "comment", cardsCommentPreprocess)
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(text) == "" {
+			return fmt.Errorf("provide the comment text as an argument or with --comment-file")
+		}
+
+		targets, bulk, err := cardsCommentBulk.targets(cards)
+		if err != nil {
+			return err
+		}
+		if bulk {
+			return runBulk(cmd, &cardsCommentBulk, targets, "commented on", func(id string, do func(func() error) error) (string, string, error) {
+				var action *api.Action
+				err := do(func() (err error) {
+					action, err = client.AddComment(id, text)
+					return err
+				})
+				if err != nil {
+					return "", "", err
+				}
+				return "", "comment " + action.ID, nil
+			})
+		}
+
+		action, err := client.AddComment(targets[0].ID, text)
 		if err != nil {
 			return err
 		}
@@ -319,7 +692,7 @@ Examples:
 			return output.PrintJSON(action, output.IsPretty(cmd))
 		}
 
-		fmt.Printf("Comment added to card %s.\n", args[0])
+		fmt.Printf("Comment added to card %s.\n", targets[0].ID)
 		fmt.Printf("Action ID: %s\n", action.ID)
 		return nil
 	},
@@ -415,34 +788,49 @@ Examples:
 var (
 	cardsLabelAdd    string
 	cardsLabelRemove string
+	cardsLabelBulk   bulkFlags
 )
 
 var cardsLabelCmd = &cobra.Command{
-	Use:   "label <card-id>",
-	Short: "Add or remove labels on a card",
+	Use:   "label <card-id>...",
+	Short: "Add or remove labels on cards",
 	Long: `Add or remove labels on a Trello card.
 
+` + bulkHelp + `
+
 Examples:
   trello cards label abc123 --add <label-id>
-  trello cards label abc123 --remove <label-id>`,
-	Args: cobra.ExactArgs(1),
+  trello cards label abc123 --remove <label-id>
+  trello cards label abc123 def456 --add <label-id>
+  trello cards label --in "Sprint 12" --where 'overdue and label != urgent' --add <label-id>`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if cardsLabelAdd == "" && cardsLabelRemove == "" {
 			return fmt.Errorf("provide --add <label-id> or --remove <label-id>")
 		}
 
+		targets, bulk, err := cardsLabelBulk.targets(args)
+		if err != nil {
+			return err
+		}
+		if bulk {
+			return runBulk(cmd, &cardsLabelBulk, targets, "labelled", func(id string, do func(func() error) error) (string, string, error) {
+				return "", "", updateCardRefs(do, id, cardsLabelAdd, cardsLabelRemove, client.AddLabelToCard, client.RemoveLabelFromCard)
+			})
+		}
+
+		cardID := targets[0].ID
 		if cardsLabelAdd != "" {
-			if err := client.AddLabelToCard(args[0], cardsLabelAdd); err != nil {
+			if err := client.AddLabelToCard(cardID, cardsLabelAdd); err != nil {
 				return err
 			}
-			fmt.Printf("Label %s added to card %s.\n", cardsLabelAdd, args[0])
+			fmt.Printf("Label %s added to card %s.\n", cardsLabelAdd, cardID)
 		}
 
 		if cardsLabelRemove != "" {
-			if err := client.RemoveLabelFromCard(args[0], cardsLabelRemove); err != nil {
+			if err := client.RemoveLabelFromCard(cardID, cardsLabelRemove); err != nil {
 				return err
 			}
-			fmt.Printf("Label %s removed from card %s.\n", cardsLabelRemove, args[0])
+			fmt.Printf("Label %s removed from card %s.\n", cardsLabelRemove, cardID)
 		}
 
 		return nil
@@ -454,52 +842,95 @@ Examples:
 var (
 	cardsMemberAdd    string
 	cardsMemberRemove string
+	cardsMemberBulk   bulkFlags
 )
 
 var cardsMemberCmd = &cobra.Command{
-	Use:   "member <card-id>",
-	Short: "Add or remove members from a card",
+	Use:   "member <card-id>...",
+	Short: "Add or remove members from cards",
 	Long: `Assign or unassign members from a Trello card.
 
+` + bulkHelp + `
+
 Examples:
   trello cards member abc123 --add <member-id>
-  trello cards member abc123 --remove <member-id>`,
-	Args: cobra.ExactArgs(1),
+  trello cards member abc123 --remove <member-id>
+  trello cards member abc123 def456 --add <member-id>
+  trello cards member --in "Sprint 12" --where 'member = alice and list != Done' --remove <member-id>`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if cardsMemberAdd == "" && cardsMemberRemove == "" {
 			return fmt.Errorf("provide --add <member-id> or --remove <member-id>")
 		}
 
+		targets, bulk, err := cardsMemberBulk.targets(args)
+		if err != nil {
+			return err
+		}
+		if bulk {
+			return runBulk(cmd, &cardsMemberBulk, targets, "updated", func(id string, do func(func() error) error) (string, string, error) {
+				return "", "", updateCardRefs(do, id, cardsMemberAdd, cardsMemberRemove, client.AddMemberToCard, client.RemoveMemberFromCard)
+			})
+		}
+
+		cardID := targets[0].ID
 		if cardsMemberAdd != "" {
-			if err := client.AddMemberToCard(args[0], cardsMemberAdd); err != nil {
+			if err := client.AddMemberToCard(cardID, cardsMemberAdd); err != nil {
 				return err
 			}
-			fmt.Printf("Member %s added to card %s.\n", cardsMemberAdd, args[0])
+			fmt.Printf("Member %s added to card %s.\n", cardsMemberAdd, cardID)
 		}
 
 		if cardsMemberRemove != "" {
-			if err := client.RemoveMemberFromCard(args[0], cardsMemberRemove); err != nil {
+			if err := client.RemoveMemberFromCard(cardID, cardsMemberRemove); err != nil {
 				return err
 			}
-			fmt.Printf("Member %s removed from card %s.\n", cardsMemberRemove, args[0])
+			fmt.Printf("Member %s removed from card %s.\n", cardsMemberRemove, cardID)
 		}
 
 		return nil
 	},
 }
 
+// updateCardRefs adds and removes a label or member on a card for the
+// bulk label and member commands; either ID may be empty. Each request goes
+// through do on its own, so retrying the removal does not repeat the add.
+func updateCardRefs(do func(func() error) error, cardID, add, remove string, addFn, removeFn func(cardID, id string) error) error {
+	if add != "" {
+		if err := do(func() error { return addFn(cardID, add) }); err != nil {
+			return err
+		}
+	}
+	if remove != "" {
+		return do(func() error { return removeFn(cardID, remove) })
+	}
+	return nil
+}
+
 func init() {
 	// cards list flags
 	cardsListCmd.Flags().StringVar(&cardsListBoardID, "board", "", "Board ID")
 	cardsListCmd.Flags().StringVar(&cardsListListID, "list", "", "List ID")
 	cardsListCmd.Flags().StringVar(&cardsListFilter, "filter", "open", "Filter: open, closed, all, visible")
+	cardsListCmd.Flags().StringSliceVar(&cardsListFields, "field", nil, "Show a custom field as a column (repeatable)")
+	addCardFilterFlags(cardsListCmd, &cardsListCards)
+
+	// cards get flags
+	cardsGetCmd.Flags().IntVar(&cardsGetComments, "comments", 0, "Also show the N most recent comments")
 
 	// cards create flags
 	cardsCreateCmd.Flags().StringVar(&cardsCreateListID, "list", "", "List ID (required)")
 	cardsCreateCmd.Flags().StringVar(&cardsCreateDesc, "desc", "", "Card description")
 	cardsCreateCmd.Flags().StringVar(&cardsCreateDue, "due", "", "Due date (ISO-8601, e.g. 2024-12-31)")
 	cardsCreateCmd.Flags().StringVar(&cardsCreatePos, "pos", "", "Position: top, bottom, or a positive float")
-	cardsCreateCmd.Flags().StringVar(&cardsCreateLabels, "labels", "", "Comma-separated label IDs to add")
+	cardsCreateCmd.Flags().StringSliceVar(&cardsCreateLabels, "labels", nil, "Labels to add, by ID, name, or color (comma-separated)")
+	cardsCreateCmd.Flags().StringSliceVar(&cardsCreateMembers, "members", nil, "Members to assign, by ID, username, or me (comma-separated)")
+	cardsCreateCmd.Flags().StringVar(&cardsCreateStart, "start", "", "Start date (YYYY-MM-DD or ISO-8601)")
+	cardsCreateCmd.Flags().StringVar(&cardsCreateReminder, "due-reminder", "", "Reminder before the due date: minutes, a duration (2h, 1d), or none")
+	cardsCreateCmd.Flags().StringVar(&cardsCreateURLSource, "url-source", "", "URL to attach to the new card")
+	cardsCreateCmd.Flags().StringVar(&cardsCreateCopyFrom, "copy-from", "", "Create the card as a copy of this card")
+	cardsCreateCmd.Flags().StringSliceVar(&cardsCreateKeep, "keep", nil, "What to copy with --copy-from: all, checklists, attachments, ... (default all)")
+	cardsCreateCmd.Flags().StringVar(&cardsCreateDescFile, "desc-file", "", "Read the description from a file (- for stdin)")
+	cardsCreateCmd.Flags().BoolVar(&cardsCreatePreprocess, "preprocess", false, "Render name and description as Markdown templates ({{.Date}}, {{include \"file\"}}, ...)")
 
 	// cards update flags
 	cardsUpdateCmd.Flags().StringVar(&cardsUpdateName, "name", "", "New card name")
@@ -507,6 +938,12 @@ func init() {
 	cardsUpdateCmd.Flags().StringVar(&cardsUpdateDue, "due", "", "Due date (ISO-8601)")
 	cardsUpdateCmd.Flags().BoolVar(&cardsUpdateClosed, "closed", false, "Archive the card")
 	cardsUpdateCmd.Flags().BoolVar(&cardsUpdateDueComplete, "due-complete", false, "Mark due date as complete")
+	cardsUpdateCmd.Flags().StringVar(&cardsUpdateDescFile, "desc-file", "", "Read the description from a file (- for stdin)")
+	cardsUpdateCmd.Flags().BoolVar(&cardsUpdatePreprocess, "preprocess", false, "Render name and description as Markdown templates ({{.Date}}, {{include \"file\"}}, ...)")
+
+	// cards comment flags
+	cardsCommentCmd.Flags().StringVar(&cardsCommentFile, "comment-file", "", "Read the comment from a file (- for stdin)")
+	cardsCommentCmd.Flags().BoolVar(&cardsCommentPreprocess, "preprocess", false, "Render the comment as a Markdown template ({{.Date}}, {{include \"file\"}}, ...)")
 
 	// cards move flags
 	cardsMoveCmd.Flags().StringVar(&cardsMoveListID, "list", "", "Target list ID (required)")
@@ -520,6 +957,15 @@ func init() {
 	cardsMemberCmd.Flags().StringVar(&cardsMemberAdd, "add", "", "Member ID to add")
 	cardsMemberCmd.Flags().StringVar(&cardsMemberRemove, "remove", "", "Member ID to remove")
 
+	// bulk card selection flags
+	addBulkFlags(cardsUpdateCmd, &cardsUpdateBulk)
+	addBulkFlags(cardsDeleteCmd, &cardsDeleteBulk)
+	addBulkFlags(cardsMoveCmd, &cardsMoveBulk)
+	addBulkFlags(cardsArchiveCmd, &cardsArchiveBulk)
+	addBulkFlags(cardsCommentCmd, &cardsCommentBulk)
+	addBulkFlags(cardsLabelCmd, &cardsLabelBulk)
+	addBulkFlags(cardsMemberCmd, &cardsMemberBulk)
+
 	cardsCmd.AddCommand(
 		cardsListCmd,
 		cardsGetCmd,
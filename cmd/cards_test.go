@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+const (
+	testBoardID = "5f0000000000000000000b01"
+	testListID  = "5f0000000000000000000a01"
+	testCardID  = "5f0000000000000000000c01"
+	testLabelID = "5f0000000000000000000e01"
+)
+
+// TestCreateFlags checks that every flag of cards create and boards create
+// reaches the API request.
+func TestCreateFlags(t *testing.T) {
+	start, err := parseDateFlag("2024-09-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		args  []string
+		path  string
+		query map[string]string
+	}{
+		{
+			name:  "labels by name, color, and ID",
+			args:  []string{"cards", "create", "Triage", "--list", testListID, "--labels", "urgent,green," + testLabelID},
+			path:  "/cards",
+			query: map[string]string{"idList": testListID, "name": "Triage", "idLabels": "lab-urgent,lab-green," + testLabelID},
+		},
+		{
+			name:  "members by username and me",
+			args:  []string{"cards", "create", "Triage", "--list", testListID, "--members", "me,@alice"},
+			path:  "/cards",
+			query: map[string]string{"idMembers": "mem-me,mem-alice"},
+		},
+		{
+			name:  "start",
+			args:  []string{"cards", "create", "Launch", "--list", testListID, "--start", "2024-09-01"},
+			path:  "/cards",
+			query: map[string]string{"start": start.UTC().Format(time.RFC3339)},
+		},
+		{
+			name:  "due reminder as a duration",
+			args:  []string{"cards", "create", "Launch", "--list", testListID, "--due", "2024-09-30", "--due-reminder", "1d"},
+			path:  "/cards",
+			query: map[string]string{"due": "2024-09-30", "dueReminder": "1440"},
+		},
+		{
+			name:  "due reminder none",
+			args:  []string{"cards", "create", "Launch", "--list", testListID, "--due", "2024-09-30", "--due-reminder", "none"},
+			path:  "/cards",
+			query: map[string]string{"dueReminder": "-1"},
+		},
+		{
+			name:  "url source",
+			args:  []string{"cards", "create", "Incident", "--list", testListID, "--url-source", "https://status.example.com/123"},
+			path:  "/cards",
+			query: map[string]string{"urlSource": "https://status.example.com/123"},
+		},
+		{
+			name:  "copy from with keep",
+			args:  []string{"cards", "create", "Retro", "--list", testListID, "--copy-from", testCardID, "--keep", "checklists,labels"},
+			path:  "/cards",
+			query: map[string]string{"idCardSource": testCardID, "keepFromSource": "checklists,labels"},
+		},
+		{
+			name:  "copy from keeps all by default",
+			args:  []string{"cards", "create", "Retro", "--list", testListID, "--copy-from", testCardID},
+			path:  "/cards",
+			query: map[string]string{"idCardSource": testCardID, "keepFromSource": "all"},
+		},
+		{
+			name:  "position and description",
+			args:  []string{"cards", "create", "Task", "--list", testListID, "--pos", "top", "--desc", "Details"},
+			path:  "/cards",
+			query: map[string]string{"pos": "top", "desc": "Details"},
+		},
+		{
+			name:  "board privacy",
+			args:  []string{"boards", "create", "Roadmap", "--privacy", "org", "--workspace", "ws1", "--desc", "Plans"},
+			path:  "/boards",
+			query: map[string]string{"name": "Roadmap", "prefs_permissionLevel": "org", "idOrganization": "ws1", "desc": "Plans"},
+		},
+		{
+			name:  "board privacy defaults to private",
+			args:  []string{"boards", "create", "Roadmap"},
+			path:  "/boards",
+			query: map[string]string{"prefs_permissionLevel": "private"},
+		},
+		{
+			name:  "board copy",
+			args:  []string{"boards", "copy", testBoardID, "Sprint 12", "--privacy", "public", "--no-cards"},
+			path:  "/boards",
+			query: map[string]string{"idBoardSource": testBoardID, "keepFromSource": "none", "prefs_permissionLevel": "public"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeTrello(t)
+			f.gets["/lists/"+testListID] = map[string]any{"id": testListID, "idBoard": testBoardID}
+			f.gets["/boards/"+testBoardID+"/labels"] = []map[string]any{
+				{"id": "lab-urgent", "name": "Urgent", "color": "red"},
+				{"id": "lab-green", "name": "", "color": "green"},
+			}
+			f.gets["/members/me"] = map[string]any{"id": "mem-me", "username": "me"}
+			f.gets["/members/alice"] = map[string]any{"id": "mem-alice", "username": "alice"}
+			f.gets["/cards/"+testCardID] = map[string]any{"id": testCardID, "name": "Template", "idBoard": testBoardID}
+
+			if _, err := runCommand(t, f, tt.args...); err != nil {
+				t.Fatalf("run: %v", err)
+			}
+			got := f.request(t, "POST", tt.path).Query
+			for k, want := range tt.query {
+				if v := got.Get(k); v != want {
+					t.Errorf("%s = %q, want %q", k, v, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateFlagErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"reminder without due", []string{"cards", "create", "X", "--list", testListID, "--due-reminder", "1h"}},
+		{"bad reminder", []string{"cards", "create", "X", "--list", testListID, "--due", "2024-09-30", "--due-reminder", "soon"}},
+		{"keep without copy", []string{"cards", "create", "X", "--list", testListID, "--keep", "labels"}},
+		{"bad keep", []string{"cards", "create", "X", "--list", testListID, "--copy-from", testCardID, "--keep", "votes"}},
+		{"bad url source", []string{"cards", "create", "X", "--list", testListID, "--url-source", "ftp://x"}},
+		{"unknown label", []string{"cards", "create", "X", "--list", testListID, "--labels", "nope"}},
+		{"bad privacy", []string{"boards", "create", "X", "--privacy", "secret"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeTrello(t)
+			f.gets["/lists/"+testListID] = map[string]any{"id": testListID, "idBoard": testBoardID}
+			f.gets["/boards/"+testBoardID+"/labels"] = []map[string]any{}
+			f.gets["/cards/"+testCardID] = map[string]any{"id": testCardID, "idBoard": testBoardID}
+			if _, err := runCommand(t, f, tt.args...); err == nil {
+				t.Fatal("expected an error")
+			}
+			if len(f.requests) != 0 {
+				t.Errorf("sent %d write requests, want none", len(f.requests))
+			}
+		})
+	}
+}
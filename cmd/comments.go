@@ -0,0 +1,283 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/api"
+	"github.com/the20100/trello-cli/internal/output"
+)
+
+var commentsCmd = &cobra.Command{
+	Use:   "comments",
+	Short: "Manage card comments",
+}
+
+// ---- comments list ----
+
+var (
+	commentsListBoard  string
+	commentsListLimit  int
+	commentsListAll    bool
+	commentsListOldest bool
+)
+
+var commentsListCmd = &cobra.Command{
+	Use:   "list <card>",
+	Short: "List the comments on a card",
+	Long: `List the comments on a card with their author, date, action ID, and
+Markdown text, newest first.
+
+The card can be an ID, short link, URL, or a name or "#<number>" with --board.
+The action ID shown for each comment is what "comments edit", "delete", and
+"reply" take.
+
+Examples:
+  trello comments list abc123
+  trello comments list "#42" --board "My Project" --oldest-first
+  trello comments list abc123 --all --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		card, err := resolveRef(args[0], "card", commentsListBoard)
+		if err != nil {
+			return err
+		}
+		limit := commentsListLimit
+		if commentsListAll {
+			limit = 0
+		}
+		comments, err := fetchActions("cards", card.ID, url.Values{"filter": {"commentCard"}}, limit)
+		if err != nil {
+			return err
+		}
+		if commentsListOldest {
+			for i, j := 0, len(comments)-1; i < j; i, j = i+1, j-1 {
+				comments[i], comments[j] = comments[j], comments[i]
+			}
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(comments, output.IsPretty(cmd))
+		}
+		if len(comments) == 0 {
+			fmt.Println("No comments found.")
+			return nil
+		}
+		printComments(comments)
+		return nil
+	},
+}
+
+// printComments prints comments as header lines followed by their text.
+func printComments(comments []api.Action) {
+	for i, a := range comments {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s · %s · %s\n", commentAuthor(a), output.FormatTime(a.Date), a.ID)
+		fmt.Println(a.Data.Text)
+	}
+}
+
+// commentAuthor returns "Full Name (@username)", or whatever part is known.
+func commentAuthor(a api.Action) string {
+	name := actionMemberName(a)
+	if m := a.MemberCreator; m != nil && m.Username != "" && m.Username != name {
+		return fmt.Sprintf("%s (@%s)", name, m.Username)
+	}
+	return name
+}
+
+// ---- comments edit ----
+
+var (
+	commentsEditFile       string
+	commentsEditPreprocess bool
+)
+
+var commentsEditCmd = &cobra.Command{
+	Use:   "edit <action-id> [text]",
+	Short: "Change the text of a comment",
+	Long: `Replace the text of a comment. Only comments you wrote can be edited.
+
+The new text is taken from the argument or --comment-file, like
+"cards comment". Without either, the current text is opened in $VISUAL or
+$EDITOR and saved when you quit; an unchanged text is not sent.
+
+Examples:
+  trello comments edit 5f1a2b3c4d5e6f7a8b9c0d1e "Fixed typo"
+  trello comments edit 5f1a2b3c4d5e6f7a8b9c0d1e --comment-file notes.md
+  trello comments edit 5f1a2b3c4d5e6f7a8b9c0d1e`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		value := ""
+		if len(args) > 1 {
+			value = args[1]
+		}
+		text, err := resolveArgText(value, commentsEditFile, "comment", commentsEditPreprocess)
+		if err != nil {
+			return err
+		}
+		if value == "" && commentsEditFile == "" {
+			orig, err := client.GetAction(args[0])
+			if err != nil {
+				return err
+			}
+			if text, err = editText(orig.Data.Text, "comment-*.md"); err != nil {
+				return err
+			}
+			if text == orig.Data.Text {
+				fmt.Println("Comment unchanged.")
+				return nil
+			}
+		}
+		if strings.TrimSpace(text) == "" {
+			return fmt.Errorf("comment text cannot be empty (use \"comments delete\" to remove it)")
+		}
+
+		action, err := client.UpdateComment(args[0], text)
+		if err != nil {
+			return err
+		}
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(action, output.IsPretty(cmd))
+		}
+		fmt.Printf("Comment %s updated.\n", args[0])
+		return nil
+	},
+}
+
+// editText opens text in the user's editor and returns the saved result.
+func editText(text, pattern string) (string, error) {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+	path := f.Name()
+	defer os.Remove(path)
+	if _, err := f.WriteString(text + "\n"); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	if err := launchEditor(path); err != nil {
+		return "", err
+	}
+	return readTextSource(path)
+}
+
+// ---- comments delete ----
+
+var commentsDeleteCmd = &cobra.Command{
+	Use:   "delete <action-id>",
+	Short: "Delete a comment",
+	Long: `Permanently delete a comment by its action ID.
+
+This action cannot be undone.
+
+Examples:
+  trello comments delete 5f1a2b3c4d5e6f7a8b9c0d1e`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := client.DeleteComment(args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("Comment %s deleted.\n", args[0])
+		return nil
+	},
+}
+
+// ---- comments reply ----
+
+var (
+	commentsReplyFile       string
+	commentsReplyPreprocess bool
+)
+
+var commentsReplyCmd = &cobra.Command{
+	Use:   "reply <action-id> [text]",
+	Short: "Reply to a comment, quoting it and mentioning its author",
+	Long: `Post a new comment on the same card that quotes the original comment and
+@mentions its author, so they are notified.
+
+The text is given like "cards comment": as an argument, or with
+--comment-file (- for stdin).
+
+Examples:
+  trello comments reply 5f1a2b3c4d5e6f7a8b9c0d1e "Done, thanks!"
+  trello comments reply 5f1a2b3c4d5e6f7a8b9c0d1e --comment-file answer.md`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		value := ""
+		if len(args) > 1 {
+			value = args[1]
+		}
+		text, err := resolveArgText(value, commentsReplyFile, "comment", commentsReplyPreprocess)
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(text) == "" {
+			return fmt.Errorf("provide the reply text as an argument or with --comment-file")
+		}
+
+		orig, err := client.GetAction(args[0])
+		if err != nil {
+			return err
+		}
+		if orig.Type != "commentCard" || orig.Data.Card == nil {
+			return fmt.Errorf("action %s is not a card comment", args[0])
+		}
+
+		action, err := client.AddComment(orig.Data.Card.ID, replyText(*orig, text))
+		if err != nil {
+			return err
+		}
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(action, output.IsPretty(cmd))
+		}
+		fmt.Printf("Reply added to card %s.\n", orig.Data.Card.ID)
+		fmt.Printf("Action ID: %s\n", action.ID)
+		return nil
+	},
+}
+
+// replyText quotes the original comment as a Markdown blockquote and starts
+// the reply with an @mention of its author.
+func replyText(orig api.Action, text string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(orig.Data.Text, "\n"), "\n") {
+		b.WriteString(strings.TrimRight("> "+line, " ") + "\n")
+	}
+	b.WriteString("\n")
+	if m := orig.MemberCreator; m != nil && m.Username != "" {
+		b.WriteString("@" + m.Username + " ")
+	}
+	b.WriteString(text)
+	return b.String()
+}
+
+func init() {
+	commentsListCmd.Flags().StringVar(&commentsListBoard, "board", "", "Board context for card names and #numbers")
+	commentsListCmd.Flags().IntVar(&commentsListLimit, "limit", 50, "Maximum number of comments to fetch")
+	commentsListCmd.Flags().BoolVar(&commentsListAll, "all", false, "Fetch every comment, paging as needed")
+	commentsListCmd.Flags().BoolVar(&commentsListOldest, "oldest-first", false, "Show the oldest comment first")
+
+	commentsEditCmd.Flags().StringVar(&commentsEditFile, "comment-file", "", "Read the new text from a file (- for stdin)")
+	commentsEditCmd.Flags().BoolVar(&commentsEditPreprocess, "preprocess", false, "Render the text as a Markdown template")
+
+	commentsReplyCmd.Flags().StringVar(&commentsReplyFile, "comment-file", "", "Read the reply from a file (- for stdin)")
+	commentsReplyCmd.Flags().BoolVar(&commentsReplyPreprocess, "preprocess", false, "Render the reply as a Markdown template")
+
+	commentsCmd.AddCommand(
+		commentsListCmd,
+		commentsEditCmd,
+		commentsDeleteCmd,
+		commentsReplyCmd,
+	)
+	rootCmd.AddCommand(commentsCmd)
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/api"
+	"github.com/the20100/trello-cli/internal/output"
+)
+
+var (
+	// dryRunning is set once a command runs in dry-run mode.
+	dryRunning bool
+	// dryRunCount is the number of operations a dry run skipped.
+	dryRunCount int
+)
+
+// startDryRun puts the client in dry-run mode. Each skipped operation is
+// printed to stderr, as text or, with JSON output, one JSON object per
+// line, so stdout keeps the command's usual (synthetic) output.
+func startDryRun(cmd *cobra.Command) {
+	dryRunning = true
+	client.SetDryRun(func(op api.Operation) {
+		reportDryRun(cmd, op)
+	})
+}
+
+// reportDryRun prints an operation a dry run skipped. Commands that make
+// changes without the API client call it directly.
+func reportDryRun(cmd *cobra.Command, op api.Operation) {
+	dryRunCount++
+	if output.IsJSON(cmd) {
+		data, _ := json.Marshal(map[string]any{"dryRun": op})
+		fmt.Fprintln(os.Stderr, string(data))
+		return
+	}
+	fmt.Fprintf(os.Stderr, "[dry-run] %s\n", op.Description)
+	fmt.Fprintf(os.Stderr, "          %s %s\n", op.Method, op.Path)
+	keys := make([]string, 0, len(op.Params))
+	for k := range op.Params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(os.Stderr, "          %s=%s\n", k, oneLine(strings.Join(op.Params[k], ","), 100))
+	}
+	if op.Body != nil {
+		data, _ := json.Marshal(op.Body)
+		fmt.Fprintf(os.Stderr, "          body: %s\n", oneLine(string(data), 100))
+	}
+}
+
+// finishDryRun reminds the user that nothing was changed. It runs from
+// Execute, so the reminder also follows commands that fail.
+func finishDryRun(cmd *cobra.Command) {
+	if output.IsJSON(cmd) {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Dry run: %d change(s) not made.\n", dryRunCount)
+}
@@ -0,0 +1,370 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/api"
+	"github.com/the20100/trello-cli/internal/output"
+	"github.com/the20100/trello-cli/internal/strutil"
+)
+
+// customFieldTypes are the field types supported by the Custom Fields power-up.
+var customFieldTypes = []string{"text", "number", "date", "checkbox", "list"}
+
+var fieldsCmd = &cobra.Command{
+	Use:   "fields",
+	Short: "Manage custom fields (Custom Fields power-up)",
+	Long: `Manage the custom field definitions of a board. The Custom Fields
+power-up must be enabled on the board.
+
+Set values on cards with "trello cards set-field", show them with
+"trello cards get" or as table columns with "trello cards list --field".`,
+}
+
+// findCustomField returns the field matching ref by ID or case-insensitive name.
+func findCustomField(fields []api.CustomField, ref string) (api.CustomField, bool) {
+	for _, f := range fields {
+		if f.ID == ref {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.Name, ref) {
+			return f, true
+		}
+	}
+	return api.CustomField{}, false
+}
+
+// customFieldColumns resolves --field references against a board's fields.
+func customFieldColumns(boardID string, refs []string) ([]api.CustomField, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	fields, err := client.GetBoardCustomFields(boardID)
+	if err != nil {
+		return nil, err
+	}
+	cols := make([]api.CustomField, len(refs))
+	for i, ref := range refs {
+		f, ok := findCustomField(fields, ref)
+		if !ok {
+			return nil, fmt.Errorf("no custom field %q on board %s", ref, boardID)
+		}
+		cols[i] = f
+	}
+	return cols, nil
+}
+
+// customFieldItem returns the value of field on c, if set.
+func customFieldItem(c api.Card, field api.CustomField) (api.CustomFieldItem, bool) {
+	for _, it := range c.CustomFieldItems {
+		if it.IDCustomField == field.ID {
+			return it, true
+		}
+	}
+	return api.CustomFieldItem{}, false
+}
+
+// formatCustomFieldValue renders a card's value for field for display.
+func formatCustomFieldValue(field api.CustomField, it api.CustomFieldItem) string {
+	if field.Type == "list" {
+		for _, o := range field.Options {
+			if o.ID == it.IDValue {
+				return o.Value.Text
+			}
+		}
+		return strutil.FirstNonEmpty(it.IDValue, "-")
+	}
+	if it.Value == nil {
+		return "-"
+	}
+	switch field.Type {
+	case "checkbox":
+		return output.FormatBool(it.Value.Checked == "true")
+	case "date":
+		return output.FormatTime(it.Value.Date)
+	case "number":
+		return it.Value.Number
+	}
+	return it.Value.Text
+}
+
+// parseCustomFieldValue converts a command-line value to what the API expects
+// for field: a value for text, number, date, and checkbox fields, or an
+// option ID for list fields.
+func parseCustomFieldValue(field api.CustomField, raw string) (*api.CustomFieldValue, string, error) {
+	switch field.Type {
+	case "text":
+		return &api.CustomFieldValue{Text: raw}, "", nil
+	case "number":
+		n, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("%s is a number field: %q is not a number", field.Name, raw)
+		}
+		return &api.CustomFieldValue{Number: strconv.FormatFloat(n, 'f', -1, 64)}, "", nil
+	case "date":
+		t, err := parseDateFlag(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, "", fmt.Errorf("%s is a date field: %w", field.Name, err)
+		}
+		return &api.CustomFieldValue{Date: t.UTC().Format(time.RFC3339)}, "", nil
+	case "checkbox":
+		switch strings.ToLower(strings.TrimSpace(raw)) {
+		case "true", "yes", "y", "on", "1", "checked":
+			return &api.CustomFieldValue{Checked: "true"}, "", nil
+		case "false", "no", "n", "off", "0", "unchecked":
+			return &api.CustomFieldValue{Checked: "false"}, "", nil
+		}
+		return nil, "", fmt.Errorf("%s is a checkbox field: use true or false, not %q", field.Name, raw)
+	case "list":
+		names := make([]string, len(field.Options))
+		for i, o := range field.Options {
+			if o.ID == raw || strings.EqualFold(o.Value.Text, raw) {
+				return nil, o.ID, nil
+			}
+			names[i] = o.Value.Text
+		}
+		return nil, "", fmt.Errorf("%s has no option %q (options: %s)", field.Name, raw, strings.Join(names, ", "))
+	}
+	return nil, "", fmt.Errorf("%s has unsupported field type %q", field.Name, field.Type)
+}
+
+// ---- fields list ----
+
+var fieldsListBoard string
+
+var fieldsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the custom fields of a board",
+	Long: `List the custom field definitions of a board, with list options.
+
+Examples:
+  trello fields list --board "My Project"
+  trello fields list --board abc123 --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if fieldsListBoard == "" {
+			return fmt.Errorf("--board is required")
+		}
+		boardID, err := resolveBoardID(fieldsListBoard)
+		if err != nil {
+			return err
+		}
+		fields, err := client.GetBoardCustomFields(boardID)
+		if err != nil {
+			return err
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(fields, output.IsPretty(cmd))
+		}
+		if len(fields) == 0 {
+			fmt.Println("No custom fields found.")
+			return nil
+		}
+		headers := []string{"ID", "NAME", "TYPE", "ON CARD FRONT", "OPTIONS"}
+		rows := make([][]string, len(fields))
+		for i, f := range fields {
+			opts := make([]string, len(f.Options))
+			for j, o := range f.Options {
+				opts[j] = o.Value.Text
+			}
+			rows[i] = []string{
+				f.ID,
+				output.Truncate(f.Name, 30),
+				f.Type,
+				output.FormatBool(f.Display.CardFront),
+				output.Truncate(output.FormatLabels(opts), 50),
+			}
+		}
+		output.PrintTable(headers, rows)
+		return nil
+	},
+}
+
+// ---- fields create ----
+
+var (
+	fieldsCreateBoard     string
+	fieldsCreateType      string
+	fieldsCreateOptions   []string
+	fieldsCreateCardFront bool
+)
+
+var fieldsCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a custom field on a board",
+	Long: `Create a custom field on a board.
+
+--type is one of text, number, date, checkbox, or list. List fields need
+their choices, given with --option (repeatable or comma-separated).
+
+Examples:
+  trello fields create "Story points" --board "My Project" --type number --card-front
+  trello fields create "Priority" --board abc123 --type list --option Low,Medium,High
+  trello fields create "Reviewed" --board abc123 --type checkbox`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if fieldsCreateBoard == "" {
+			return fmt.Errorf("--board is required")
+		}
+		if !containsString(customFieldTypes, fieldsCreateType) {
+			return fmt.Errorf("invalid --type %q: use one of %s", fieldsCreateType, strings.Join(customFieldTypes, ", "))
+		}
+		if fieldsCreateType == "list" && len(fieldsCreateOptions) == 0 {
+			return fmt.Errorf("list fields need at least one --option")
+		}
+		if fieldsCreateType != "list" && len(fieldsCreateOptions) > 0 {
+			return fmt.Errorf("--option only applies to list fields")
+		}
+		boardID, err := resolveBoardID(fieldsCreateBoard)
+		if err != nil {
+			return err
+		}
+
+		f, err := client.CreateCustomField(boardID, args[0], fieldsCreateType, fieldsCreateOptions, fieldsCreateCardFront)
+		if err != nil {
+			return err
+		}
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(f, output.IsPretty(cmd))
+		}
+		fmt.Printf("Custom field created: %s (%s)\n", f.Name, f.Type)
+		fmt.Printf("ID: %s\n", f.ID)
+		return nil
+	},
+}
+
+// ---- fields delete ----
+
+var fieldsDeleteBoard string
+
+var fieldsDeleteCmd = &cobra.Command{
+	Use:   "delete <field>",
+	Short: "Delete a custom field",
+	Long: `Permanently delete a custom field, by ID or by name with --board. Its
+values are removed from every card.
+
+This action cannot be undone.
+
+Examples:
+  trello fields delete 5f1a2b3c4d5e6f7a8b9c0d1e
+  trello fields delete "Story points" --board "My Project"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id := args[0]
+		if fieldsDeleteBoard != "" {
+			boardID, err := resolveBoardID(fieldsDeleteBoard)
+			if err != nil {
+				return err
+			}
+			fields, err := client.GetBoardCustomFields(boardID)
+			if err != nil {
+				return err
+			}
+			f, ok := findCustomField(fields, args[0])
+			if !ok {
+				return fmt.Errorf("no custom field %q on board %s", args[0], boardID)
+			}
+			id = f.ID
+		} else if !isTrelloID(id) {
+			return fmt.Errorf("%q: --board is required to look up a field by name", id)
+		}
+
+		if err := client.DeleteCustomField(id); err != nil {
+			return err
+		}
+		fmt.Printf("Custom field %s deleted.\n", args[0])
+		return nil
+	},
+}
+
+// ---- cards set-field ----
+
+var (
+	cardsSetFieldBoard string
+	cardsSetFieldClear bool
+)
+
+var cardsSetFieldCmd = &cobra.Command{
+	Use:   "set-field <card> <field> [value]",
+	Short: "Set a custom field on a card",
+	Long: `Set or clear the value of a custom field on a card. The field is given by
+name or ID; the value is parsed according to the field type:
+
+  text      any text
+  number    a number, e.g. 3 or 2.5
+  date      YYYY-MM-DD or an ISO-8601 timestamp
+  checkbox  true/false (also yes/no, on/off, 1/0)
+  list      the text (case-insensitive) or ID of an option
+
+Examples:
+  trello cards set-field abc123 "Story points" 5
+  trello cards set-field abc123 Priority high
+  trello cards set-field "#42" Reviewed yes --board "My Project"
+  trello cards set-field abc123 "Target date" 2024-09-30
+  trello cards set-field abc123 Priority --clear`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if cardsSetFieldClear == (len(args) == 3) {
+			return fmt.Errorf("provide either a value or --clear")
+		}
+		card, err := resolveRef(args[0], "card", cardsSetFieldBoard)
+		if err != nil {
+			return err
+		}
+		fields, err := client.GetBoardCustomFields(card.IDBoard)
+		if err != nil {
+			return err
+		}
+		field, ok := findCustomField(fields, args[1])
+		if !ok {
+			return fmt.Errorf("no custom field %q on the card's board", args[1])
+		}
+
+		var value *api.CustomFieldValue
+		var idValue string
+		if !cardsSetFieldClear {
+			if value, idValue, err = parseCustomFieldValue(field, args[2]); err != nil {
+				return err
+			}
+		}
+		if err := client.SetCardCustomField(card.ID, field.ID, value, idValue); err != nil {
+			return err
+		}
+
+		if cardsSetFieldClear {
+			fmt.Printf("Cleared %s on card %s.\n", field.Name, card.ID)
+		} else {
+			fmt.Printf("Set %s = %s on card %s.\n", field.Name, args[2], card.ID)
+		}
+		return nil
+	},
+}
+
+func init() {
+	fieldsListCmd.Flags().StringVar(&fieldsListBoard, "board", "", "Board ID, short link, URL, or name (required)")
+
+	fieldsCreateCmd.Flags().StringVar(&fieldsCreateBoard, "board", "", "Board ID, short link, URL, or name (required)")
+	fieldsCreateCmd.Flags().StringVar(&fieldsCreateType, "type", "text", "Field type: text, number, date, checkbox, list")
+	fieldsCreateCmd.Flags().StringSliceVar(&fieldsCreateOptions, "option", nil, "Option of a list field (repeatable)")
+	fieldsCreateCmd.Flags().BoolVar(&fieldsCreateCardFront, "card-front", false, "Show the field on the front of cards")
+
+	fieldsDeleteCmd.Flags().StringVar(&fieldsDeleteBoard, "board", "", "Board to look the field up by name")
+
+	cardsSetFieldCmd.Flags().StringVar(&cardsSetFieldBoard, "board", "", "Board context for card names and #numbers")
+	cardsSetFieldCmd.Flags().BoolVar(&cardsSetFieldClear, "clear", false, "Remove the field's value from the card")
+
+	fieldsCmd.AddCommand(
+		fieldsListCmd,
+		fieldsCreateCmd,
+		fieldsDeleteCmd,
+	)
+	rootCmd.AddCommand(fieldsCmd)
+	cardsCmd.AddCommand(cardsSetFieldCmd)
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/the20100/trello-cli/internal/api"
 	"github.com/the20100/trello-cli/internal/output"
@@ -20,14 +21,18 @@ func buildParams(pairs ...string) url.Values {
 	return p
 }
 
-// printAPICardsTable renders a slice of api.Card as a table.
-func printAPICardsTable(cards []api.Card) {
+// printAPICardsTable renders a slice of api.Card as a table, with a column
+// for each of fields (the cards must carry their custom field items).
+func printAPICardsTable(cards []api.Card, fields ...api.CustomField) {
 	if len(cards) == 0 {
 		fmt.Println("No cards found.")
 		return
 	}
 
 	headers := []string{"ID", "#", "NAME", "DUE", "LABELS"}
+	for _, f := range fields {
+		headers = append(headers, strings.ToUpper(f.Name))
+	}
 	rows := make([][]string, len(cards))
 	for i, c := range cards {
 		labelNames := make([]string, len(c.Labels))
@@ -45,6 +50,13 @@ func printAPICardsTable(cards []api.Card) {
 			output.FormatDate(c.Due),
 			output.FormatLabels(labelNames),
 		}
+		for _, f := range fields {
+			value := "-"
+			if it, ok := customFieldItem(c, f); ok {
+				value = formatCustomFieldValue(f, it)
+			}
+			rows[i] = append(rows[i], output.Truncate(value, 30))
+		}
 	}
 	output.PrintTable(headers, rows)
 }
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/the20100/trello-cli/internal/api"
+)
+
+// fakeTrello is a stand-in for the Trello API. GET requests are answered
+// from gets, keyed by path; other requests are recorded and answered from
+// writes, keyed by "METHOD path", or with an empty object. A write listed in
+// limited is first answered with that many 429s.
+type fakeTrello struct {
+	*httptest.Server
+	gets    map[string]any
+	writes  map[string]any
+	limited map[string]int
+
+	mu       sync.Mutex
+	requests []fakeRequest
+}
+
+// fakeRequest is a write request received by fakeTrello.
+type fakeRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+}
+
+func newFakeTrello(t *testing.T) *fakeTrello {
+	t.Helper()
+	f := &fakeTrello{gets: map[string]any{}, writes: map[string]any{}, limited: map[string]int{}}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeTrello) serve(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/1")
+	var body any = map[string]any{}
+	if r.Method == http.MethodGet {
+		b, ok := f.gets[path]
+		if !ok {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		body = b
+	} else {
+		f.mu.Lock()
+		f.requests = append(f.requests, fakeRequest{Method: r.Method, Path: path, Query: r.URL.Query()})
+		limited := f.limited[r.Method+" "+path] > 0
+		if limited {
+			f.limited[r.Method+" "+path]--
+		}
+		f.mu.Unlock()
+		if limited {
+			w.Header().Set("X-Rate-Limit-Api-Token-Remaining", "0")
+			w.Header().Set("X-Rate-Limit-Api-Token-Max", "100")
+			w.Header().Set("X-Rate-Limit-Api-Token-Interval-Ms", "10")
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		if b, ok := f.writes[r.Method+" "+path]; ok {
+			body = b
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+// client returns an API client that talks to f.
+func (f *fakeTrello) client(key, token string) *api.Client {
+	c := api.NewClient(key, token)
+	c.SetBaseURL(f.URL + "/1")
+	return c
+}
+
+// request returns the only write request sent to method and path.
+func (f *fakeTrello) request(t *testing.T, method, path string) fakeRequest {
+	t.Helper()
+	found := f.requestsTo(method, path)
+	if len(found) != 1 {
+		t.Fatalf("%s %s: got %d requests, want 1 (all: %+v)", method, path, len(found), f.requests)
+	}
+	return found[0]
+}
+
+// requestsTo returns the write requests sent to method and path.
+func (f *fakeTrello) requestsTo(method, path string) []fakeRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var found []fakeRequest
+	for _, r := range f.requests {
+		if r.Method == method && r.Path == path {
+			found = append(found, r)
+		}
+	}
+	return found
+}
+
+// runCommand runs the CLI with args against f and returns its stdout.
+// Flags are reset to their defaults first, so that runs do not leak into
+// each other.
+func runCommand(t *testing.T, f *fakeTrello, args ...string) (string, error) {
+	t.Helper()
+	t.Setenv("TRELLO_API_KEY", "test-key")
+	t.Setenv("TRELLO_API_TOKEN", "test-token")
+	newClient = f.client
+	t.Cleanup(func() { newClient = api.NewClient })
+	resetFlags(rootCmd)
+	dryRunning, dryRunCount = false, 0
+
+	out, err := os.CreateTemp(t.TempDir(), "stdout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = out
+	rootCmd.SetArgs(args)
+	runErr := rootCmd.Execute()
+	os.Stdout = stdout
+
+	out.Seek(0, io.SeekStart)
+	data, err := io.ReadAll(out)
+	out.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data), runErr
+}
+
+// resetFlags restores every flag of c and its subcommands to its default.
+func resetFlags(c *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if s, ok := f.Value.(pflag.SliceValue); ok {
+			s.Replace(nil)
+		} else {
+			f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	c.Flags().VisitAll(reset)
+	c.PersistentFlags().VisitAll(reset)
+	for _, sub := range c.Commands() {
+		resetFlags(sub)
+	}
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/the20100/trello-cli/internal/api"
+	"github.com/the20100/trello-cli/internal/output"
+)
+
+const (
+	kanbanGap      = 2
+	kanbanMinWidth = 18
+	kanbanMaxWidth = 40
+)
+
+// kanbanColumn is one list rendered as a board column.
+type kanbanColumn struct {
+	List  api.TrelloList `json:"list"`
+	Cards []api.Card     `json:"cards"`
+	More  int            `json:"more"`
+}
+
+// buildKanbanColumns groups cards under their lists (already in board order),
+// keeping only the lists selected by names (IDs or case-insensitive names; all if empty)
+// and at most maxCards cards per column (unlimited if <= 0).
+func buildKanbanColumns(lists []api.TrelloList, cards []api.Card, names []string, maxCards int) ([]kanbanColumn, error) {
+	byList := map[string][]api.Card{}
+	for _, c := range cards {
+		byList[c.IDList] = append(byList[c.IDList], c)
+	}
+
+	selected := lists
+	if len(names) > 0 {
+		selected = nil
+		for _, n := range names {
+			l, ok := findList(lists, n)
+			if !ok {
+				return nil, fmt.Errorf("list %q not found on board", n)
+			}
+			selected = append(selected, l)
+		}
+	}
+
+	cols := make([]kanbanColumn, len(selected))
+	for i, l := range selected {
+		cs := byList[l.ID]
+		col := kanbanColumn{List: l, Cards: cs}
+		if maxCards > 0 && len(cs) > maxCards {
+			col.Cards = cs[:maxCards]
+			col.More = len(cs) - maxCards
+		}
+		if col.Cards == nil {
+			col.Cards = []api.Card{}
+		}
+		cols[i] = col
+	}
+	return cols, nil
+}
+
+// findList returns the list whose ID or (case-insensitive) name matches ref.
+func findList(lists []api.TrelloList, ref string) (api.TrelloList, bool) {
+	for _, l := range lists {
+		if l.ID == ref {
+			return l, true
+		}
+	}
+	for _, l := range lists {
+		if strings.EqualFold(strings.TrimSpace(l.Name), strings.TrimSpace(ref)) {
+			return l, true
+		}
+	}
+	return api.TrelloList{}, false
+}
+
+// renderKanban lays out columns side by side within totalWidth characters.
+// Columns that do not fit are wrapped into additional bands below.
+func renderKanban(cols []kanbanColumn, totalWidth int) string {
+	if len(cols) == 0 {
+		return "No lists found.\n"
+	}
+
+	perBand := (totalWidth + kanbanGap) / (kanbanMinWidth + kanbanGap)
+	if perBand < 1 {
+		perBand = 1
+	}
+	if perBand > len(cols) {
+		perBand = len(cols)
+	}
+	width := (totalWidth+kanbanGap)/perBand - kanbanGap
+	if width > kanbanMaxWidth {
+		width = kanbanMaxWidth
+	}
+	if width < 8 {
+		width = 8
+	}
+
+	var b strings.Builder
+	for start := 0; start < len(cols); start += perBand {
+		end := start + perBand
+		if end > len(cols) {
+			end = len(cols)
+		}
+		if start > 0 {
+			b.WriteString("\n")
+		}
+		band := make([][]string, end-start)
+		height := 0
+		for i, col := range cols[start:end] {
+			band[i] = kanbanColumnLines(col, width)
+			if len(band[i]) > height {
+				height = len(band[i])
+			}
+		}
+		for row := 0; row < height; row++ {
+			var line strings.Builder
+			for i, lines := range band {
+				cell := ""
+				if row < len(lines) {
+					cell = lines[row]
+				}
+				if i < len(band)-1 {
+					line.WriteString(output.PadRight(cell, width))
+					line.WriteString(strings.Repeat(" ", kanbanGap))
+				} else {
+					line.WriteString(cell)
+				}
+			}
+			b.WriteString(strings.TrimRight(line.String(), " "))
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// kanbanColumnLines renders a single column as lines no wider than width.
+func kanbanColumnLines(col kanbanColumn, width int) []string {
+	total := len(col.Cards) + col.More
+	lines := []string{
+		output.Truncate(fmt.Sprintf("%s (%d)", strings.ToUpper(col.List.Name), total), width),
+		strings.Repeat("─", width),
+	}
+	now := time.Now()
+	for i, c := range col.Cards {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, output.Wrap(c.Name, width)...)
+		if meta := kanbanCardMeta(c, now); meta != "" {
+			lines = append(lines, output.Wrap(meta, width)...)
+		}
+	}
+	if col.More > 0 {
+		lines = append(lines, "", fmt.Sprintf("… +%d more", col.More))
+	}
+	return lines
+}
+
+// kanbanCardMeta builds the label chips, due marker, and member initials line for a card.
+func kanbanCardMeta(c api.Card, now time.Time) string {
+	var parts []string
+	for _, l := range c.Labels {
+		name := l.Name
+		if name == "" {
+			name = l.Color
+		}
+		parts = append(parts, "["+name+"]")
+	}
+	if due, ok := cardDue(c); ok {
+		marker := "⏰"
+		switch {
+		case c.DueComplete:
+			marker = "✓"
+		case due.Before(now):
+			marker = "!"
+		}
+		parts = append(parts, marker+due.Local().Format("01-02"))
+	}
+	if len(c.Members) > 0 {
+		initials := make([]string, len(c.Members))
+		for i, m := range c.Members {
+			initials[i] = memberInitials(m)
+		}
+		parts = append(parts, "@"+strings.Join(initials, ","))
+	}
+	return strings.Join(parts, " ")
+}
+
+// memberInitials returns the member's initials, deriving them from the
+// full name or username when Trello did not return any.
+func memberInitials(m api.Member) string {
+	if m.Initials != "" {
+		return m.Initials
+	}
+	var b strings.Builder
+	for _, f := range strings.Fields(m.FullName) {
+		b.WriteRune([]rune(f)[0])
+	}
+	if b.Len() > 0 {
+		return strings.ToUpper(b.String())
+	}
+	return m.Username
+}
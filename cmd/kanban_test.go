@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/the20100/trello-cli/internal/api"
+)
+
+// TestRenderKanbanAlignment checks that the second column starts at the same
+// terminal column on every line, even after wide due markers and names.
+func TestRenderKanbanAlignment(t *testing.T) {
+	due := time.Now().AddDate(0, 0, 7).UTC().Format(time.RFC3339)
+	cols := []kanbanColumn{
+		{
+			List: api.TrelloList{Name: "To Do"},
+			Cards: []api.Card{
+				{Name: "Fix login", Due: &due, Labels: []api.Label{{Name: "bug"}}},
+				{Name: "日本語のカード", Due: &due},
+			},
+		},
+		{
+			List:  api.TrelloList{Name: "Doing"},
+			Cards: []api.Card{{Name: strings.Repeat("release notes ", 10)}},
+		},
+	}
+	const width = 20
+	out := renderKanban(cols, 2*width+kanbanGap)
+	if !strings.Contains(out, "⏰") {
+		t.Fatalf("no due marker in:\n%s", out)
+	}
+
+	// Cells hold single-spaced words, so the first run of gap-wide spaces
+	// ends the first column.
+	cell := regexp.MustCompile(`^(.*?)( {2,})(\S.*)$`)
+	for _, line := range strings.Split(strings.TrimRight(out, "\n"), "\n") {
+		m := cell.FindStringSubmatch(line)
+		if m == nil {
+			t.Fatalf("line %q has no second column", line)
+		}
+		if got := runewidth.StringWidth(m[1] + m[2]); got != width+kanbanGap {
+			t.Errorf("line %q: second column starts at %d, want %d", line, got, width+kanbanGap)
+		}
+	}
+}
@@ -0,0 +1,383 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/api"
+	"github.com/the20100/trello-cli/internal/output"
+	"github.com/the20100/trello-cli/internal/strutil"
+)
+
+var labelsCmd = &cobra.Command{
+	Use:   "labels",
+	Short: "Manage board labels",
+	Long: `Manage the labels of a board.
+
+Labels can be given by ID, or by name (or color, for unnamed labels) with
+--board. Boards can be given by ID, short link, URL, or name.`,
+}
+
+// labelsBoard is the --board flag shared by every labels subcommand.
+var labelsBoard string
+
+// labelsBoardID resolves --board, which is required.
+func labelsBoardID() (string, error) {
+	if labelsBoard == "" {
+		return "", fmt.Errorf("--board is required")
+	}
+	return resolveBoardID(labelsBoard)
+}
+
+// resolveLabel finds a label by ID, or by name or color on --board.
+func resolveLabel(ref string) (*api.Label, error) {
+	if labelsBoard == "" {
+		if !isTrelloID(ref) {
+			return nil, fmt.Errorf("%q: --board is required to look up a label by name", ref)
+		}
+		return client.GetLabel(ref)
+	}
+	boardID, err := labelsBoardID()
+	if err != nil {
+		return nil, err
+	}
+	labels, err := client.GetBoardLabels(boardID)
+	if err != nil {
+		return nil, err
+	}
+	l, ok := findLabel(labels, ref)
+	if !ok {
+		return nil, fmt.Errorf("no label %q on board %s", ref, boardID)
+	}
+	return &l, nil
+}
+
+// ---- labels list ----
+
+var labelsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the labels of a board",
+	Long: `List the labels of a board with the number of cards using each.
+
+Examples:
+  trello labels list --board "My Project"
+  trello labels list --board abc123 --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		boardID, err := labelsBoardID()
+		if err != nil {
+			return err
+		}
+		labels, err := client.GetBoardLabels(boardID)
+		if err != nil {
+			return err
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(labels, output.IsPretty(cmd))
+		}
+		if len(labels) == 0 {
+			fmt.Println("No labels found.")
+			return nil
+		}
+		headers := []string{"ID", "NAME", "COLOR", "USES"}
+		rows := make([][]string, len(labels))
+		for i, l := range labels {
+			rows[i] = []string{l.ID, strutil.FirstNonEmpty(l.Name, "-"), strutil.FirstNonEmpty(l.Color, "-"), fmt.Sprintf("%d", l.Uses)}
+		}
+		output.PrintTable(headers, rows)
+		return nil
+	},
+}
+
+// ---- labels get ----
+
+var labelsGetCmd = &cobra.Command{
+	Use:   "get <label>",
+	Short: "Get details of a label",
+	Long: `Get a label by ID, or by name or color with --board.
+
+Examples:
+  trello labels get 5f1a2b3c4d5e6f7a8b9c0d1e
+  trello labels get urgent --board "My Project"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l, err := resolveLabel(args[0])
+		if err != nil {
+			return err
+		}
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(l, output.IsPretty(cmd))
+		}
+		output.PrintKeyValue([][]string{
+			{"ID", l.ID},
+			{"Name", strutil.FirstNonEmpty(l.Name, "-")},
+			{"Color", strutil.FirstNonEmpty(l.Color, "-")},
+			{"Board", l.IDBoard},
+		})
+		return nil
+	},
+}
+
+// ---- labels create ----
+
+var labelsCreateColor string
+
+var labelsCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a label on a board",
+	Long: `Create a label on a board.
+
+Colors: green, yellow, orange, red, purple, blue, sky, lime, pink, black,
+and their _dark / _light variants (e.g. red_dark).
+
+Examples:
+  trello labels create "Urgent" --board "My Project" --color red
+  trello labels create "Needs review" --board abc123 --color sky`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		boardID, err := labelsBoardID()
+		if err != nil {
+			return err
+		}
+		l, err := client.CreateLabel(boardID, args[0], labelsCreateColor)
+		if err != nil {
+			return err
+		}
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(l, output.IsPretty(cmd))
+		}
+		fmt.Printf("Label created: %s\n", labelName(l.Name, l.Color))
+		fmt.Printf("ID: %s\n", l.ID)
+		return nil
+	},
+}
+
+// ---- labels update ----
+
+var (
+	labelsUpdateName  string
+	labelsUpdateColor string
+)
+
+var labelsUpdateCmd = &cobra.Command{
+	Use:   "update <label>",
+	Short: "Rename or recolor a label",
+	Long: `Rename or recolor a label. Cards keep the label.
+
+Examples:
+  trello labels update urgent --board "My Project" --name "P0"
+  trello labels update 5f1a2b3c4d5e6f7a8b9c0d1e --color orange`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params := url.Values{}
+		if cmd.Flags().Changed("name") {
+			params.Set("name", labelsUpdateName)
+		}
+		if cmd.Flags().Changed("color") {
+			params.Set("color", labelsUpdateColor)
+		}
+		if len(params) == 0 {
+			return fmt.Errorf("provide --name and/or --color")
+		}
+
+		l, err := resolveLabel(args[0])
+		if err != nil {
+			return err
+		}
+		updated, err := client.UpdateLabel(l.ID, params)
+		if err != nil {
+			return err
+		}
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(updated, output.IsPretty(cmd))
+		}
+		fmt.Printf("Label %s updated: %s\n", updated.ID, labelName(updated.Name, updated.Color))
+		return nil
+	},
+}
+
+// ---- labels delete ----
+
+var labelsDeleteCmd = &cobra.Command{
+	Use:   "delete <label>",
+	Short: "Delete a label",
+	Long: `Permanently delete a label. It is removed from every card carrying it.
+
+This action cannot be undone.
+
+Examples:
+  trello labels delete 5f1a2b3c4d5e6f7a8b9c0d1e
+  trello labels delete "Old stuff" --board "My Project"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l, err := resolveLabel(args[0])
+		if err != nil {
+			return err
+		}
+		if err := client.DeleteLabel(l.ID); err != nil {
+			return err
+		}
+		fmt.Printf("Label %s deleted.\n", labelName(l.Name, l.Color))
+		return nil
+	},
+}
+
+// ---- labels merge ----
+
+var labelsMergeCmd = &cobra.Command{
+	Use:   "merge <from> <into>",
+	Short: "Move every card from one label to another and delete the first",
+	Long: `Merge two labels of the same board: every card (including archived ones)
+carrying <from> gets <into>, then <from> is deleted.
+
+With --dry-run, only the cards that would be relabeled are shown.
+
+Examples:
+  trello labels merge "bug" "Bug" --board "My Project"
+  trello labels merge red_dark red --board abc123 --dry-run`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, err := resolveLabel(args[0])
+		if err != nil {
+			return err
+		}
+		into, err := resolveLabel(args[1])
+		if err != nil {
+			return err
+		}
+		if from.ID == into.ID {
+			return fmt.Errorf("cannot merge a label into itself")
+		}
+		if from.IDBoard != into.IDBoard {
+			return fmt.Errorf("labels belong to different boards")
+		}
+
+		cards, err := client.GetBoardCards(from.IDBoard, "all")
+		if err != nil {
+			return err
+		}
+		relabeled := 0
+		for _, c := range cards {
+			if !containsString(c.IDLabels, from.ID) {
+				continue
+			}
+			if containsString(c.IDLabels, into.ID) {
+				continue
+			}
+			relabeled++
+			if dryRunFlag {
+				fmt.Printf("would relabel %s\n", quoteName(c.Name))
+				continue
+			}
+			if err := client.AddLabelToCard(c.ID, into.ID); err != nil {
+				return fmt.Errorf("relabeling %s: %w", quoteName(c.Name), err)
+			}
+			fmt.Printf("relabeled %s\n", quoteName(c.Name))
+		}
+
+		if dryRunFlag {
+			fmt.Printf("Dry run: %d card(s) would get %s, then %s would be deleted.\n",
+				relabeled, labelName(into.Name, into.Color), labelName(from.Name, from.Color))
+			return nil
+		}
+		if err := client.DeleteLabel(from.ID); err != nil {
+			return err
+		}
+		fmt.Printf("Merged %s into %s (%d card(s) relabeled).\n",
+			labelName(from.Name, from.Color), labelName(into.Name, into.Color), relabeled)
+		return nil
+	},
+}
+
+// ---- labels prune ----
+
+var labelsPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete labels that no card uses",
+	Long: `Delete every label of a board that no card uses, archived cards included.
+
+With --dry-run, the unused labels are listed but not deleted.
+
+Examples:
+  trello labels prune --board "My Project" --dry-run
+  trello labels prune --board abc123`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		boardID, err := labelsBoardID()
+		if err != nil {
+			return err
+		}
+		labels, err := client.GetBoardLabels(boardID)
+		if err != nil {
+			return err
+		}
+		cards, err := client.GetBoardCards(boardID, "all")
+		if err != nil {
+			return err
+		}
+		used := map[string]bool{}
+		for _, c := range cards {
+			for _, id := range c.IDLabels {
+				used[id] = true
+			}
+		}
+
+		var unused []api.Label
+		for _, l := range labels {
+			if !used[l.ID] {
+				unused = append(unused, l)
+			}
+		}
+
+		if output.IsJSON(cmd) && dryRunFlag {
+			return output.PrintJSON(unused, output.IsPretty(cmd))
+		}
+		if len(unused) == 0 {
+			fmt.Println("No unused labels.")
+			return nil
+		}
+		for _, l := range unused {
+			if dryRunFlag {
+				fmt.Printf("would delete %s (%s)\n", labelName(l.Name, l.Color), l.ID)
+				continue
+			}
+			if err := client.DeleteLabel(l.ID); err != nil {
+				return err
+			}
+			fmt.Printf("deleted %s (%s)\n", labelName(l.Name, l.Color), l.ID)
+		}
+		if dryRunFlag {
+			fmt.Printf("Dry run: %d unused label(s).\n", len(unused))
+		} else {
+			fmt.Printf("%d unused label(s) deleted.\n", len(unused))
+		}
+		return nil
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{
+		labelsListCmd, labelsGetCmd, labelsCreateCmd, labelsUpdateCmd,
+		labelsDeleteCmd, labelsMergeCmd, labelsPruneCmd,
+	} {
+		c.Flags().StringVar(&labelsBoard, "board", "", "Board ID, short link, URL, or name")
+	}
+
+	labelsCreateCmd.Flags().StringVar(&labelsCreateColor, "color", "", "Label color")
+
+	labelsUpdateCmd.Flags().StringVar(&labelsUpdateName, "name", "", "New label name")
+	labelsUpdateCmd.Flags().StringVar(&labelsUpdateColor, "color", "", "New label color")
+
+	labelsCmd.AddCommand(
+		labelsListCmd,
+		labelsGetCmd,
+		labelsCreateCmd,
+		labelsUpdateCmd,
+		labelsDeleteCmd,
+		labelsMergeCmd,
+		labelsPruneCmd,
+	)
+	rootCmd.AddCommand(labelsCmd)
+}
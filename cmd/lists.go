@@ -210,6 +210,8 @@ Examples:
 
 var (
 	listsCardsFilter string
+	listsCardsCards  cardFilter
+	listsCardsFields []string
 )
 
 var listsCardsCmd = &cobra.Command{
@@ -220,10 +222,21 @@ var listsCardsCmd = &cobra.Command{
 Examples:
   trello lists cards abc123
   trello lists cards abc123 --filter all
+  trello lists cards abc123 --sort due --reverse
+  trello lists cards abc123 --incomplete-checklist
+  trello lists cards abc123 --field "Story points"
   trello lists cards abc123 --json`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cards, err := client.GetListCards(args[0], listsCardsFilter)
+		params := buildParams("filter", listsCardsFilter)
+		if len(listsCardsFields) > 0 {
+			params.Set("customFieldItems", "true")
+		}
+		cards, err := client.GetListCardsWithParams(args[0], params)
+		if err != nil {
+			return err
+		}
+		cards, err = listsCardsCards.apply(cards)
 		if err != nil {
 			return err
 		}
@@ -232,6 +245,19 @@ Examples:
 			return output.PrintJSON(cards, output.IsPretty(cmd))
 		}
 
+		if len(listsCardsFields) > 0 {
+			list, err := client.GetList(args[0])
+			if err != nil {
+				return err
+			}
+			fields, err := customFieldColumns(list.IDBoard, listsCardsFields)
+			if err != nil {
+				return err
+			}
+			printAPICardsTable(cards, fields...)
+			return nil
+		}
+
 		if len(cards) == 0 {
 			fmt.Println("No cards found.")
 			return nil
@@ -272,6 +298,8 @@ func init() {
 
 	// lists cards flags
 	listsCardsCmd.Flags().StringVar(&listsCardsFilter, "filter", "open", "Filter: open, closed, all")
+	listsCardsCmd.Flags().StringSliceVar(&listsCardsFields, "field", nil, "Show a custom field as a column (repeatable)")
+	addCardFilterFlags(listsCardsCmd, &listsCardsCards)
 
 	listsCmd.AddCommand(
 		listsListCmd,
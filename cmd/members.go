@@ -136,6 +136,7 @@ Examples:
 var (
 	memberCardsTarget string
 	memberCardsFilter string
+	memberCardsCards  cardFilter
 )
 
 var membersCardsCmd = &cobra.Command{
@@ -147,6 +148,8 @@ Examples:
   trello members cards
   trello members cards johndoe
   trello members cards --filter all
+  trello members cards --overdue --sort due
+  trello members cards --sort list
   trello members cards --json`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -159,6 +162,10 @@ Examples:
 		if err != nil {
 			return err
 		}
+		cards, err = memberCardsCards.apply(cards)
+		if err != nil {
+			return err
+		}
 
 		if output.IsJSON(cmd) {
 			return output.PrintJSON(cards, output.IsPretty(cmd))
@@ -238,6 +245,7 @@ func init() {
 
 	// members cards flags
 	membersCardsCmd.Flags().StringVar(&memberCardsFilter, "filter", "open", "Filter: open, closed, all, visible")
+	addCardFilterFlags(membersCardsCmd, &memberCardsCards)
 
 	membersCmd.AddCommand(
 		membersMeCmd,
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/output"
+)
+
+var (
+	openBoard   string
+	openType    string
+	openURLOnly bool
+)
+
+var openCmd = &cobra.Command{
+	Use:   "open <card|board|list>",
+	Short: "Open a card, board, or list in the browser",
+	Long: `Open a Trello card, board, or list in the web browser, or print its URL.
+
+The reference may be:
+  - a Trello URL, ID, or short link
+  - a card number "#42" (with --board)
+  - a list or card name (with --board), or a board name
+
+Lists have no page of their own, so they open their board.
+
+The browser is taken from $BROWSER, falling back to xdg-open (Linux),
+open (macOS), or start (Windows).
+
+Examples:
+  trello open abc123
+  trello open https://trello.com/c/AbCdEfGh
+  trello open "#42" --board <board-id>
+  trello open "Fix login" --board "My Project"
+  trello open "My Project"
+  trello open abc123 --url-only`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveRef(args[0], openType, openBoard)
+		if err != nil {
+			return err
+		}
+		if ref.URL == "" {
+			return fmt.Errorf("%s %q has no URL", ref.Kind, ref.Name)
+		}
+
+		return openRef(cmd, ref, openURLOnly)
+	},
+}
+
+// openRef opens ref in the browser, or with urlOnly prints its URL. With
+// JSON output, ref is printed as JSON instead of the text message.
+func openRef(cmd *cobra.Command, ref *trelloRef, urlOnly bool) error {
+	if !urlOnly {
+		if err := openBrowser(ref.URL); err != nil {
+			return err
+		}
+	}
+	if output.IsJSON(cmd) {
+		return output.PrintJSON(ref, output.IsPretty(cmd))
+	}
+	if urlOnly {
+		fmt.Println(ref.URL)
+		return nil
+	}
+	fmt.Printf("Opened %s %q: %s\n", ref.Kind, ref.Name, ref.URL)
+	return nil
+}
+
+// openBrowser launches the user's web browser on u.
+func openBrowser(u string) error {
+	var c *exec.Cmd
+	// $BROWSER may carry arguments, and may use %s as the URL placeholder.
+	if fields := strings.Fields(os.Getenv("BROWSER")); len(fields) > 0 {
+		args := fields[1:]
+		placed := false
+		for i, a := range args {
+			if strings.Contains(a, "%s") {
+				args[i] = strings.ReplaceAll(a, "%s", u)
+				placed = true
+			}
+		}
+		if !placed {
+			args = append(args, u)
+		}
+		c = exec.Command(fields[0], args...)
+	} else {
+		switch runtime.GOOS {
+		case "darwin":
+			c = exec.Command("open", u)
+		case "windows":
+			c = exec.Command("rundll32", "url.dll,FileProtocolHandler", u)
+		default:
+			c = exec.Command("xdg-open", u)
+		}
+	}
+	c.Stderr = os.Stderr
+	if err := c.Start(); err != nil {
+		return fmt.Errorf("launching browser: %w (use --url-only to print the URL instead)", err)
+	}
+	return c.Process.Release()
+}
+
+func init() {
+	openCmd.Flags().StringVar(&openBoard, "board", "", "Board context for card numbers and names (ID, short link, or name)")
+	openCmd.Flags().StringVar(&openType, "type", "", "Restrict lookup to: board, list, card")
+	openCmd.Flags().BoolVar(&openURLOnly, "url-only", false, "Print the resolved URL instead of launching the browser")
+	rootCmd.AddCommand(openCmd)
+}
@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/the20100/trello-cli/internal/api"
+	"github.com/the20100/trello-cli/internal/strutil"
+)
+
+// trelloRef is a board, list, or card resolved from a user-supplied reference.
+type trelloRef struct {
+	Kind    string `json:"type"` // "board", "list", or "card"
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	URL     string `json:"url"`
+	IDBoard string `json:"idBoard,omitempty"`
+}
+
+// resolveRef resolves ref to a board, list, or card.
+//
+// ref may be a Trello URL, a 24-character ID, a short link, "#<idShort>"
+// (card number, needs board), or a name. Names are matched against the
+// lists and cards of board when given, otherwise against the member's
+// boards. kind restricts the lookup to "board", "list", or "card" ("" = any).
+func resolveRef(ref, kind, board string) (*trelloRef, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, fmt.Errorf("empty reference")
+	}
+	switch kind {
+	case "", "board", "list", "card":
+	default:
+		return nil, fmt.Errorf("invalid type %q: use board, list, or card", kind)
+	}
+
+	// Trello URLs: https://trello.com/b/<shortLink>/... or /c/<shortLink>/...
+	if u, err := url.Parse(ref); err == nil && u.Host != "" {
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if len(parts) >= 2 {
+			switch parts[0] {
+			case "b":
+				return lookupBoard(parts[1])
+			case "c":
+				return lookupCard(parts[1])
+			}
+		}
+		return nil, fmt.Errorf("unrecognised Trello URL: %s", ref)
+	}
+
+	// #idShort — card number within a board.
+	if strings.HasPrefix(ref, "#") {
+		n, err := strconv.Atoi(ref[1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid card number %q", ref)
+		}
+		if board == "" {
+			return nil, fmt.Errorf("%s: --board is required to resolve a card number", ref)
+		}
+		boardRef, err := resolveRef(board, "board", "")
+		if err != nil {
+			return nil, err
+		}
+		cards, err := client.GetBoardCards(boardRef.ID, "all")
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range cards {
+			if c.IDShort == n {
+				return cardRef(c), nil
+			}
+		}
+		return nil, fmt.Errorf("no card #%d on board %s", n, boardRef.Name)
+	}
+
+	// IDs and short links: try each candidate kind in turn.
+	if isTrelloID(ref) || isShortLink(ref) {
+		lookups := []struct {
+			kind string
+			fn   func(string) (*trelloRef, error)
+		}{
+			{"card", lookupCard},
+			{"board", lookupBoard},
+			{"list", lookupList},
+		}
+		for _, l := range lookups {
+			if kind != "" && kind != l.kind {
+				continue
+			}
+			if l.kind == "list" && !isTrelloID(ref) {
+				continue // lists have no short link
+			}
+			r, err := l.fn(ref)
+			if err == nil {
+				return r, nil
+			}
+			if !isNotFound(err) {
+				return nil, err
+			}
+		}
+		if isTrelloID(ref) {
+			return nil, fmt.Errorf("no %s found with ID %s", kindOrObject(kind), ref)
+		}
+	}
+
+	return resolveByName(ref, kind, board)
+}
+
+// resolveBoardID resolves a board reference (ID, short link, URL, or name) to its ID.
+func resolveBoardID(ref string) (string, error) {
+	if isTrelloID(ref) {
+		return ref, nil
+	}
+	r, err := resolveRef(ref, "board", "")
+	if err != nil {
+		return "", err
+	}
+	return r.ID, nil
+}
+
+// resolveByName matches ref against names in the board context, or against
+// the member's boards when no board is given.
+func resolveByName(ref, kind, board string) (*trelloRef, error) {
+	if board == "" {
+		if kind != "" && kind != "board" {
+			return nil, fmt.Errorf("%q: --board is required to look up a %s by name", ref, kind)
+		}
+		boards, err := client.GetMyBoards("open")
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, len(boards))
+		for i, b := range boards {
+			names[i] = b.Name
+		}
+		i, err := matchName(ref, names, "board")
+		if err != nil {
+			return nil, err
+		}
+		return boardRef(boards[i]), nil
+	}
+
+	boardRef, err := resolveRef(board, "board", "")
+	if err != nil {
+		return nil, err
+	}
+	if kind == "board" {
+		return nil, fmt.Errorf("no board found matching %q", ref)
+	}
+
+	if kind == "" || kind == "list" {
+		lists, err := client.GetBoardLists(boardRef.ID, "open")
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, len(lists))
+		for i, l := range lists {
+			names[i] = l.Name
+		}
+		if i, err := matchName(ref, names, "list"); err == nil {
+			return listRef(lists[i], boardRef.URL), nil
+		} else if kind == "list" || !errors.Is(err, errNoMatch) {
+			return nil, err
+		}
+	}
+
+	cards, err := client.GetBoardCards(boardRef.ID, "open")
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(cards))
+	for i, c := range cards {
+		names[i] = c.Name
+	}
+	i, err := matchName(ref, names, "card")
+	if err != nil {
+		return nil, fmt.Errorf("%w on board %s", err, boardRef.Name)
+	}
+	return cardRef(cards[i]), nil
+}
+
+var errNoMatch = errors.New("no match")
+
+// matchName finds ref in names: an exact case-insensitive match wins,
+// otherwise a single substring match. Ambiguous matches are an error.
+func matchName(ref string, names []string, what string) (int, error) {
+	needle := strings.ToLower(strings.TrimSpace(ref))
+	var exact, partial []int
+	for i, n := range names {
+		h := strings.ToLower(strings.TrimSpace(n))
+		if h == needle {
+			exact = append(exact, i)
+		} else if strings.Contains(h, needle) {
+			partial = append(partial, i)
+		}
+	}
+	candidates := exact
+	if len(candidates) == 0 {
+		candidates = partial
+	}
+	switch len(candidates) {
+	case 0:
+		return -1, fmt.Errorf("%w: no %s named %q", errNoMatch, what, ref)
+	case 1:
+		return candidates[0], nil
+	}
+	quoted := make([]string, 0, len(candidates))
+	for _, i := range candidates {
+		quoted = append(quoted, strconv.Quote(names[i]))
+	}
+	return -1, fmt.Errorf("%q matches %d %ss: %s", ref, len(candidates), what, strings.Join(quoted, ", "))
+}
+
+func lookupBoard(id string) (*trelloRef, error) {
+	b, err := client.GetBoard(id, url.Values{"fields": {"name,shortUrl,url"}})
+	if err != nil {
+		return nil, err
+	}
+	return boardRef(*b), nil
+}
+
+func lookupCard(id string) (*trelloRef, error) {
+	c, err := client.GetCard(id, url.Values{"fields": {"name,idBoard,idList,idShort,shortUrl,url"}})
+	if err != nil {
+		return nil, err
+	}
+	return cardRef(*c), nil
+}
+
+func lookupList(id string) (*trelloRef, error) {
+	l, err := client.GetList(id)
+	if err != nil {
+		return nil, err
+	}
+	b, err := client.GetBoard(l.IDBoard, url.Values{"fields": {"shortUrl"}})
+	if err != nil {
+		return nil, err
+	}
+	return listRef(*l, b.ShortURL), nil
+}
+
+func boardRef(b api.Board) *trelloRef {
+	return &trelloRef{Kind: "board", ID: b.ID, Name: b.Name, URL: strutil.FirstNonEmpty(b.ShortURL, b.URL), IDBoard: b.ID}
+}
+
+func cardRef(c api.Card) *trelloRef {
+	return &trelloRef{Kind: "card", ID: c.ID, Name: c.Name, URL: strutil.FirstNonEmpty(c.ShortURL, c.URL), IDBoard: c.IDBoard}
+}
+
+// listRef builds a list reference. Lists have no page of their own, so the
+// URL is that of the board they belong to.
+func listRef(l api.TrelloList, boardURL string) *trelloRef {
+	return &trelloRef{Kind: "list", ID: l.ID, Name: l.Name, URL: boardURL, IDBoard: l.IDBoard}
+}
+
+// isShortLink reports whether s looks like an 8-character Trello short link.
+func isShortLink(s string) bool {
+	if len(s) != 8 {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// isNotFound reports whether err is an API error for a missing or malformed object.
+func isNotFound(err error) bool {
+	var te *api.TrelloError
+	if errors.As(err, &te) {
+		return te.StatusCode == 404 || te.StatusCode == 400
+	}
+	return false
+}
+
+func kindOrObject(kind string) string {
+	if kind == "" {
+		return "board, list, or card"
+	}
+	return kind
+}
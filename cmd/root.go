@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"runtime"
@@ -14,14 +15,25 @@ var (
 	// Persistent flags
 	jsonFlag   bool
 	prettyFlag bool
+	dryRunFlag bool
 
 	// Global API client, set in PersistentPreRunE
 	client *api.Client
 
+	// newClient builds the API client; tests replace it to reach a fake server.
+	newClient = api.NewClient
+
 	// Global config, set in PersistentPreRunE
 	cfg *config.Config
 )
 
+// noAuthAnnotation marks commands that run without Trello credentials.
+const noAuthAnnotation = "noAuth"
+
+// dryRunAnnotation marks commands without credentials that honour --dry-run
+// themselves; the others reject it.
+const dryRunAnnotation = "dryRun"
+
 var rootCmd = &cobra.Command{
 	Use:   "trello",
 	Short: "Trello CLI — manage boards, lists, and cards via the Trello API",
@@ -51,7 +63,15 @@ Examples:
 
 // Execute is the entrypoint called from main.go.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	cmd, err := rootCmd.ExecuteC()
+	if dryRunning {
+		finishDryRun(cmd)
+	}
+	if err != nil {
+		var exit *exitError
+		if errors.As(err, &exit) {
+			os.Exit(exit.code)
+		}
 		os.Exit(1)
 	}
 }
@@ -59,9 +79,17 @@ func Execute() {
 func init() {
 	rootCmd.PersistentFlags().BoolVar(&jsonFlag, "json", false, "Force JSON output")
 	rootCmd.PersistentFlags().BoolVar(&prettyFlag, "pretty", false, "Force pretty-printed JSON output (implies --json)")
+	rootCmd.PersistentFlags().BoolVar(&dryRunFlag, "dry-run", false, "Print the changes a command would make to Trello without making them")
 
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
-		if isAuthCommand(cmd) || cmd.Name() == "info" {
+		if isAuthCommand(cmd) || cmd.Name() == "info" || cmd.Annotations[noAuthAnnotation] != "" {
+			if !dryRunFlag {
+				return nil
+			}
+			if cmd.Annotations[dryRunAnnotation] == "" {
+				return fmt.Errorf("%s does not support --dry-run", cmd.CommandPath())
+			}
+			dryRunning = true
 			return nil
 		}
 
@@ -70,7 +98,10 @@ func init() {
 			return err
 		}
 
-		client = api.NewClient(apiKey, apiToken)
+		client = newClient(apiKey, apiToken)
+		if dryRunFlag {
+			startDryRun(cmd)
+		}
 		return nil
 	}
 
@@ -80,6 +111,8 @@ func init() {
 var infoCmd = &cobra.Command{
 	Use:   "info",
 	Short: "Show tool info: config path, auth status, and environment",
+	// info only reads local state, so a dry run changes nothing.
+	Annotations: map[string]string{dryRunAnnotation: "true"},
 	Run: func(cmd *cobra.Command, args []string) {
 		printInfo()
 	},
@@ -4,12 +4,15 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/api"
 	"github.com/the20100/trello-cli/internal/output"
 )
 
 var (
 	searchTypes []string
 	searchLimit int
+	searchOpen  int
+	searchURL   bool
 )
 
 var searchCmd = &cobra.Command{
@@ -19,20 +22,33 @@ var searchCmd = &cobra.Command{
 
 By default searches all types. Use --type to narrow the search.
 
+--open N opens result N in the browser, as numbered in the # column: cards
+first, then boards. Like "trello open", add --url-only to print its URL
+instead, or --json to print the result as JSON.
+
 Examples:
   trello search "deploy"
   trello search "bug fix" --type cards
   trello search "John" --type members
   trello search "project" --type boards,cards
   trello search "deploy" --limit 5
+  trello search "deploy" --open 1
+  trello search "deploy" --open 2 --url-only
   trello search "deploy" --json`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if cmd.Flags().Changed("open") && searchOpen < 1 {
+			return fmt.Errorf("--open must be a result number of at least 1")
+		}
 		results, err := client.Search(args[0], searchTypes, searchLimit)
 		if err != nil {
 			return err
 		}
 
+		if cmd.Flags().Changed("open") {
+			return openSearchResult(cmd, results, searchOpen)
+		}
+
 		if output.IsJSON(cmd) {
 			return output.PrintJSON(results, output.IsPretty(cmd))
 		}
@@ -46,10 +62,10 @@ Examples:
 			return nil
 		}
 
-		// Cards
+		// Cards and boards are numbered together, as --open counts them.
 		if totalCards > 0 {
 			fmt.Printf("\nCards (%d)\n", totalCards)
-			headers := []string{"ID", "#", "NAME", "DUE", "LABELS"}
+			headers := []string{"#", "ID", "CARD #", "NAME", "DUE", "LABELS"}
 			rows := make([][]string, totalCards)
 			for i, c := range results.Cards {
 				labelNames := make([]string, len(c.Labels))
@@ -61,6 +77,7 @@ Examples:
 					}
 				}
 				rows[i] = []string{
+					fmt.Sprintf("%d", i+1),
 					c.ID,
 					fmt.Sprintf("%d", c.IDShort),
 					output.Truncate(c.Name, 44),
@@ -74,10 +91,11 @@ Examples:
 		// Boards
 		if totalBoards > 0 {
 			fmt.Printf("\nBoards (%d)\n", totalBoards)
-			headers := []string{"ID", "NAME", "URL", "CLOSED"}
+			headers := []string{"#", "ID", "NAME", "URL", "CLOSED"}
 			rows := make([][]string, totalBoards)
 			for i, b := range results.Boards {
 				rows[i] = []string{
+					fmt.Sprintf("%d", totalCards+i+1),
 					b.ID,
 					output.Truncate(b.Name, 44),
 					b.ShortURL,
@@ -102,8 +120,26 @@ Examples:
 	},
 }
 
+// openSearchResult opens the n-th (1-based) card or board result in the browser.
+func openSearchResult(cmd *cobra.Command, results *api.SearchResult, n int) error {
+	var ref *trelloRef
+	switch {
+	case n <= len(results.Cards):
+		c := results.Cards[n-1]
+		ref = &trelloRef{Kind: "card", ID: c.ID, Name: c.Name, URL: c.ShortURL, IDBoard: c.IDBoard}
+	case n <= len(results.Cards)+len(results.Boards):
+		b := results.Boards[n-1-len(results.Cards)]
+		ref = &trelloRef{Kind: "board", ID: b.ID, Name: b.Name, URL: b.ShortURL}
+	default:
+		return fmt.Errorf("--open %d: only %d card and board results", n, len(results.Cards)+len(results.Boards))
+	}
+	return openRef(cmd, ref, searchURL)
+}
+
 func init() {
 	searchCmd.Flags().StringArrayVar(&searchTypes, "type", nil, "Limit to: cards, boards, members (can be repeated or comma-separated)")
 	searchCmd.Flags().IntVar(&searchLimit, "limit", 10, "Max results per type (1-1000)")
+	searchCmd.Flags().IntVar(&searchOpen, "open", 0, "Open result N (the # column) in the browser")
+	searchCmd.Flags().BoolVar(&searchURL, "url-only", false, "With --open, print the result's URL instead of launching the browser")
 	rootCmd.AddCommand(searchCmd)
 }
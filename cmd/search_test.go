@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSearchOpen(t *testing.T) {
+	tests := []struct {
+		name    string
+		open    string
+		wantURL string
+		wantErr string
+	}{
+		{name: "first card", open: "1", wantURL: "https://trello.com/c/card1"},
+		{name: "boards follow cards", open: "3", wantURL: "https://trello.com/b/board1"},
+		{name: "past the results", open: "4", wantErr: "only 3 card and board results"},
+		{name: "zero", open: "0", wantErr: "at least 1"},
+		{name: "negative", open: "-1", wantErr: "at least 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeTrello(t)
+			f.gets["/search"] = map[string]any{
+				"cards": []map[string]any{
+					{"id": "c1", "name": "Deploy API", "shortUrl": "https://trello.com/c/card1"},
+					{"id": "c2", "name": "Deploy web", "shortUrl": "https://trello.com/c/card2"},
+				},
+				"boards":  []map[string]any{{"id": "b1", "name": "Deploys", "shortUrl": "https://trello.com/b/board1"}},
+				"members": []map[string]any{{"id": "m1", "username": "deployer"}},
+			}
+
+			out, err := runCommand(t, f, "search", "deploy", "--open", tt.open, "--url-only")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("run: %v", err)
+			}
+			var ref trelloRef
+			if err := json.Unmarshal([]byte(out), &ref); err != nil {
+				t.Fatalf("output %q: %v", out, err)
+			}
+			if ref.URL != tt.wantURL {
+				t.Errorf("opened %q, want %q", ref.URL, tt.wantURL)
+			}
+		})
+	}
+}
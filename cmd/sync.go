@@ -0,0 +1,1199 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/api"
+	"github.com/the20100/trello-cli/internal/output"
+	"github.com/the20100/trello-cli/internal/snapshot"
+	"github.com/the20100/trello-cli/internal/strutil"
+	"github.com/the20100/trello-cli/internal/textdiff"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	syncPull   bool
+	syncPush   bool
+	syncPrefer string
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync <board> <dir>",
+	Short: "Two-way sync between a board and a directory of Markdown files",
+	Long: `Mirror a board as files: each open list is a directory and each open card a
+Markdown file with YAML front-matter, the description as the body:
+
+  ---
+  id: 5f1a2b3c4d5e6f7a8b9c0d1e
+  name: Fix the login bug
+  labels: [bug]
+  members: [alice]
+  due: 2026-05-01T17:00:00.000Z
+  checklists:
+    - name: QA
+      items: ["[x] Reproduce", "[ ] Add a regression test"]
+  ---
+  The description, as Markdown.
+
+A sync pulls the changes made on Trello into the files and pushes the
+changes made to the files to Trello; --pull or --push does one direction
+only. Locally, edit a file to change the card, move it to another
+directory to move the card, delete it to archive the card, or add a file
+without an id to create a card (the id is written back). A new directory
+becomes a new list.
+
+The state of the last sync is kept in .trello-sync.json in the directory:
+the last activity date and content of every card as last synced. A card
+changed on both sides is merged field by field against that base, with a
+three-way line merge of the description. Conflicting changes are resolved
+with --prefer local or --prefer remote; otherwise the file keeps the local
+value, gets conflict markers in the description and a "conflicts:" list in
+its front-matter, and is not pushed until you resolve them and remove the
+list. Exit status is 2 when conflicts remain.
+
+Card order within a list and archived cards are not synced.
+
+With --dry-run, the sync runs on a temporary copy of the directory: the
+results show what would change on both sides, and neither Trello nor the
+directory is modified.
+
+Examples:
+  trello sync "My Project" ./tasks
+  trello sync abc123 ./tasks --pull
+  trello sync abc123 ./tasks --push
+  trello sync abc123 ./tasks --prefer remote`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch syncPrefer {
+		case "", "local", "remote":
+		default:
+			return fmt.Errorf("invalid --prefer %q: use local or remote", syncPrefer)
+		}
+		boardID, err := resolveBoardID(args[0])
+		if err != nil {
+			return err
+		}
+		dir := args[1]
+		work := dir
+		if dryRunFlag {
+			if work, err = copySyncDir(dir); err != nil {
+				return err
+			}
+			defer os.RemoveAll(work)
+		} else if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		state, err := loadSyncState(work)
+		if err != nil {
+			return err
+		}
+		if state.Board != "" && state.Board != boardID {
+			return fmt.Errorf("%s is synced with board %s; use another directory", dir, state.Board)
+		}
+		state.Board = boardID
+
+		snap, err := fetchBoardContent(boardID, false)
+		if err != nil {
+			return err
+		}
+		s := &syncer{
+			results: []syncResult{},
+			dir:     work,
+			snap:    snap,
+			state:   state,
+			pull:    syncPull || !syncPush,
+			push:    syncPush || !syncPull,
+		}
+		runErr := s.run()
+		state.LastSync = time.Now().UTC().Format(time.RFC3339)
+		if err := state.save(work); err != nil {
+			return err
+		}
+		if runErr != nil {
+			return runErr
+		}
+
+		if output.IsJSON(cmd) {
+			if err := output.PrintJSON(s.results, output.IsPretty(cmd)); err != nil {
+				return err
+			}
+		} else {
+			printSyncResults(snap.Name, dir, s.results)
+		}
+		for _, r := range s.results {
+			if r.Action == "conflict" {
+				cmd.SilenceErrors = true
+				return &exitError{code: 2}
+			}
+		}
+		return nil
+	},
+}
+
+// syncStateFile is the name of the sync state file in a synced directory.
+const syncStateFile = ".trello-sync.json"
+
+// syncState records the last sync of a directory.
+type syncState struct {
+	Board    string               `json:"board"`
+	LastSync string               `json:"lastSync,omitempty"`
+	Lists    map[string]*syncList `json:"lists"`
+	Cards    map[string]*syncBase `json:"cards"`
+}
+
+// syncList is a synced list and its directory.
+type syncList struct {
+	Name string `json:"name"`
+	Dir  string `json:"dir"`
+}
+
+// syncBase is a card as last synced: its file, last activity, and content.
+type syncBase struct {
+	Path             string   `json:"path"`
+	DateLastActivity string   `json:"dateLastActivity"`
+	Card             syncCard `json:"card"`
+}
+
+// copySyncDir copies dir, which may not exist yet, to a new temporary
+// directory for a dry run and returns its path.
+func copySyncDir(dir string) (string, error) {
+	tmp, err := os.MkdirTemp("", "trello-sync-*")
+	if err != nil {
+		return "", err
+	}
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p == dir && errors.Is(err, fs.ErrNotExist) {
+				return fs.SkipDir
+			}
+			return err
+		}
+		rel, _ := filepath.Rel(dir, p)
+		dst := filepath.Join(tmp, rel)
+		if d.IsDir() {
+			return os.MkdirAll(dst, 0755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(dst, data, 0644)
+	})
+	if err != nil {
+		os.RemoveAll(tmp)
+		return "", err
+	}
+	return tmp, nil
+}
+
+func loadSyncState(dir string) (*syncState, error) {
+	st := &syncState{Lists: map[string]*syncList{}, Cards: map[string]*syncBase{}}
+	data, err := os.ReadFile(filepath.Join(dir, syncStateFile))
+	if os.IsNotExist(err) {
+		return st, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, st); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", syncStateFile, err)
+	}
+	if st.Lists == nil {
+		st.Lists = map[string]*syncList{}
+	}
+	if st.Cards == nil {
+		st.Cards = map[string]*syncBase{}
+	}
+	return st, nil
+}
+
+func (st *syncState) save(dir string) error {
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, syncStateFile), append(data, '\n'), 0644)
+}
+
+// syncCard is the content of a card file. List holds the list ID and is
+// not written to the file, whose directory gives the list.
+type syncCard struct {
+	ID          string          `yaml:"id,omitempty" json:"id"`
+	Name        string          `yaml:"name" json:"name"`
+	Labels      []string        `yaml:"labels,flow" json:"labels"`
+	Members     []string        `yaml:"members,flow" json:"members"`
+	Due         string          `yaml:"due,omitempty" json:"due,omitempty"`
+	DueComplete bool            `yaml:"dueComplete,omitempty" json:"dueComplete,omitempty"`
+	Checklists  []syncChecklist `yaml:"checklists,omitempty" json:"checklists,omitempty"`
+	Conflicts   []string        `yaml:"conflicts,omitempty" json:"-"`
+	Desc        string          `yaml:"-" json:"desc"`
+	List        string          `yaml:"-" json:"list"`
+}
+
+// syncChecklist is a checklist of a card file; items are "[x] text" or
+// "[ ] text".
+type syncChecklist struct {
+	Name  string   `yaml:"name" json:"name"`
+	Items []string `yaml:"items,flow" json:"items"`
+}
+
+// normalized returns a copy for comparison: sorted labels and members, no
+// empty slices, trimmed description, and no ID or conflicts.
+func (c syncCard) normalized() syncCard {
+	n := c
+	n.ID, n.Conflicts = "", nil
+	n.Labels = sortedCopy(c.Labels)
+	n.Members = sortedCopy(c.Members)
+	n.Checklists = nil
+	for _, cl := range c.Checklists {
+		if len(cl.Items) == 0 {
+			cl.Items = nil
+		}
+		n.Checklists = append(n.Checklists, cl)
+	}
+	if n.Due == "" {
+		n.DueComplete = false
+	}
+	n.Desc = strings.TrimRight(c.Desc, "\n ")
+	return n
+}
+
+func sortedCopy(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	out := append([]string(nil), s...)
+	sort.Strings(out)
+	return out
+}
+
+func sameSyncCard(a, b syncCard) bool {
+	return reflect.DeepEqual(a.normalized(), b.normalized())
+}
+
+func renderSyncCard(c syncCard) ([]byte, error) {
+	if c.Labels == nil {
+		c.Labels = []string{}
+	}
+	if c.Members == nil {
+		c.Members = []string{}
+	}
+	fm, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	b.WriteString("---\n")
+	b.Write(fm)
+	b.WriteString("---\n")
+	b.WriteString(c.Desc)
+	if c.Desc != "" && !strings.HasSuffix(c.Desc, "\n") {
+		b.WriteString("\n")
+	}
+	return b.Bytes(), nil
+}
+
+// parseSyncCard reads a card file. A file without front-matter is a new
+// card named after its first heading or its file name.
+func parseSyncCard(name string, data []byte) (syncCard, error) {
+	var c syncCard
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if !strings.HasPrefix(text, "---\n") {
+		c.Name = strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+		body := text
+		if h, rest, ok := strings.Cut(text, "\n"); ok && strings.HasPrefix(h, "# ") {
+			c.Name, body = strings.TrimSpace(h[2:]), rest
+		}
+		c.Desc = strings.Trim(body, "\n")
+		return c, nil
+	}
+	fm, body, err := splitFrontMatter(text)
+	if err != nil {
+		return c, err
+	}
+	if err := yaml.Unmarshal([]byte(fm), &c); err != nil {
+		return c, fmt.Errorf("parsing front-matter: %w", err)
+	}
+	c.Desc = strings.TrimRight(body, "\n")
+	return c, nil
+}
+
+// parseSyncItem splits "[x] text" into its text and completion.
+func parseSyncItem(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	lower := strings.ToLower(s)
+	switch {
+	case strings.HasPrefix(lower, "[x]"):
+		return strings.TrimSpace(s[3:]), true
+	case strings.HasPrefix(lower, "[ ]"):
+		return strings.TrimSpace(s[3:]), false
+	}
+	return s, false
+}
+
+// syncResult is what a sync did to one card or list.
+type syncResult struct {
+	Action string `json:"action"` // pulled, pushed, created, merged, archived, removed, conflict, skipped, failed
+	Name   string `json:"name"`
+	Path   string `json:"path,omitempty"`
+	ID     string `json:"id,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// localCard is a card file found in the directory.
+type localCard struct {
+	path string // relative, with forward slashes
+	card syncCard
+}
+
+// syncer runs one sync of a directory with a board.
+type syncer struct {
+	dir        string
+	snap       *snapshot.Board
+	state      *syncState
+	pull, push bool
+	results    []syncResult
+
+	remote  map[string]api.Card // open cards on open lists, by ID
+	dirList map[string]string   // directory → list ID ("" for archived lists)
+	taken   map[string]bool     // card file paths in use
+}
+
+func (s *syncer) report(action, name, path, id, detail string) {
+	s.results = append(s.results, syncResult{Action: action, Name: name, Path: path, ID: id, Detail: detail})
+}
+
+func (s *syncer) run() error {
+	if err := s.syncLists(); err != nil {
+		return err
+	}
+	s.remote = map[string]api.Card{}
+	for _, c := range s.snap.Cards {
+		if l, ok := s.snap.List(c.IDList); ok && !l.Closed && !c.Closed {
+			s.remote[c.ID] = c
+		}
+	}
+
+	local, fresh, err := s.scan()
+	if err != nil {
+		return err
+	}
+
+	ids := map[string]bool{}
+	for id := range s.state.Cards {
+		ids[id] = true
+	}
+	for id := range s.remote {
+		ids[id] = true
+	}
+	for id := range local {
+		ids[id] = true
+	}
+	sorted := make([]string, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+	for _, id := range sorted {
+		lc, inLocal := local[id]
+		var lp *localCard
+		if inLocal {
+			lp = &lc
+		}
+		if err := s.syncCard(id, lp); err != nil {
+			return err
+		}
+	}
+	if s.push {
+		for _, lc := range fresh {
+			s.createCard(lc)
+		}
+	}
+	s.removeEmptyDirs()
+	return nil
+}
+
+// syncLists maps list directories: it creates, renames, and forgets the
+// directories of lists created, renamed, and archived on Trello, and (when
+// pushing) creates lists for new directories.
+func (s *syncer) syncLists() error {
+	open := map[string]api.TrelloList{}
+	for _, l := range s.snap.Lists {
+		if !l.Closed {
+			open[l.ID] = l
+		}
+	}
+	var gone []string
+	for id, sl := range s.state.Lists {
+		l, ok := open[id]
+		switch {
+		case !ok && s.pull:
+			// Still scanned, so unchanged files of its cards are removed.
+			gone = append(gone, sl.Dir)
+			delete(s.state.Lists, id)
+		case ok && s.pull && l.Name != sl.Name:
+			newDir := s.freeDir(listDirName(l.Name), sl.Dir)
+			if newDir != sl.Dir {
+				if err := os.Rename(filepath.Join(s.dir, sl.Dir), filepath.Join(s.dir, newDir)); err != nil && !os.IsNotExist(err) {
+					return err
+				}
+				for _, b := range s.state.Cards {
+					if strings.HasPrefix(b.Path, sl.Dir+"/") {
+						b.Path = newDir + strings.TrimPrefix(b.Path, sl.Dir)
+					}
+				}
+				s.report("renamed", l.Name, newDir, id, "list directory, was "+sl.Dir)
+			}
+			sl.Name, sl.Dir = l.Name, newDir
+		}
+	}
+
+	// Adopt existing directories named like a list, then create the rest.
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+	for _, l := range s.snap.Lists {
+		if l.Closed || s.state.Lists[l.ID] != nil {
+			continue
+		}
+		for _, e := range entries {
+			if e.IsDir() && s.dirListID(e.Name()) == "" &&
+				(e.Name() == listDirName(l.Name) || strings.EqualFold(e.Name(), l.Name)) {
+				s.state.Lists[l.ID] = &syncList{Name: l.Name, Dir: e.Name()}
+				break
+			}
+		}
+		if s.state.Lists[l.ID] == nil && s.pull {
+			d := s.freeDir(listDirName(l.Name), "")
+			if err := os.MkdirAll(filepath.Join(s.dir, d), 0755); err != nil {
+				return err
+			}
+			s.state.Lists[l.ID] = &syncList{Name: l.Name, Dir: d}
+		}
+	}
+
+	s.dirList = map[string]string{}
+	for _, d := range gone {
+		s.dirList[d] = ""
+	}
+	for id, sl := range s.state.Lists {
+		s.dirList[sl.Dir] = id
+	}
+	if !s.push {
+		return nil
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if _, mapped := s.dirList[name]; !e.IsDir() || strings.HasPrefix(name, ".") || mapped {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(s.dir, name)); err != nil {
+			continue // renamed above
+		}
+		list, err := client.CreateList(name, s.state.Board, "bottom")
+		if err != nil {
+			s.report("failed", name, name, "", "creating list: "+err.Error())
+			continue
+		}
+		s.state.Lists[list.ID] = &syncList{Name: list.Name, Dir: name}
+		s.dirList[name] = list.ID
+		s.snap.Lists = append(s.snap.Lists, *list)
+		s.report("created", name, name, list.ID, "list")
+	}
+	return nil
+}
+
+func (s *syncer) dirListID(dir string) string {
+	for id, sl := range s.state.Lists {
+		if sl.Dir == dir {
+			return id
+		}
+	}
+	return ""
+}
+
+// listDirName turns a list name into a directory name.
+func listDirName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) || r < ' ' {
+			return '-'
+		}
+		return r
+	}, name)
+	name = strings.Trim(name, " .")
+	if name == "" {
+		return "list"
+	}
+	return name
+}
+
+// freeDir returns want, or want with a number appended, such that no other
+// list uses it; own is the list's current directory.
+func (s *syncer) freeDir(want, own string) string {
+	for n := 1; ; n++ {
+		d := want
+		if n > 1 {
+			d = fmt.Sprintf("%s (%d)", want, n)
+		}
+		if d == own {
+			return d
+		}
+		if s.dirListID(d) != "" {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(s.dir, d)); err == nil {
+			continue
+		}
+		return d
+	}
+}
+
+// scan reads the card files of the list directories: those with a card ID,
+// and new ones without.
+func (s *syncer) scan() (map[string]localCard, []localCard, error) {
+	local := map[string]localCard{}
+	var fresh []localCard
+	s.taken = map[string]bool{}
+	dirs := make([]string, 0, len(s.dirList))
+	for d := range s.dirList {
+		dirs = append(dirs, d)
+	}
+	sort.Strings(dirs)
+	for _, d := range dirs {
+		entries, err := os.ReadDir(filepath.Join(s.dir, d))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, e := range entries {
+			if e.IsDir() || !strings.EqualFold(filepath.Ext(e.Name()), ".md") {
+				continue
+			}
+			rel := d + "/" + e.Name()
+			s.taken[rel] = true
+			data, err := os.ReadFile(filepath.Join(s.dir, filepath.FromSlash(rel)))
+			if err != nil {
+				return nil, nil, err
+			}
+			c, err := parseSyncCard(e.Name(), data)
+			if err != nil {
+				s.report("failed", e.Name(), rel, "", err.Error())
+				continue
+			}
+			c.List = s.dirList[d]
+			lc := localCard{path: rel, card: c}
+			switch {
+			case c.ID == "":
+				fresh = append(fresh, lc)
+			case local[c.ID].path != "":
+				s.report("failed", c.Name, rel, c.ID, "same id as "+local[c.ID].path)
+			default:
+				local[c.ID] = lc
+			}
+		}
+	}
+	return local, fresh, nil
+}
+
+// syncCard syncs one card known to the state, the board, or the files.
+func (s *syncer) syncCard(id string, local *localCard) error {
+	base, inState := s.state.Cards[id]
+	rc, inRemote := s.remote[id]
+
+	switch {
+	case inRemote && local != nil:
+		remote := s.remoteCard(rc)
+		var baseCard *syncCard
+		remoteChanged, localChanged := true, true
+		if inState {
+			baseCard = &base.Card
+			remoteChanged = rc.DateLastActivity != base.DateLastActivity && !sameSyncCard(remote, base.Card)
+			localChanged = !sameSyncCard(local.card, base.Card)
+		}
+		switch {
+		case !remoteChanged && !localChanged:
+			if inState {
+				base.Path, base.DateLastActivity = local.path, rc.DateLastActivity
+			}
+		case sameSyncCard(local.card, remote):
+			s.record(rc, remote, local.path)
+		case remoteChanged && !localChanged:
+			if s.pull {
+				return s.writeCard(rc, remote, local.path, "pulled")
+			}
+			s.report("skipped", rc.Name, local.path, id, "changed on Trello; pull to update the file")
+		case localChanged && !remoteChanged:
+			if s.push {
+				s.pushCard(rc, remote, *local, "pushed")
+			} else {
+				s.report("skipped", rc.Name, local.path, id, "changed locally; push to update Trello")
+			}
+		default:
+			return s.mergeCard(rc, remote, baseCard, *local)
+		}
+
+	case inRemote && local == nil:
+		remote := s.remoteCard(rc)
+		switch {
+		case !inState:
+			if s.pull {
+				return s.writeCard(rc, remote, "", "pulled")
+			}
+		case rc.DateLastActivity == base.DateLastActivity || sameSyncCard(remote, base.Card):
+			if s.push {
+				if _, err := client.UpdateCard(id, url.Values{"closed": {"true"}}); err != nil {
+					s.report("failed", rc.Name, base.Path, id, "archiving: "+err.Error())
+					return nil
+				}
+				delete(s.state.Cards, id)
+				s.report("archived", rc.Name, base.Path, id, "file deleted")
+			}
+		case s.pull:
+			// Deleted locally but changed on Trello: the changes win.
+			return s.writeCard(rc, remote, base.Path, "pulled")
+		}
+
+	case !inRemote && local != nil:
+		switch {
+		case !inState:
+			s.report("skipped", local.card.Name, local.path, id, "no open card with this id on the board; remove the id to create a new card")
+		case !s.pull:
+			// Left for a pull to remove.
+		case sameSyncCard(local.card, base.Card):
+			if err := os.Remove(filepath.Join(s.dir, filepath.FromSlash(local.path))); err != nil {
+				return err
+			}
+			delete(s.state.Cards, id)
+			s.report("removed", local.card.Name, local.path, id, "archived or deleted on Trello")
+		default:
+			// Edited locally but gone on Trello: keep the edits as a new
+			// card to create once the conflict is acknowledged.
+			c := local.card
+			c.ID = ""
+			c.Conflicts = append(c.Conflicts, "archived or deleted on Trello; remove this list to create it again")
+			if err := s.writeFile(local.path, c); err != nil {
+				return err
+			}
+			delete(s.state.Cards, id)
+			s.report("conflict", c.Name, local.path, id, "edited locally but archived or deleted on Trello")
+		}
+
+	default:
+		if s.pull {
+			delete(s.state.Cards, id)
+		}
+	}
+	return nil
+}
+
+// mergeCard merges a card changed on both sides, field by field against
+// base (nil when there is no base: every difference conflicts).
+func (s *syncer) mergeCard(rc api.Card, remote syncCard, base *syncCard, local localCard) error {
+	m := &syncMerge{base: base, prefer: syncPrefer}
+	b := syncCard{}
+	if base != nil {
+		b = *base
+	}
+	l := local.card
+	merged := syncCard{ID: rc.ID}
+	merged.Name = mergeSyncField(m, "name", b.Name, l.Name, remote.Name)
+	merged.Labels = mergeSyncField(m, "labels", sortedCopy(b.Labels), sortedCopy(l.Labels), sortedCopy(remote.Labels))
+	merged.Members = mergeSyncField(m, "members", sortedCopy(b.Members), sortedCopy(l.Members), sortedCopy(remote.Members))
+	merged.Due = mergeSyncField(m, "due", b.Due, l.Due, remote.Due)
+	merged.DueComplete = mergeSyncField(m, "dueComplete", b.DueComplete, l.DueComplete, remote.DueComplete)
+	merged.Checklists = mergeSyncField(m, "checklists", b.Checklists, l.Checklists, remote.Checklists)
+	merged.List = mergeSyncField(m, "list", b.List, l.List, remote.List)
+	merged.Desc = m.desc(b.Desc, l.Desc, remote.Desc)
+
+	if len(m.conflicts) > 0 {
+		merged.Conflicts = m.conflicts
+		path := s.cardPath(local.path, merged.List, merged.Name)
+		if err := s.moveFile(local.path, path); err != nil {
+			return err
+		}
+		if err := s.writeFile(path, merged); err != nil {
+			return err
+		}
+		// The remote version becomes the base, so the resolved file is
+		// pushed as a local change.
+		s.record(rc, remote, path)
+		s.report("conflict", rc.Name, path, rc.ID, strings.Join(m.conflicts, "; "))
+		return nil
+	}
+
+	if !s.push {
+		path := s.cardPath(local.path, merged.List, merged.Name)
+		if err := s.moveFile(local.path, path); err != nil {
+			return err
+		}
+		if err := s.writeFile(path, merged); err != nil {
+			return err
+		}
+		s.record(rc, remote, path)
+		s.report("merged", rc.Name, path, rc.ID, "local changes not pushed")
+		return nil
+	}
+	s.pushCard(rc, remote, localCard{path: local.path, card: merged}, "merged")
+	return nil
+}
+
+// syncMerge collects the conflicts of a field-by-field merge.
+type syncMerge struct {
+	base      *syncCard
+	prefer    string
+	conflicts []string
+}
+
+// mergeSyncField merges one field changed on both sides.
+func mergeSyncField[T any](m *syncMerge, field string, base, local, remote T) T {
+	switch {
+	case reflect.DeepEqual(local, remote):
+		return local
+	case m.base != nil && reflect.DeepEqual(local, base):
+		return remote
+	case m.base != nil && reflect.DeepEqual(remote, base):
+		return local
+	}
+	return m.conflict(field, local, remote).(T)
+}
+
+func (m *syncMerge) conflict(field string, local, remote any) any {
+	switch m.prefer {
+	case "local":
+		return local
+	case "remote":
+		return remote
+	}
+	m.conflicts = append(m.conflicts, fmt.Sprintf("%s changed on both sides, Trello has %s", field, syncValue(remote)))
+	return local
+}
+
+// syncValue describes a field value in a conflict message.
+func syncValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return displayValue(v)
+	case []string:
+		return "[" + strings.Join(v, ", ") + "]"
+	case bool:
+		return fmt.Sprintf("%t", v)
+	case []syncChecklist:
+		names := make([]string, len(v))
+		for i, cl := range v {
+			names[i] = fmt.Sprintf("%s (%d items)", cl.Name, len(cl.Items))
+		}
+		return "[" + strings.Join(names, ", ") + "]"
+	}
+	return fmt.Sprint(v)
+}
+
+// desc merges descriptions line by line.
+func (m *syncMerge) desc(base, local, remote string) string {
+	base, local, remote = strings.TrimRight(base, "\n "), strings.TrimRight(local, "\n "), strings.TrimRight(remote, "\n ")
+	if local == remote {
+		return local
+	}
+	if m.base == nil {
+		return m.conflict("desc", local, remote).(string)
+	}
+	merged, conflict := textdiff.Merge3(base, local, remote)
+	if !conflict {
+		return merged
+	}
+	switch m.prefer {
+	case "local":
+		return local
+	case "remote":
+		return remote
+	}
+	m.conflicts = append(m.conflicts, "desc: see the conflict markers")
+	return merged
+}
+
+// remoteCard builds the file content of a card on the board.
+func (s *syncer) remoteCard(c api.Card) syncCard {
+	sc := syncCard{ID: c.ID, Name: c.Name, Desc: c.Desc, List: c.IDList, DueComplete: c.DueComplete}
+	if c.Due != nil {
+		sc.Due = *c.Due
+	} else {
+		sc.DueComplete = false
+	}
+	for _, id := range c.IDLabels {
+		if l, ok := s.snap.Label(id); ok {
+			sc.Labels = append(sc.Labels, labelDisplayName(l))
+		}
+	}
+	for _, id := range c.IDMembers {
+		if m, ok := s.snap.Member(id); ok {
+			sc.Members = append(sc.Members, m.Username)
+		} else {
+			sc.Members = append(sc.Members, id)
+		}
+	}
+	for _, cl := range s.snap.CardChecklists(c.ID) {
+		scl := syncChecklist{Name: cl.Name, Items: []string{}}
+		for _, it := range cl.CheckItems {
+			mark := "[ ] "
+			if it.State == "complete" {
+				mark = "[x] "
+			}
+			scl.Items = append(scl.Items, mark+it.Name)
+		}
+		sc.Checklists = append(sc.Checklists, scl)
+	}
+	return sc
+}
+
+// record stores a card as synced.
+func (s *syncer) record(c api.Card, content syncCard, path string) {
+	content.Conflicts = nil
+	s.state.Cards[c.ID] = &syncBase{Path: path, DateLastActivity: c.DateLastActivity, Card: content}
+}
+
+// writeCard writes a board card to its file (at path, moved to its list's
+// directory if needed; a new file when path is empty) and records it.
+func (s *syncer) writeCard(c api.Card, content syncCard, path, action string) error {
+	newPath := s.cardPath(path, c.IDList, c.Name)
+	if path != "" {
+		if err := s.moveFile(path, newPath); err != nil {
+			return err
+		}
+	}
+	if err := s.writeFile(newPath, content); err != nil {
+		return err
+	}
+	s.record(c, content, newPath)
+	detail := ""
+	if path != "" && path != newPath {
+		detail = "moved from " + path
+	}
+	s.report(action, c.Name, newPath, c.ID, detail)
+	return nil
+}
+
+// cardPath returns the file path of a card in list: the current file name
+// in that list's directory, or a new file named after the card.
+func (s *syncer) cardPath(current, listID, name string) string {
+	sl := s.state.Lists[listID]
+	if sl == nil {
+		return current
+	}
+	if current != "" {
+		if path.Dir(current) == sl.Dir {
+			return current
+		}
+		name = strings.TrimSuffix(path.Base(current), path.Ext(current))
+	} else {
+		name = strutil.FirstNonEmpty(slugify(name), "card")
+	}
+	for n := 1; ; n++ {
+		p := sl.Dir + "/" + name + ".md"
+		if n > 1 {
+			p = fmt.Sprintf("%s/%s-%d.md", sl.Dir, name, n)
+		}
+		if !s.taken[p] {
+			s.taken[p] = true
+			return p
+		}
+	}
+}
+
+func (s *syncer) moveFile(from, to string) error {
+	if from == to || from == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Join(s.dir, filepath.FromSlash(path.Dir(to))), 0755); err != nil {
+		return err
+	}
+	delete(s.taken, from)
+	return os.Rename(filepath.Join(s.dir, filepath.FromSlash(from)), filepath.Join(s.dir, filepath.FromSlash(to)))
+}
+
+func (s *syncer) writeFile(rel string, c syncCard) error {
+	data, err := renderSyncCard(c)
+	if err != nil {
+		return err
+	}
+	p := filepath.Join(s.dir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(p, data, 0644)
+}
+
+// unresolved explains why a file cannot be pushed yet, if it cannot.
+func unresolved(c syncCard) string {
+	switch {
+	case len(c.Conflicts) > 0:
+		return "unresolved conflicts; remove the conflicts list from the front-matter once resolved"
+	case textdiff.HasConflict(c.Desc):
+		return "conflict markers in the description"
+	case strings.TrimSpace(c.Name) == "":
+		return "name cannot be empty"
+	}
+	return ""
+}
+
+// pushCard updates a board card to match a file, then records and
+// rewrites the file from the updated card.
+func (s *syncer) pushCard(rc api.Card, remote syncCard, local localCard, action string) {
+	fail := func(err error) {
+		s.report("failed", rc.Name, local.path, rc.ID, err.Error())
+	}
+	want := local.card
+	if reason := unresolved(want); reason != "" {
+		s.report("conflict", rc.Name, local.path, rc.ID, reason)
+		return
+	}
+	params, err := s.cardParams(want)
+	if err != nil {
+		fail(err)
+		return
+	}
+	if _, err := client.UpdateCard(rc.ID, params); err != nil {
+		fail(err)
+		return
+	}
+	if err := s.pushChecklists(rc.ID, s.snap.CardChecklists(rc.ID), want.Checklists); err != nil {
+		fail(err)
+		return
+	}
+	if err := s.refresh(rc.ID, local.path, action); err != nil {
+		fail(err)
+	}
+}
+
+// createCard creates a card from a new file and writes its ID back.
+func (s *syncer) createCard(local localCard) {
+	c := local.card
+	if reason := unresolved(c); reason != "" {
+		s.report("conflict", c.Name, local.path, "", reason)
+		return
+	}
+	if c.List == "" {
+		s.report("skipped", c.Name, local.path, "", "its list is archived on Trello")
+		return
+	}
+	params, err := s.cardParams(c)
+	if err != nil {
+		s.report("failed", c.Name, local.path, "", err.Error())
+		return
+	}
+	params.Del("idList")
+	params.Set("pos", "bottom")
+	card, err := client.CreateCard(c.List, c.Name, c.Desc, params)
+	if err != nil {
+		s.report("failed", c.Name, local.path, "", err.Error())
+		return
+	}
+	if err := s.pushChecklists(card.ID, nil, c.Checklists); err != nil {
+		s.report("failed", c.Name, local.path, card.ID, err.Error())
+		return
+	}
+	if err := s.refresh(card.ID, local.path, "created"); err != nil {
+		s.report("failed", c.Name, local.path, card.ID, err.Error())
+	}
+}
+
+// cardParams resolves a file's fields to card update parameters.
+func (s *syncer) cardParams(c syncCard) (url.Values, error) {
+	params := url.Values{}
+	params.Set("name", c.Name)
+	params.Set("desc", c.Desc)
+	params.Set("idList", c.List)
+	if c.Due == "" {
+		params.Set("due", "null")
+	} else {
+		t, err := parseDateFlag(c.Due)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due %q: use RFC3339 or YYYY-MM-DD", c.Due)
+		}
+		params.Set("due", t.UTC().Format(time.RFC3339))
+		params.Set("dueComplete", fmt.Sprintf("%t", c.DueComplete))
+	}
+	var labelIDs, memberIDs []string
+	for _, ref := range c.Labels {
+		l, ok := findLabel(s.snap.Labels, ref)
+		if !ok {
+			return nil, fmt.Errorf("label %q not found on board", ref)
+		}
+		labelIDs = append(labelIDs, l.ID)
+	}
+	for _, ref := range c.Members {
+		m, ok := findMember(s.snap.Members, ref)
+		if !ok {
+			return nil, fmt.Errorf("member %q is not on this board", ref)
+		}
+		memberIDs = append(memberIDs, m.ID)
+	}
+	params.Set("idLabels", strings.Join(labelIDs, ","))
+	params.Set("idMembers", strings.Join(memberIDs, ","))
+	return params, nil
+}
+
+// pushChecklists makes a card's checklists match want, matching checklists
+// and items by name.
+func (s *syncer) pushChecklists(cardID string, have []api.Checklist, want []syncChecklist) error {
+	kept := map[string]bool{}
+	for _, w := range want {
+		var cl *api.Checklist
+		for i := range have {
+			if !kept[have[i].ID] && have[i].Name == w.Name {
+				cl = &have[i]
+				break
+			}
+		}
+		if cl == nil {
+			created, err := client.CreateChecklist(cardID, w.Name)
+			if err != nil {
+				return fmt.Errorf("creating checklist %s: %w", w.Name, err)
+			}
+			cl = created
+		}
+		kept[cl.ID] = true
+
+		used := map[string]bool{}
+		for _, raw := range w.Items {
+			name, done := parseSyncItem(raw)
+			var item *api.CheckItem
+			for i := range cl.CheckItems {
+				if it := &cl.CheckItems[i]; !used[it.ID] && it.Name == name {
+					item = it
+					break
+				}
+			}
+			if item == nil {
+				created, err := client.CreateCheckItem(cl.ID, name)
+				if err != nil {
+					return fmt.Errorf("adding checklist item to %s: %w", w.Name, err)
+				}
+				item = created
+			}
+			used[item.ID] = true
+			state := "incomplete"
+			if done {
+				state = "complete"
+			}
+			if item.State != state {
+				if _, err := client.UpdateCheckItem(cardID, cl.ID, item.ID, state); err != nil {
+					return fmt.Errorf("updating checklist item in %s: %w", w.Name, err)
+				}
+			}
+		}
+		for _, it := range cl.CheckItems {
+			if !used[it.ID] {
+				if err := client.DeleteCheckItem(cl.ID, it.ID); err != nil {
+					return fmt.Errorf("removing checklist item from %s: %w", w.Name, err)
+				}
+			}
+		}
+	}
+	for _, cl := range have {
+		if !kept[cl.ID] {
+			if err := client.DeleteChecklist(cl.ID); err != nil {
+				return fmt.Errorf("deleting checklist %s: %w", cl.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// refresh fetches a pushed card, rewrites its file as Trello now has it,
+// and records it.
+func (s *syncer) refresh(cardID, path, action string) error {
+	card, err := client.GetCard(cardID, url.Values{"checklists": {"all"}})
+	if err != nil {
+		return err
+	}
+	var kept []api.Checklist
+	for _, cl := range s.snap.Checklists {
+		if cl.IDCard != cardID {
+			kept = append(kept, cl)
+		}
+	}
+	for _, cl := range card.Checklists {
+		cl.IDCard = cardID
+		kept = append(kept, cl)
+	}
+	s.snap.Checklists = kept
+	s.snap.Sort()
+
+	content := s.remoteCard(*card)
+	newPath := s.cardPath(path, card.IDList, card.Name)
+	if err := s.moveFile(path, newPath); err != nil {
+		return err
+	}
+	if err := s.writeFile(newPath, content); err != nil {
+		return err
+	}
+	s.record(*card, content, newPath)
+	s.report(action, card.Name, newPath, card.ID, "")
+	return nil
+}
+
+// removeEmptyDirs removes directories of lists no longer synced once they
+// are empty.
+func (s *syncer) removeEmptyDirs() {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") || s.dirListID(e.Name()) != "" {
+			continue
+		}
+		if files, err := os.ReadDir(filepath.Join(s.dir, e.Name())); err == nil && len(files) == 0 {
+			os.Remove(filepath.Join(s.dir, e.Name()))
+		}
+	}
+}
+
+// printSyncResults prints what a sync did, one line per change.
+func printSyncResults(board, dir string, results []syncResult) {
+	counts := map[string]int{}
+	for _, r := range results {
+		counts[r.Action]++
+		line := fmt.Sprintf("%-9s %s", r.Action, strutil.FirstNonEmpty(r.Path, quoteName(r.Name)))
+		if r.Detail != "" {
+			line += " (" + r.Detail + ")"
+		}
+		fmt.Println(line)
+	}
+	if len(results) == 0 {
+		fmt.Printf("%s and %s are in sync.\n", board, dir)
+		return
+	}
+	var parts []string
+	for _, a := range []string{"pulled", "pushed", "created", "merged", "archived", "removed", "renamed", "skipped", "conflict", "failed"} {
+		if counts[a] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[a], a))
+		}
+	}
+	fmt.Printf("\nSynced %s with %s: %s.\n", board, dir, strings.Join(parts, ", "))
+}
+
+func init() {
+	syncCmd.Flags().BoolVar(&syncPull, "pull", false, "Only pull changes from Trello into the files")
+	syncCmd.Flags().BoolVar(&syncPush, "push", false, "Only push changes in the files to Trello")
+	syncCmd.Flags().StringVar(&syncPrefer, "prefer", "", "Resolve conflicting changes automatically: local or remote")
+	rootCmd.AddCommand(syncCmd)
+}
@@ -0,0 +1,337 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/the20100/trello-cli/internal/api"
+	"github.com/the20100/trello-cli/internal/snapshot"
+	"github.com/the20100/trello-cli/internal/textdiff"
+)
+
+const (
+	syncCardID   = "5f0000000000000000000c11"
+	syncListTodo = "5f0000000000000000000a11"
+	syncListDone = "5f0000000000000000000a12"
+	syncLastSeen = "2026-01-01T00:00:00.000Z"
+	syncChanged  = "2026-01-02T00:00:00.000Z"
+	syncFilePath = "to-do/fix-login.md"
+)
+
+// syncBaseCard is the card as last synced, in the to-do directory.
+func syncBaseCard() syncCard {
+	return syncCard{
+		ID:     syncCardID,
+		Name:   "Fix login",
+		Labels: []string{"bug"},
+		Desc:   "Steps:\n1. open the app\n2. log in",
+		List:   syncListTodo,
+	}
+}
+
+// remoteSyncCard returns the board card matching c, with its last activity.
+func remoteSyncCard(c syncCard, activity string) api.Card {
+	rc := api.Card{ID: c.ID, Name: c.Name, Desc: c.Desc, IDList: c.List, DateLastActivity: activity}
+	for _, l := range c.Labels {
+		if l == "bug" {
+			rc.IDLabels = append(rc.IDLabels, "lab-bug")
+		}
+	}
+	return rc
+}
+
+// newTestSyncer returns a syncer for a board with a To Do and a Done list,
+// whose API calls go to f.
+func newTestSyncer(t *testing.T, f *fakeTrello) *syncer {
+	t.Helper()
+	prev := client
+	client = f.client("test-key", "test-token")
+	t.Cleanup(func() { client = prev })
+	return &syncer{
+		dir: t.TempDir(),
+		snap: &snapshot.Board{
+			Lists:  []api.TrelloList{{ID: syncListTodo, Name: "To Do"}, {ID: syncListDone, Name: "Done"}},
+			Labels: []api.Label{{ID: "lab-bug", Name: "bug", Color: "red"}},
+		},
+		state: &syncState{
+			Lists: map[string]*syncList{
+				syncListTodo: {Name: "To Do", Dir: "to-do"},
+				syncListDone: {Name: "Done", Dir: "done"},
+			},
+			Cards: map[string]*syncBase{},
+		},
+		pull:    true,
+		push:    true,
+		results: []syncResult{},
+		remote:  map[string]api.Card{},
+		taken:   map[string]bool{},
+	}
+}
+
+func TestSyncCard(t *testing.T) {
+	tests := []struct {
+		name string
+		// remote and local change the base card; nil means gone.
+		remote func(*syncCard) bool
+		local  func(*syncCard) bool
+		noBase bool
+		pull   bool // pull only
+		// refreshed is what Trello returns after a push.
+		refreshed func(*syncCard)
+
+		wantAction string
+		wantPut    map[string]string // params of the card update, if any
+		wantFile   func(t *testing.T, c syncCard)
+		wantGone   bool // file removed
+		wantState  bool // card still in the sync state
+	}{
+		{
+			name:       "unchanged",
+			wantState:  true,
+			wantAction: "",
+		},
+		{
+			name:       "pull a change made on Trello",
+			remote:     func(c *syncCard) bool { c.Name = "Fix the login"; return true },
+			wantAction: "pulled",
+			wantFile:   func(t *testing.T, c syncCard) { wantEqual(t, "name", c.Name, "Fix the login") },
+			wantState:  true,
+		},
+		{
+			name:       "pull a move to another list",
+			remote:     func(c *syncCard) bool { c.List = syncListDone; return true },
+			wantAction: "pulled",
+			wantState:  true,
+		},
+		{
+			name:       "push a local change",
+			local:      func(c *syncCard) bool { c.Labels = nil; return true },
+			refreshed:  func(c *syncCard) { c.Labels = nil },
+			wantAction: "pushed",
+			wantPut:    map[string]string{"name": "Fix login", "idLabels": "", "idList": syncListTodo},
+			wantState:  true,
+		},
+		{
+			name:       "local change is skipped when only pulling",
+			local:      func(c *syncCard) bool { c.Name = "Renamed"; return true },
+			pull:       true,
+			wantAction: "skipped",
+			wantFile:   func(t *testing.T, c syncCard) { wantEqual(t, "name", c.Name, "Renamed") },
+			wantState:  true,
+		},
+		{
+			name:   "merge changes to different fields and lines",
+			remote: func(c *syncCard) bool { c.Name = "Fix the login"; c.Desc += "\n3. see the error"; return true },
+			local:  func(c *syncCard) bool { c.Desc = strings.Replace(c.Desc, "Steps:", "To reproduce:", 1); return true },
+			refreshed: func(c *syncCard) {
+				c.Name = "Fix the login"
+				c.Desc = "To reproduce:\n1. open the app\n2. log in\n3. see the error"
+			},
+			wantAction: "merged",
+			wantPut: map[string]string{
+				"name": "Fix the login",
+				"desc": "To reproduce:\n1. open the app\n2. log in\n3. see the error",
+			},
+			wantState: true,
+		},
+		{
+			name:       "merge without pushing",
+			remote:     func(c *syncCard) bool { c.Name = "Fix the login"; return true },
+			local:      func(c *syncCard) bool { c.Labels = nil; return true },
+			pull:       true,
+			wantAction: "merged",
+			wantFile: func(t *testing.T, c syncCard) {
+				wantEqual(t, "name", c.Name, "Fix the login")
+				wantEqual(t, "labels", strings.Join(c.Labels, ","), "")
+			},
+			wantState: true,
+		},
+		{
+			name:       "same change on both sides",
+			remote:     func(c *syncCard) bool { c.Name = "Fix the login"; return true },
+			local:      func(c *syncCard) bool { c.Name = "Fix the login"; return true },
+			wantAction: "",
+			wantState:  true,
+		},
+		{
+			name:       "conflicting field changes",
+			remote:     func(c *syncCard) bool { c.Name = "Fix SSO login"; return true },
+			local:      func(c *syncCard) bool { c.Name = "Fix password login"; return true },
+			wantAction: "conflict",
+			wantFile: func(t *testing.T, c syncCard) {
+				wantEqual(t, "name", c.Name, "Fix password login")
+				if len(c.Conflicts) != 1 || !strings.Contains(c.Conflicts[0], "Fix SSO login") {
+					t.Errorf("conflicts = %q, want one naming the Trello value", c.Conflicts)
+				}
+			},
+			wantState: true,
+		},
+		{
+			name:       "conflicting description lines",
+			remote:     func(c *syncCard) bool { c.Desc = strings.Replace(c.Desc, "log in", "log in with SSO", 1); return true },
+			local:      func(c *syncCard) bool { c.Desc = strings.Replace(c.Desc, "log in", "log in twice", 1); return true },
+			wantAction: "conflict",
+			wantFile: func(t *testing.T, c syncCard) {
+				if !textdiff.HasConflict(c.Desc) {
+					t.Errorf("desc = %q, want conflict markers", c.Desc)
+				}
+			},
+			wantState: true,
+		},
+		{
+			name:       "changed on both sides without a base",
+			remote:     func(c *syncCard) bool { c.Name = "Fix SSO login"; return true },
+			local:      func(c *syncCard) bool { c.Name = "Fix password login"; return true },
+			noBase:     true,
+			wantAction: "conflict",
+			wantState:  true,
+		},
+		{
+			name:       "deleted locally archives the card",
+			local:      func(c *syncCard) bool { return false },
+			wantAction: "archived",
+			wantPut:    map[string]string{"closed": "true"},
+			wantGone:   true,
+		},
+		{
+			name:       "deleted locally but changed on Trello",
+			remote:     func(c *syncCard) bool { c.Name = "Fix the login"; return true },
+			local:      func(c *syncCard) bool { return false },
+			wantAction: "pulled",
+			wantFile:   func(t *testing.T, c syncCard) { wantEqual(t, "name", c.Name, "Fix the login") },
+			wantState:  true,
+		},
+		{
+			name:       "archived on Trello removes the file",
+			remote:     func(c *syncCard) bool { return false },
+			wantAction: "removed",
+			wantGone:   true,
+		},
+		{
+			name:       "archived on Trello but edited locally",
+			remote:     func(c *syncCard) bool { return false },
+			local:      func(c *syncCard) bool { c.Name = "Fix password login"; return true },
+			wantAction: "conflict",
+			wantFile: func(t *testing.T, c syncCard) {
+				wantEqual(t, "id", c.ID, "")
+				wantEqual(t, "name", c.Name, "Fix password login")
+				if len(c.Conflicts) == 0 {
+					t.Error("no conflicts recorded")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeTrello(t)
+			s := newTestSyncer(t, f)
+			if tt.pull {
+				s.push = false
+			}
+
+			base := syncBaseCard()
+			if !tt.noBase {
+				s.state.Cards[syncCardID] = &syncBase{Path: syncFilePath, DateLastActivity: syncLastSeen, Card: base}
+			}
+
+			remote := syncBaseCard()
+			inRemote := true
+			activity := syncLastSeen
+			if tt.remote != nil {
+				inRemote = tt.remote(&remote)
+				activity = syncChanged
+			}
+			if inRemote {
+				rc := remoteSyncCard(remote, activity)
+				s.remote[syncCardID] = rc
+				s.snap.Cards = append(s.snap.Cards, rc)
+			}
+
+			local := &localCard{path: syncFilePath, card: syncBaseCard()}
+			if tt.local != nil && !tt.local(&local.card) {
+				local = nil
+			}
+			if local != nil {
+				if err := s.writeFile(local.path, local.card); err != nil {
+					t.Fatal(err)
+				}
+				s.taken[local.path] = true
+			}
+
+			after := remote
+			if tt.refreshed != nil {
+				tt.refreshed(&after)
+			}
+			f.gets["/cards/"+syncCardID] = remoteSyncCard(after, syncChanged)
+
+			if err := s.syncCard(syncCardID, local); err != nil {
+				t.Fatalf("syncCard: %v", err)
+			}
+
+			action := ""
+			if len(s.results) > 0 {
+				action = s.results[0].Action
+			}
+			if action != tt.wantAction || len(s.results) > 1 {
+				t.Fatalf("results = %+v, want one %q", s.results, tt.wantAction)
+			}
+
+			if tt.wantPut != nil {
+				got := f.request(t, "PUT", "/cards/"+syncCardID).Query
+				for k, want := range tt.wantPut {
+					if v := got.Get(k); v != want {
+						t.Errorf("PUT %s = %q, want %q", k, v, want)
+					}
+				}
+			} else if len(f.requests) > 0 {
+				t.Errorf("unexpected requests: %+v", f.requests)
+			}
+
+			files := syncFiles(t, s.dir)
+			switch {
+			case tt.wantGone && len(files) > 0:
+				t.Errorf("files = %v, want none", files)
+			case !tt.wantGone && len(files) != 1:
+				t.Fatalf("files = %v, want one", files)
+			case tt.wantFile != nil:
+				tt.wantFile(t, files[0])
+			}
+
+			if _, ok := s.state.Cards[syncCardID]; ok != tt.wantState {
+				t.Errorf("card in state = %v, want %v", ok, tt.wantState)
+			}
+		})
+	}
+}
+
+// syncFiles parses the card files under dir.
+func syncFiles(t *testing.T, dir string) []syncCard {
+	t.Helper()
+	var cards []syncCard
+	err := filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		c, err := parseSyncCard(d.Name(), data)
+		cards = append(cards, c)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cards
+}
+
+func wantEqual(t *testing.T, field, got, want string) {
+	t.Helper()
+	if got != want {
+		t.Errorf("%s = %q, want %q", field, got, want)
+	}
+}
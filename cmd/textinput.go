@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// maxIncludeDepth bounds nested {{includeTemplate}} directives.
+const maxIncludeDepth = 8
+
+// resolveText returns the text for a free-text flag.
+//
+// file, when set, names a file to read ("-" for stdin) and takes the place
+// of value. Otherwise value is used as-is, except that "@path" reads the file
+// at path ("@-" reads stdin) and "@@..." stands for a literal leading "@".
+// Values containing whitespace are never treated as file references, so
+// "@alice thanks!" stays literal.
+//
+// When preprocess is true the text is rendered as a Markdown template (see
+// preprocessMarkdown), with includes resolved relative to the source file.
+func resolveText(value, file, flagName string, preprocess bool) (string, error) {
+	if file != "" && value != "" {
+		return "", fmt.Errorf("use either --%s or --%s-file, not both", flagName, flagName)
+	}
+
+	src := ""
+	switch {
+	case file != "":
+		src = file
+	case strings.HasPrefix(value, "@@"):
+		value = value[1:]
+	case strings.HasPrefix(value, "@") && len(value) > 1 && !strings.ContainsAny(value, " \t\n"):
+		src = value[1:]
+	}
+
+	text := value
+	baseDir := "."
+	if src != "" {
+		data, err := readTextSource(src)
+		if err != nil {
+			return "", fmt.Errorf("--%s: %w (prefix with @@ for a literal @)", flagName, err)
+		}
+		text = data
+		if src != "-" {
+			baseDir = filepath.Dir(src)
+		}
+	}
+
+	if preprocess && text != "" {
+		return preprocessMarkdown(text, baseDir)
+	}
+	return text, nil
+}
+
+// resolveArgText is resolveText for a positional argument. Arguments are
+// always literal, so "trello cards create @home" names a card "@home"; file
+// input goes through the --<flag>-file flag instead.
+func resolveArgText(value, file, flagName string, preprocess bool) (string, error) {
+	if strings.HasPrefix(value, "@") {
+		value = "@" + value
+	}
+	return resolveText(value, file, flagName, preprocess)
+}
+
+// readTextSource reads a file, or stdin when path is "-".
+func readTextSource(path string) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\n"), nil
+}
+
+// textTemplateData is the data available to preprocessed Markdown.
+type textTemplateData struct {
+	Date string            // YYYY-MM-DD (local)
+	Time string            // RFC 3339 timestamp (local)
+	User string            // Trello username from the config file, if known
+	Env  map[string]string // environment variables
+}
+
+// preprocessMarkdown renders text as a Go template. Besides the fields of
+// textTemplateData it provides:
+//
+//	{{include "path"}}          contents of another file, as is
+//	{{includeTemplate "path"}}  another file, itself rendered as a template
+//	{{env "NAME"}}              an environment variable
+//	{{tail 50 "text"}}          the last N lines of text
+//	{{code "text"}}             text wrapped in a fenced code block
+//
+// so that e.g. {{include "build.log" | tail 100 | code}} posts the end of a log.
+func preprocessMarkdown(text, baseDir string) (string, error) {
+	now := time.Now()
+	data := textTemplateData{
+		Date: now.Format("2006-01-02"),
+		Time: now.Format(time.RFC3339),
+		Env:  map[string]string{},
+	}
+	if cfg != nil {
+		data.User = cfg.Username
+	}
+	for _, kv := range os.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			data.Env[k] = v
+		}
+	}
+	return renderMarkdownTemplate(text, baseDir, data, 0)
+}
+
+func renderMarkdownTemplate(text, baseDir string, data textTemplateData, depth int) (string, error) {
+	if depth > maxIncludeDepth {
+		return "", fmt.Errorf("includes nested deeper than %d levels", maxIncludeDepth)
+	}
+	readInclude := func(path string) (string, string, error) {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return "", "", err
+		}
+		return strings.TrimRight(string(b), "\n"), path, nil
+	}
+	funcs := template.FuncMap{
+		// include is raw, so logs containing "{{" are posted as they are.
+		"include": func(path string) (string, error) {
+			text, _, err := readInclude(path)
+			return text, err
+		},
+		"includeTemplate": func(path string) (string, error) {
+			text, path, err := readInclude(path)
+			if err != nil {
+				return "", err
+			}
+			return renderMarkdownTemplate(text, filepath.Dir(path), data, depth+1)
+		},
+		"env": os.Getenv,
+		"tail": func(n int, s string) string {
+			lines := strings.Split(s, "\n")
+			if len(lines) > n {
+				lines = lines[len(lines)-n:]
+			}
+			return strings.Join(lines, "\n")
+		},
+		"code": func(s string) string {
+			return "```\n" + strings.TrimRight(s, "\n") + "\n```"
+		},
+	}
+	tmpl, err := template.New("text").Funcs(funcs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parsing template: %w", err)
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("rendering template: %w", err)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPreprocessInclude(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"build.log":    "step 1\nrun: echo ${{ secrets.TOKEN }}\nhelm: {{ .Values.image }}\n",
+		"footer.md":    "Posted on {{.Env.DEPLOY_ENV}}",
+		"nested.md":    `{{includeTemplate "footer.md"}}`,
+		"recursive.md": `{{includeTemplate "recursive.md"}}`,
+	}
+	for name, text := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(text), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("DEPLOY_ENV", "staging")
+
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "include is raw",
+			text: `{{include "build.log" | tail 2 | code}}`,
+			want: "```\nrun: echo ${{ secrets.TOKEN }}\nhelm: {{ .Values.image }}\n```",
+		},
+		{
+			name: "includeTemplate renders",
+			text: `{{includeTemplate "nested.md"}}`,
+			want: "Posted on staging",
+		},
+		{
+			name: "include does not render",
+			text: `{{include "footer.md"}}`,
+			want: "Posted on {{.Env.DEPLOY_ENV}}",
+		},
+		{
+			name:    "includeTemplate fails on template syntax in logs",
+			text:    `{{includeTemplate "build.log"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "includeTemplate depth is bounded",
+			text:    `{{includeTemplate "recursive.md"}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := preprocessMarkdown(tt.text, dir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error: %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/tui"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui [board-id]",
+	Short: "Browse and edit boards in a full-screen interactive UI",
+	Long: `Open a full-screen interactive browser: boards → lists → cards → card details.
+
+Keys:
+  ↑/↓ or j/k     move the selection
+  ⏎ or →         open the selected board, list, or card
+  ← or esc       go back
+  [ and ]        move the selected card to the previous / next list
+  m              move the card to a list picked from the board
+  space          toggle the selected checklist item (card view)
+  c              add a comment
+  r              refresh the current view
+  q              quit
+
+When stdin or stdout is not a terminal, the command tree help is shown instead.
+
+Examples:
+  trello tui
+  trello tui <board-id>`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !isatty.IsTerminal(os.Stdin.Fd()) || !isatty.IsTerminal(os.Stdout.Fd()) {
+			fmt.Fprintln(os.Stderr, "trello tui needs an interactive terminal; showing the available commands instead.")
+			fmt.Fprintln(os.Stderr)
+			return rootCmd.Help()
+		}
+
+		screen, err := tcell.NewScreen()
+		if err != nil {
+			return fmt.Errorf("opening terminal: %w", err)
+		}
+
+		boardID := ""
+		if len(args) > 0 {
+			boardID = args[0]
+		}
+		return tui.New(screen, client).Run(boardID)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+}
@@ -42,6 +42,11 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("resolving binary path: %w", err)
 	}
 
+	if dryRunFlag {
+		fmt.Fprintf(out, "Dry run: would build the latest source from %s and replace %s.\n", repoURL, exe)
+		return nil
+	}
+
 	fmt.Fprintf(out, "Updating binary at %s\n\n", exe)
 
 	tmpDir, err := os.MkdirTemp("", "trello-cli-update-*")
@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/api"
+	"github.com/the20100/trello-cli/internal/output"
+)
+
+var webhooksCmd = &cobra.Command{
+	Use:   "webhooks",
+	Short: "Manage Trello webhooks",
+}
+
+// ---- webhooks list ----
+
+var webhooksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List webhooks registered with your token",
+	Long: `List all webhooks registered with the current API token.
+
+Webhooks that keep failing show their consecutive failure count and the date
+of the first failure; Trello disables a webhook after repeated failures.
+
+Examples:
+  trello webhooks list
+  trello webhooks list --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		webhooks, err := client.GetTokenWebhooks()
+		if err != nil {
+			return err
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(webhooks, output.IsPretty(cmd))
+		}
+
+		if len(webhooks) == 0 {
+			fmt.Println("No webhooks found.")
+			return nil
+		}
+
+		headers := []string{"ID", "MODEL", "CALLBACK", "ACTIVE", "FAILURES", "FAILING SINCE", "DESCRIPTION"}
+		rows := make([][]string, len(webhooks))
+		for i, w := range webhooks {
+			rows[i] = []string{
+				w.ID,
+				w.IDModel,
+				output.Truncate(w.CallbackURL, 40),
+				output.FormatBool(w.Active),
+				fmt.Sprintf("%d", w.ConsecutiveFailures),
+				formatFailDate(w),
+				output.Truncate(w.Description, 30),
+			}
+		}
+		output.PrintTable(headers, rows)
+		return nil
+	},
+}
+
+// ---- webhooks get ----
+
+var webhooksGetCmd = &cobra.Command{
+	Use:   "get <webhook-id>",
+	Short: "Get details of a webhook",
+	Long: `Get full details of a Trello webhook by its ID.
+
+Examples:
+  trello webhooks get abc123
+  trello webhooks get abc123 --pretty`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w, err := client.GetWebhook(args[0])
+		if err != nil {
+			return err
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(w, output.IsPretty(cmd))
+		}
+
+		printWebhook(w)
+		return nil
+	},
+}
+
+// ---- webhooks create ----
+
+var (
+	webhooksCreateModel    string
+	webhooksCreateBoard    string
+	webhooksCreateCallback string
+	webhooksCreateDesc     string
+	webhooksCreateActive   bool
+)
+
+var webhooksCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Register a new webhook",
+	Long: `Register a webhook that receives events for a board, list, or card.
+
+--model accepts anything "trello open" does: an ID, short link, URL, a
+board name, or a list/card name or "#<number>" together with --board.
+
+Trello checks the callback URL with a HEAD request when the webhook is
+created, so the receiver must already be reachable (see: trello webhooks serve).
+
+Examples:
+  trello webhooks create --model <board-id> --callback https://example.com/hook
+  trello webhooks create --model "My Project" --callback https://example.com/hook --description "CI"
+  trello webhooks create --model "#42" --board "My Project" --callback https://example.com/hook --active=false`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if webhooksCreateModel == "" {
+			return fmt.Errorf("--model is required")
+		}
+		if webhooksCreateCallback == "" {
+			return fmt.Errorf("--callback is required")
+		}
+
+		model, err := resolveRef(webhooksCreateModel, "", webhooksCreateBoard)
+		if err != nil {
+			return err
+		}
+
+		params := buildParams()
+		if cmd.Flags().Changed("active") {
+			params.Set("active", fmt.Sprintf("%t", webhooksCreateActive))
+		}
+		w, err := client.CreateWebhook(webhooksCreateCallback, model.ID, webhooksCreateDesc, params)
+		if err != nil {
+			return err
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(w, output.IsPretty(cmd))
+		}
+
+		fmt.Printf("Webhook created for %s %q\n", model.Kind, model.Name)
+		fmt.Printf("ID:       %s\n", w.ID)
+		fmt.Printf("Callback: %s\n", w.CallbackURL)
+		return nil
+	},
+}
+
+// ---- webhooks update ----
+
+var (
+	webhooksUpdateModel    string
+	webhooksUpdateBoard    string
+	webhooksUpdateCallback string
+	webhooksUpdateDesc     string
+	webhooksUpdateActive   bool
+)
+
+var webhooksUpdateCmd = &cobra.Command{
+	Use:   "update <webhook-id>",
+	Short: "Update a webhook",
+	Long: `Update a webhook's model, callback URL, description, or active state.
+
+Examples:
+  trello webhooks update abc123 --callback https://example.com/new-hook
+  trello webhooks update abc123 --active=false
+  trello webhooks update abc123 --model "Other Board" --description "moved"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params := buildParams(
+			"callbackURL", webhooksUpdateCallback,
+			"description", webhooksUpdateDesc,
+		)
+		if webhooksUpdateModel != "" {
+			model, err := resolveRef(webhooksUpdateModel, "", webhooksUpdateBoard)
+			if err != nil {
+				return err
+			}
+			params.Set("idModel", model.ID)
+		}
+		if cmd.Flags().Changed("active") {
+			params.Set("active", fmt.Sprintf("%t", webhooksUpdateActive))
+		}
+		if len(params) == 0 {
+			return fmt.Errorf("nothing to update: provide --model, --callback, --description, or --active")
+		}
+
+		w, err := client.UpdateWebhook(args[0], params)
+		if err != nil {
+			return err
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(w, output.IsPretty(cmd))
+		}
+
+		fmt.Printf("Webhook updated: %s\n", w.ID)
+		printWebhook(w)
+		return nil
+	},
+}
+
+// ---- webhooks delete ----
+
+var webhooksDeleteCmd = &cobra.Command{
+	Use:   "delete <webhook-id>",
+	Short: "Delete a webhook",
+	Long: `Delete a Trello webhook. Trello stops sending events immediately.
+
+Examples:
+  trello webhooks delete abc123`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := client.DeleteWebhook(args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("Webhook %s deleted.\n", args[0])
+		return nil
+	},
+}
+
+// printWebhook prints a webhook as a key-value table.
+func printWebhook(w *api.Webhook) {
+	output.PrintKeyValue([][]string{
+		{"ID", w.ID},
+		{"Description", w.Description},
+		{"Model", w.IDModel},
+		{"Callback", w.CallbackURL},
+		{"Active", output.FormatBool(w.Active)},
+		{"Failures", fmt.Sprintf("%d", w.ConsecutiveFailures)},
+		{"Failing since", formatFailDate(*w)},
+	})
+}
+
+// formatFailDate formats the date a webhook started failing, or "-".
+func formatFailDate(w api.Webhook) string {
+	if w.FirstConsecutiveFailDate == nil {
+		return "-"
+	}
+	return output.FormatTime(*w.FirstConsecutiveFailDate)
+}
+
+func init() {
+	// create flags
+	webhooksCreateCmd.Flags().StringVar(&webhooksCreateModel, "model", "", "Board, list, or card to watch (required)")
+	webhooksCreateCmd.Flags().StringVar(&webhooksCreateBoard, "board", "", "Board context for list/card names and #numbers")
+	webhooksCreateCmd.Flags().StringVar(&webhooksCreateCallback, "callback", "", "Callback URL that receives events (required)")
+	webhooksCreateCmd.Flags().StringVar(&webhooksCreateDesc, "description", "", "Webhook description")
+	webhooksCreateCmd.Flags().BoolVar(&webhooksCreateActive, "active", true, "Whether the webhook is active")
+
+	// update flags
+	webhooksUpdateCmd.Flags().StringVar(&webhooksUpdateModel, "model", "", "New board, list, or card to watch")
+	webhooksUpdateCmd.Flags().StringVar(&webhooksUpdateBoard, "board", "", "Board context for list/card names and #numbers")
+	webhooksUpdateCmd.Flags().StringVar(&webhooksUpdateCallback, "callback", "", "New callback URL")
+	webhooksUpdateCmd.Flags().StringVar(&webhooksUpdateDesc, "description", "", "New description")
+	webhooksUpdateCmd.Flags().BoolVar(&webhooksUpdateActive, "active", true, "Activate or deactivate the webhook")
+
+	webhooksCmd.AddCommand(
+		webhooksListCmd,
+		webhooksGetCmd,
+		webhooksCreateCmd,
+		webhooksUpdateCmd,
+		webhooksDeleteCmd,
+	)
+	rootCmd.AddCommand(webhooksCmd)
+}
@@ -0,0 +1,315 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/trello-cli/internal/api"
+	"github.com/the20100/trello-cli/internal/webhook"
+)
+
+// ---- webhooks serve ----
+
+var (
+	webhooksServeAddr     string
+	webhooksServeSecret   string
+	webhooksServeCallback string
+	webhooksServeHooks    []string
+	webhooksServeForward  []string
+	webhooksServeQuiet    bool
+	webhooksServeInsecure bool
+)
+
+var webhooksServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run a local receiver for Trello webhook events",
+	Long: `Run an HTTP server that receives Trello webhook deliveries.
+
+The server answers Trello's HEAD verification request, verifies each
+X-Trello-Webhook signature (HMAC-SHA1 of body + callback URL with your
+application secret), decodes the payload, and then for every event:
+
+  - prints it as one NDJSON line on stdout (unless --quiet)
+  - runs the matching --hook command, with the event JSON on stdin and
+    TRELLO_ACTION_TYPE / TRELLO_ACTION_ID / TRELLO_MODEL_ID in the environment
+  - POSTs the original body to every --forward URL
+
+The signature covers the exact callback URL registered with Trello. Behind a
+proxy or tunnel, pass it with --callback-url; otherwise it is reconstructed
+from the request (honouring X-Forwarded-Proto and X-Forwarded-Host).
+
+Events are processed in arrival order while Trello gets its 200 right
+away. If more than 256 events are waiting, new ones are dropped and
+reported on stderr.
+
+The secret can also be set with TRELLO_WEBHOOK_SECRET. The server refuses
+to start without one unless --insecure is given, in which case signatures
+are not checked (for local testing only).
+
+Examples:
+  trello webhooks serve --addr :8080 --secret $APP_SECRET
+  trello webhooks serve --callback-url https://hooks.example.com/trello --secret $APP_SECRET
+  trello webhooks serve --hook commentCard='./notify.sh' --hook '*=logger -t trello'
+  trello webhooks serve --forward http://localhost:9000/events --quiet --secret $APP_SECRET
+  trello webhooks serve --insecure   # local testing with unsigned fixtures`,
+	Annotations: map[string]string{noAuthAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		hooks, err := parseHooks(webhooksServeHooks)
+		if err != nil {
+			return err
+		}
+
+		secret := webhooksServeSecret
+		if secret == "" {
+			secret = os.Getenv("TRELLO_WEBHOOK_SECRET")
+		}
+		switch {
+		case secret == "" && !webhooksServeInsecure:
+			return fmt.Errorf("no webhook secret: pass --secret or set TRELLO_WEBHOOK_SECRET (or --insecure to accept unsigned requests)")
+		case secret == "":
+			fmt.Fprintln(os.Stderr, "warning: --insecure given, signatures will not be verified")
+		}
+
+		// Events are handled one at a time, in arrival order, off the request path
+		// so Trello gets its 200 immediately.
+		events := make(chan receivedEvent, webhookQueueSize)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			enc := json.NewEncoder(os.Stdout)
+			for ev := range events {
+				if !webhooksServeQuiet {
+					if err := enc.Encode(ev.event); err != nil {
+						fmt.Fprintf(os.Stderr, "writing event: %v\n", err)
+					}
+				}
+				runHook(hooks, ev)
+				for _, u := range webhooksServeForward {
+					forwardEvent(u, ev)
+				}
+			}
+		}()
+
+		var dropped atomic.Int64
+		handler := &webhook.Handler{
+			Secret:      secret,
+			CallbackURL: webhooksServeCallback,
+			OnEvent: func(ev webhook.Event, raw []byte) {
+				// Never block the handler: a full queue drops the event.
+				select {
+				case events <- receivedEvent{event: ev, raw: raw}:
+				default:
+					n := dropped.Add(1)
+					fmt.Fprintf(os.Stderr, "%s dropped %s %s: queue full (%d dropped)\n",
+						time.Now().Format(time.RFC3339), ev.Action.Type, ev.Action.ID, n)
+				}
+			},
+			OnError: func(r *http.Request, err error) {
+				fmt.Fprintf(os.Stderr, "%s rejected %s %s: %v\n", time.Now().Format(time.RFC3339), r.Method, r.URL.Path, err)
+			},
+		}
+		srv := &http.Server{
+			Addr:              webhooksServeAddr,
+			Handler:           handler,
+			ReadHeaderTimeout: 10 * time.Second,
+			// Bounds every handler, so Shutdown below outlasts them all.
+			ReadTimeout: 10 * time.Second,
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		stopped := make(chan struct{})
+		go func() {
+			defer close(stopped)
+			<-ctx.Done()
+			shutdown, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			defer cancel()
+			srv.Shutdown(shutdown)
+		}()
+
+		fmt.Fprintf(os.Stderr, "Listening for Trello webhooks on %s\n", webhooksServeAddr)
+		err = srv.ListenAndServe()
+		// ListenAndServe returns as soon as Shutdown starts; handlers may still
+		// be queueing events until it returns.
+		stop()
+		<-stopped
+		close(events)
+		<-done
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	},
+}
+
+// webhookQueueSize is how many events may wait for processing before new
+// ones are dropped.
+const webhookQueueSize = 256
+
+// receivedEvent is a verified delivery queued for processing.
+type receivedEvent struct {
+	event webhook.Event
+	raw   []byte
+}
+
+// parseHooks parses --hook type=command flags into a map. "*" matches every type.
+func parseHooks(specs []string) (map[string]string, error) {
+	hooks := map[string]string{}
+	for _, spec := range specs {
+		typ, command, ok := strings.Cut(spec, "=")
+		if !ok || strings.TrimSpace(typ) == "" || strings.TrimSpace(command) == "" {
+			return nil, fmt.Errorf("invalid --hook %q: use <action-type>=<command>", spec)
+		}
+		hooks[strings.TrimSpace(typ)] = command
+	}
+	return hooks, nil
+}
+
+// runHook runs the hook for the event's action type, falling back to "*".
+func runHook(hooks map[string]string, ev receivedEvent) {
+	command, ok := hooks[ev.event.Action.Type]
+	if !ok {
+		command, ok = hooks["*"]
+	}
+	if !ok {
+		return
+	}
+
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.Command("cmd", "/C", command)
+	} else {
+		c = exec.Command("sh", "-c", command)
+	}
+	c.Stdin = bytes.NewReader(ev.raw)
+	c.Stdout = os.Stderr
+	c.Stderr = os.Stderr
+	c.Env = append(os.Environ(),
+		"TRELLO_ACTION_TYPE="+ev.event.Action.Type,
+		"TRELLO_ACTION_ID="+ev.event.Action.ID,
+		"TRELLO_MODEL_ID="+modelID(ev.event.Model),
+	)
+	if err := c.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "hook for %s failed: %v\n", ev.event.Action.Type, err)
+	}
+}
+
+// forwardEvent re-posts the original delivery body to u.
+func forwardEvent(u string, ev receivedEvent) {
+	req, err := http.NewRequest(http.MethodPost, u, bytes.NewReader(ev.raw))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "forward to %s: %v\n", u, err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Trello-Action-Type", ev.event.Action.Type)
+	hc := &http.Client{Timeout: 10 * time.Second}
+	resp, err := hc.Do(req)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "forward to %s: %v\n", u, err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		fmt.Fprintf(os.Stderr, "forward to %s: HTTP %d\n", u, resp.StatusCode)
+	}
+}
+
+// modelID extracts the "id" field of a webhook payload's model.
+func modelID(raw json.RawMessage) string {
+	var m struct {
+		ID string `json:"id"`
+	}
+	json.Unmarshal(raw, &m)
+	return m.ID
+}
+
+// ---- webhooks send ----
+
+var (
+	webhooksSendURL      string
+	webhooksSendSecret   string
+	webhooksSendCallback string
+)
+
+var webhooksSendCmd = &cobra.Command{
+	Use:   "send <payload.json>",
+	Short: "Post a signed webhook payload to a receiver (for testing)",
+	Long: `Sign a webhook payload fixture the way Trello does and POST it to a receiver,
+e.g. a local "trello webhooks serve". Use - to read the payload from stdin.
+
+The signature is computed over the body and --callback-url, which defaults
+to --url.
+
+Examples:
+  trello webhooks send fixtures/comment.json --url http://localhost:8080/ --secret test
+  cat fixtures/move.json | trello webhooks send - --secret test`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{noAuthAnnotation: "true", dryRunAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		body, err := readTextSource(args[0])
+		if err != nil {
+			return err
+		}
+		callback := webhooksSendCallback
+		if callback == "" {
+			callback = webhooksSendURL
+		}
+
+		req, err := http.NewRequest(http.MethodPost, webhooksSendURL, strings.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if webhooksSendSecret != "" {
+			req.Header.Set(webhook.SignatureHeader, webhook.Sign(webhooksSendSecret, []byte(body), callback))
+		}
+		if dryRunFlag {
+			op := api.Operation{Method: req.Method, Path: webhooksSendURL, Description: "send webhook payload to " + webhooksSendURL, Body: body}
+			if json.Valid([]byte(body)) {
+				op.Body = json.RawMessage(body)
+			}
+			reportDryRun(cmd, op)
+			return nil
+		}
+		resp, err := (&http.Client{Timeout: 10 * time.Second}).Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		fmt.Printf("%s → %s\n", webhooksSendURL, resp.Status)
+		if resp.StatusCode >= 400 {
+			return fmt.Errorf("receiver responded %s", resp.Status)
+		}
+		return nil
+	},
+}
+
+func init() {
+	webhooksServeCmd.Flags().StringVar(&webhooksServeAddr, "addr", ":8080", "Address to listen on")
+	webhooksServeCmd.Flags().StringVar(&webhooksServeSecret, "secret", "", "Trello application secret used to verify signatures")
+	webhooksServeCmd.Flags().StringVar(&webhooksServeCallback, "callback-url", "", "Public callback URL registered with Trello (default: derived from the request)")
+	webhooksServeCmd.Flags().StringArrayVar(&webhooksServeHooks, "hook", nil, "Run a shell command per event: <action-type>=<command>, or *=<command> (repeatable)")
+	webhooksServeCmd.Flags().StringArrayVar(&webhooksServeForward, "forward", nil, "Forward each event to this URL (repeatable)")
+	webhooksServeCmd.Flags().BoolVar(&webhooksServeQuiet, "quiet", false, "Do not print events as NDJSON on stdout")
+	webhooksServeCmd.Flags().BoolVar(&webhooksServeInsecure, "insecure", false, "Accept requests without verifying signatures when no secret is set")
+
+	webhooksSendCmd.Flags().StringVar(&webhooksSendURL, "url", "http://localhost:8080/", "Receiver URL")
+	webhooksSendCmd.Flags().StringVar(&webhooksSendSecret, "secret", "", "Application secret to sign the payload with")
+	webhooksSendCmd.Flags().StringVar(&webhooksSendCallback, "callback-url", "", "Callback URL to sign against (default: --url)")
+
+	webhooksCmd.AddCommand(webhooksServeCmd, webhooksSendCmd)
+}
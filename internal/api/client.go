@@ -5,9 +5,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/the20100/trello-cli/internal/strutil"
 )
 
 const apiBase = "https://api.trello.com/1"
@@ -16,7 +23,21 @@ const apiBase = "https://api.trello.com/1"
 type Client struct {
 	apiKey     string
 	apiToken   string
+	baseURL    string
 	httpClient *http.Client
+
+	mu        sync.Mutex
+	rateLimit RateLimit
+
+	dry *dryRun // set by SetDryRun
+}
+
+// RateLimit is the token rate-limit state reported by the most recent response.
+// Zero values mean the API did not report it.
+type RateLimit struct {
+	Max       int           // requests allowed per interval
+	Remaining int           // requests left in the current interval
+	Interval  time.Duration // length of the rate-limit window
 }
 
 // NewClient creates a new authenticated Client.
@@ -24,12 +45,18 @@ func NewClient(apiKey, apiToken string) *Client {
 	return &Client{
 		apiKey:   apiKey,
 		apiToken: apiToken,
+		baseURL:  apiBase,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
 	}
 }
 
+// SetBaseURL points the client at another API root, such as a test server.
+func (c *Client) SetBaseURL(u string) {
+	c.baseURL = strings.TrimRight(u, "/")
+}
+
 // authParams returns the base auth query params added to every request.
 func (c *Client) authParams() url.Values {
 	p := url.Values{}
@@ -40,7 +67,7 @@ func (c *Client) authParams() url.Values {
 
 // buildURL constructs a full API URL merging auth params with caller params.
 func (c *Client) buildURL(path string, params url.Values) string {
-	u, _ := url.Parse(apiBase + path)
+	u, _ := url.Parse(c.baseURL + path)
 	q := c.authParams()
 	for k, vs := range params {
 		for _, v := range vs {
@@ -53,13 +80,24 @@ func (c *Client) buildURL(path string, params url.Values) string {
 
 // doRequest executes an HTTP request and returns the body bytes.
 func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	return c.doRequestWith(c.httpClient, req)
+}
+
+// transferClient returns an HTTP client without the overall request timeout,
+// for uploads and downloads whose duration depends on their size.
+func (c *Client) transferClient() *http.Client {
+	return &http.Client{Transport: c.httpClient.Transport}
+}
+
+func (c *Client) doRequestWith(hc *http.Client, req *http.Request) ([]byte, error) {
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := hc.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
+	c.recordRateLimit(resp.Header)
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -74,11 +112,37 @@ func (c *Client) doRequest(req *http.Request) ([]byte, error) {
 	return body, nil
 }
 
+// recordRateLimit stores the token rate-limit headers of a response, if present.
+func (c *Client) recordRateLimit(h http.Header) {
+	remaining, err := strconv.Atoi(h.Get("X-Rate-Limit-Api-Token-Remaining"))
+	if err != nil {
+		return
+	}
+	max, _ := strconv.Atoi(h.Get("X-Rate-Limit-Api-Token-Max"))
+	ms, _ := strconv.Atoi(h.Get("X-Rate-Limit-Api-Token-Interval-Ms"))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rateLimit = RateLimit{Max: max, Remaining: remaining, Interval: time.Duration(ms) * time.Millisecond}
+}
+
+// RateLimit returns the rate-limit state reported by the most recent response.
+func (c *Client) RateLimit() RateLimit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rateLimit
+}
+
 // Get makes a GET request to path with the given extra params.
 func (c *Client) Get(path string, params url.Values) ([]byte, error) {
 	if params == nil {
 		params = url.Values{}
 	}
+	if c.dry != nil {
+		if body, ok := c.dryGet(path); ok {
+			return body, nil
+		}
+	}
 	req, err := http.NewRequest(http.MethodGet, c.buildURL(path, params), nil)
 	if err != nil {
 		return nil, err
@@ -91,6 +155,9 @@ func (c *Client) Post(path string, params url.Values, payload any) ([]byte, erro
 	if params == nil {
 		params = url.Values{}
 	}
+	if c.dry != nil {
+		return c.intercept(http.MethodPost, path, params, payload)
+	}
 	data, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("encoding request: %w", err)
@@ -108,6 +175,9 @@ func (c *Client) Put(path string, params url.Values, payload any) ([]byte, error
 	if params == nil {
 		params = url.Values{}
 	}
+	if c.dry != nil {
+		return c.intercept(http.MethodPut, path, params, payload)
+	}
 	var bodyReader io.Reader
 	if payload != nil {
 		data, err := json.Marshal(payload)
@@ -126,11 +196,53 @@ func (c *Client) Put(path string, params url.Values, payload any) ([]byte, error
 	return c.doRequest(req)
 }
 
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// PostMultipart makes a multipart/form-data POST request to path, streaming
+// file as the form field fileField. contentType may be empty.
+func (c *Client) PostMultipart(path string, params url.Values, fileField, fileName, contentType string, file io.Reader) ([]byte, error) {
+	if params == nil {
+		params = url.Values{}
+	}
+	if c.dry != nil {
+		return c.intercept(http.MethodPost, path, params, map[string]string{fileField: fileName})
+	}
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, quoteEscaper.Replace(fileField), quoteEscaper.Replace(fileName)))
+		h.Set("Content-Type", contentType)
+		part, err := mw.CreatePart(h)
+		if err == nil {
+			_, err = io.Copy(part, file)
+		}
+		if err == nil {
+			err = mw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	req, err := http.NewRequest(http.MethodPost, c.buildURL(path, params), pr)
+	if err != nil {
+		pr.Close()
+		return nil, err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return c.doRequestWith(c.transferClient(), req)
+}
+
 // Delete makes a DELETE request to path.
 func (c *Client) Delete(path string, params url.Values) ([]byte, error) {
 	if params == nil {
 		params = url.Values{}
 	}
+	if c.dry != nil {
+		return c.intercept(http.MethodDelete, path, params, nil)
+	}
 	req, err := http.NewRequest(http.MethodDelete, c.buildURL(path, params), nil)
 	if err != nil {
 		return nil, err
@@ -138,6 +250,48 @@ func (c *Client) Delete(path string, params url.Values) ([]byte, error) {
 	return c.doRequest(req)
 }
 
+// MaxBatch is the number of requests the batch endpoint accepts at once.
+const MaxBatch = 10
+
+// Batch makes up to MaxBatch GET requests in one call. paths are API paths
+// with their query strings, e.g. "/lists/abc/cards?filter=all", and must not
+// contain commas. It returns one body or one error per path, in order.
+func (c *Client) Batch(paths []string) ([]json.RawMessage, []error, error) {
+	if len(paths) > MaxBatch {
+		return nil, nil, fmt.Errorf("batch: %d requests, at most %d allowed", len(paths), MaxBatch)
+	}
+	if c.dry != nil {
+		if results, errs, ok, err := c.dryBatch(paths); ok {
+			return results, errs, err
+		}
+	}
+	body, err := c.Get("/batch", url.Values{"urls": {strings.Join(paths, ",")}})
+	if err != nil {
+		return nil, nil, err
+	}
+	var entries []map[string]json.RawMessage
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return nil, nil, fmt.Errorf("decoding batch response: %w", err)
+	}
+	if len(entries) != len(paths) {
+		return nil, nil, fmt.Errorf("batch: %d responses for %d requests", len(entries), len(paths))
+	}
+	results := make([]json.RawMessage, len(paths))
+	errs := make([]error, len(paths))
+	for i, e := range entries {
+		if ok, found := e["200"]; found {
+			results[i] = ok
+			continue
+		}
+		var status int
+		var msg string
+		json.Unmarshal(e["statusCode"], &status)
+		json.Unmarshal(e["message"], &msg)
+		errs[i] = &TrelloError{StatusCode: status, Message: fmt.Sprintf("HTTP %d: %s (%s)", status, msg, paths[i])}
+	}
+	return results, errs, nil
+}
+
 // ---- Boards ----
 
 // GetBoard returns a board by ID.
@@ -187,6 +341,17 @@ func (c *Client) CreateBoard(name, desc, idOrganization string, prefs url.Values
 	return &b, json.Unmarshal(body, &b)
 }
 
+// CustomFieldsPluginID is the ID of Trello's Custom Fields power-up.
+const CustomFieldsPluginID = "56d5e249a98895a9797bebb9"
+
+// EnableBoardPlugin enables a power-up on a board.
+func (c *Client) EnableBoardPlugin(boardID, pluginID string) error {
+	params := url.Values{}
+	params.Set("idPlugin", pluginID)
+	_, err := c.Post("/boards/"+boardID+"/boardPlugins", params, nil)
+	return err
+}
+
 // UpdateBoard updates a board.
 func (c *Client) UpdateBoard(id string, params url.Values) (*Board, error) {
 	body, err := c.Put("/boards/"+id, params, nil)
@@ -223,6 +388,12 @@ func (c *Client) GetBoardCards(boardID, filter string) ([]Card, error) {
 	if filter != "" {
 		params.Set("filter", filter)
 	}
+	return c.GetBoardCardsWithParams(boardID, params)
+}
+
+// GetBoardCardsWithParams returns cards for a board using arbitrary query params,
+// e.g. members=true or checklists=all to embed nested resources in one call.
+func (c *Client) GetBoardCardsWithParams(boardID string, params url.Values) ([]Card, error) {
 	body, err := c.Get("/boards/"+boardID+"/cards", params)
 	if err != nil {
 		return nil, err
@@ -241,6 +412,29 @@ func (c *Client) GetBoardMembers(boardID string) ([]Member, error) {
 	return members, json.Unmarshal(body, &members)
 }
 
+// GetBoardMemberships returns the memberships of a board with their members.
+func (c *Client) GetBoardMemberships(boardID string) ([]Membership, error) {
+	body, err := c.Get("/boards/"+boardID+"/memberships", url.Values{"member": {"true"}})
+	if err != nil {
+		return nil, err
+	}
+	var memberships []Membership
+	return memberships, json.Unmarshal(body, &memberships)
+}
+
+// SetBoardMember adds a member to a board, or changes their type: normal,
+// admin, or observer.
+func (c *Client) SetBoardMember(boardID, memberID, memberType string) error {
+	_, err := c.Put("/boards/"+boardID+"/members/"+memberID, url.Values{"type": {memberType}}, nil)
+	return err
+}
+
+// RemoveBoardMember removes a member from a board.
+func (c *Client) RemoveBoardMember(boardID, memberID string) error {
+	_, err := c.Delete("/boards/"+boardID+"/members/"+memberID, nil)
+	return err
+}
+
 // GetBoardLabels returns all labels on a board.
 func (c *Client) GetBoardLabels(boardID string) ([]Label, error) {
 	body, err := c.Get("/boards/"+boardID+"/labels", nil)
@@ -311,6 +505,11 @@ func (c *Client) GetListCards(listID, filter string) ([]Card, error) {
 	if filter != "" {
 		params.Set("filter", filter)
 	}
+	return c.GetListCardsWithParams(listID, params)
+}
+
+// GetListCardsWithParams returns cards in a list using arbitrary query params.
+func (c *Client) GetListCardsWithParams(listID string, params url.Values) ([]Card, error) {
 	body, err := c.Get("/lists/"+listID+"/cards", params)
 	if err != nil {
 		return nil, err
@@ -398,6 +597,78 @@ func (c *Client) GetCardAttachments(cardID string) ([]Attachment, error) {
 	return attachments, json.Unmarshal(body, &attachments)
 }
 
+// GetCardAttachment returns a single attachment of a card.
+func (c *Client) GetCardAttachment(cardID, attachmentID string) (*Attachment, error) {
+	body, err := c.Get("/cards/"+cardID+"/attachments/"+attachmentID, nil)
+	if err != nil {
+		return nil, err
+	}
+	var a Attachment
+	return &a, json.Unmarshal(body, &a)
+}
+
+// AttachURL attaches a link to a card. params may set name and setCover.
+func (c *Client) AttachURL(cardID, link string, params url.Values) (*Attachment, error) {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("url", link)
+	body, err := c.Post("/cards/"+cardID+"/attachments", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	var a Attachment
+	return &a, json.Unmarshal(body, &a)
+}
+
+// UploadAttachment uploads file to a card as fileName. params may set name,
+// mimeType, and setCover.
+func (c *Client) UploadAttachment(cardID, fileName string, file io.Reader, params url.Values) (*Attachment, error) {
+	body, err := c.PostMultipart("/cards/"+cardID+"/attachments", params, "file", fileName, params.Get("mimeType"), file)
+	if err != nil {
+		return nil, err
+	}
+	var a Attachment
+	return &a, json.Unmarshal(body, &a)
+}
+
+// DownloadAttachment writes the content of an uploaded attachment to w.
+// Downloads must be authenticated with an OAuth header rather than query
+// parameters.
+func (c *Client) DownloadAttachment(cardID, attachmentID, fileName string, w io.Writer) (int64, error) {
+	u := c.baseURL + "/cards/" + cardID + "/attachments/" + attachmentID + "/download/" + url.PathEscape(fileName)
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf(`OAuth oauth_consumer_key="%s", oauth_token="%s"`, c.apiKey, c.apiToken))
+
+	resp, err := c.transferClient().Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	c.recordRateLimit(resp.Header)
+	if resp.StatusCode >= 400 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return 0, &TrelloError{StatusCode: resp.StatusCode, Message: fmt.Sprintf("HTTP %d: %s", resp.StatusCode, msg)}
+	}
+	return io.Copy(w, resp.Body)
+}
+
+// DeleteAttachment removes an attachment from a card.
+func (c *Client) DeleteAttachment(cardID, attachmentID string) error {
+	_, err := c.Delete("/cards/"+cardID+"/attachments/"+attachmentID, nil)
+	return err
+}
+
+// GetCardComments returns the comment actions on a card, newest first.
+func (c *Client) GetCardComments(cardID string) ([]Action, error) {
+	params := url.Values{}
+	params.Set("filter", "commentCard")
+	return c.GetActions("cards", cardID, params)
+}
+
 // AddComment adds a comment to a card.
 func (c *Client) AddComment(cardID, text string) (*Action, error) {
 	params := url.Values{}
@@ -410,6 +681,36 @@ func (c *Client) AddComment(cardID, text string) (*Action, error) {
 	return &action, json.Unmarshal(body, &action)
 }
 
+// GetAction returns a single action, including its creator.
+func (c *Client) GetAction(id string) (*Action, error) {
+	params := url.Values{}
+	params.Set("memberCreator", "true")
+	body, err := c.Get("/actions/"+id, params)
+	if err != nil {
+		return nil, err
+	}
+	var action Action
+	return &action, json.Unmarshal(body, &action)
+}
+
+// UpdateComment replaces the text of a comment action.
+func (c *Client) UpdateComment(actionID, text string) (*Action, error) {
+	params := url.Values{}
+	params.Set("value", text)
+	body, err := c.Put("/actions/"+actionID+"/text", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	var action Action
+	return &action, json.Unmarshal(body, &action)
+}
+
+// DeleteComment deletes a comment action.
+func (c *Client) DeleteComment(actionID string) error {
+	_, err := c.Delete("/actions/"+actionID, nil)
+	return err
+}
+
 // AddLabelToCard adds a label to a card.
 func (c *Client) AddLabelToCard(cardID, labelID string) error {
 	params := url.Values{}
@@ -464,6 +765,20 @@ func (c *Client) GetMemberBoards(idOrUsername, filter string) ([]Board, error) {
 	return boards, json.Unmarshal(body, &boards)
 }
 
+// GetOrganizationBoards returns the boards of a workspace, by ID or name.
+func (c *Client) GetOrganizationBoards(idOrName, filter string) ([]Board, error) {
+	params := url.Values{}
+	if filter != "" {
+		params.Set("filter", filter)
+	}
+	body, err := c.Get("/organizations/"+idOrName+"/boards", params)
+	if err != nil {
+		return nil, err
+	}
+	var boards []Board
+	return boards, json.Unmarshal(body, &boards)
+}
+
 // GetMemberCards returns all cards assigned to a member.
 func (c *Client) GetMemberCards(idOrUsername, filter string) ([]Card, error) {
 	params := url.Values{}
@@ -531,6 +846,12 @@ func (c *Client) CreateCheckItem(checklistID, name string) (*CheckItem, error) {
 	return &item, json.Unmarshal(body, &item)
 }
 
+// DeleteCheckItem removes an item from a checklist.
+func (c *Client) DeleteCheckItem(checklistID, checkItemID string) error {
+	_, err := c.Delete("/checklists/"+checklistID+"/checkItems/"+checkItemID, nil)
+	return err
+}
+
 // UpdateCheckItem updates the state of a check item on a card.
 func (c *Client) UpdateCheckItem(cardID, checklistID, checkItemID, state string) (*CheckItem, error) {
 	params := url.Values{}
@@ -585,12 +906,13 @@ func (c *Client) GetLabel(id string) (*Label, error) {
 	return &l, json.Unmarshal(body, &l)
 }
 
-// CreateLabel creates a new label on a board.
+// CreateLabel creates a new label on a board. An empty color creates a
+// colorless label; Trello rejects an empty color value, so "null" is sent.
 func (c *Client) CreateLabel(idBoard, name, color string) (*Label, error) {
 	params := url.Values{}
 	params.Set("idBoard", idBoard)
 	params.Set("name", name)
-	params.Set("color", color)
+	params.Set("color", strutil.FirstNonEmpty(color, "null"))
 	body, err := c.Post("/labels", params, nil)
 	if err != nil {
 		return nil, err
@@ -599,8 +921,192 @@ func (c *Client) CreateLabel(idBoard, name, color string) (*Label, error) {
 	return &l, json.Unmarshal(body, &l)
 }
 
+// UpdateLabel updates a label's name and/or color.
+func (c *Client) UpdateLabel(id string, params url.Values) (*Label, error) {
+	body, err := c.Put("/labels/"+id, params, nil)
+	if err != nil {
+		return nil, err
+	}
+	var l Label
+	return &l, json.Unmarshal(body, &l)
+}
+
 // DeleteLabel deletes a label.
 func (c *Client) DeleteLabel(id string) error {
 	_, err := c.Delete("/labels/"+id, nil)
 	return err
 }
+
+// ---- Custom fields ----
+
+// GetBoardCustomFields returns the custom field definitions of a board.
+func (c *Client) GetBoardCustomFields(boardID string) ([]CustomField, error) {
+	body, err := c.Get("/boards/"+boardID+"/customFields", nil)
+	if err != nil {
+		return nil, err
+	}
+	var fields []CustomField
+	return fields, json.Unmarshal(body, &fields)
+}
+
+// CreateCustomField creates a custom field on a board. options are the
+// choices of a list field.
+func (c *Client) CreateCustomField(boardID, name, fieldType string, options []string, cardFront bool) (*CustomField, error) {
+	payload := map[string]any{
+		"idModel":           boardID,
+		"modelType":         "board",
+		"name":              name,
+		"type":              fieldType,
+		"pos":               "bottom",
+		"display_cardFront": cardFront,
+	}
+	if len(options) > 0 {
+		opts := make([]CustomFieldOption, len(options))
+		for i, o := range options {
+			opts[i] = CustomFieldOption{Value: CustomFieldValue{Text: o}, Pos: float64(i + 1)}
+		}
+		payload["options"] = opts
+	}
+	body, err := c.Post("/customFields", nil, payload)
+	if err != nil {
+		return nil, err
+	}
+	var f CustomField
+	return &f, json.Unmarshal(body, &f)
+}
+
+// UpdateCustomField updates a custom field definition, e.g. name or
+// display/cardFront.
+func (c *Client) UpdateCustomField(id string, payload map[string]any) (*CustomField, error) {
+	body, err := c.Put("/customFields/"+id, nil, payload)
+	if err != nil {
+		return nil, err
+	}
+	var f CustomField
+	return &f, json.Unmarshal(body, &f)
+}
+
+// AddCustomFieldOption adds a choice to a list custom field.
+func (c *Client) AddCustomFieldOption(fieldID, text string) (*CustomFieldOption, error) {
+	body, err := c.Post("/customFields/"+fieldID+"/options", nil, map[string]any{
+		"value": CustomFieldValue{Text: text},
+		"pos":   "bottom",
+	})
+	if err != nil {
+		return nil, err
+	}
+	var o CustomFieldOption
+	return &o, json.Unmarshal(body, &o)
+}
+
+// DeleteCustomFieldOption removes a choice from a list custom field.
+func (c *Client) DeleteCustomFieldOption(fieldID, optionID string) error {
+	_, err := c.Delete("/customFields/"+fieldID+"/options/"+optionID, nil)
+	return err
+}
+
+// DeleteCustomField deletes a custom field and its values on every card.
+func (c *Client) DeleteCustomField(id string) error {
+	_, err := c.Delete("/customFields/"+id, nil)
+	return err
+}
+
+// GetCardCustomFieldItems returns the custom field values set on a card.
+func (c *Client) GetCardCustomFieldItems(cardID string) ([]CustomFieldItem, error) {
+	body, err := c.Get("/cards/"+cardID+"/customFieldItems", nil)
+	if err != nil {
+		return nil, err
+	}
+	var items []CustomFieldItem
+	return items, json.Unmarshal(body, &items)
+}
+
+// SetCardCustomField sets a custom field on a card. For list fields pass the
+// option ID as idValue; otherwise pass value. A nil value and empty idValue
+// clear the field.
+func (c *Client) SetCardCustomField(cardID, fieldID string, value *CustomFieldValue, idValue string) error {
+	var payload any
+	switch {
+	case idValue != "":
+		payload = map[string]string{"idValue": idValue}
+	case value != nil:
+		payload = map[string]any{"value": value}
+	default:
+		payload = map[string]string{"value": "", "idValue": ""}
+	}
+	_, err := c.Put("/cards/"+cardID+"/customField/"+fieldID+"/item", nil, payload)
+	return err
+}
+
+// ---- Actions ----
+
+// GetActions returns actions (activity) on a model, newest first.
+// model is the collection name: "boards", "lists", "cards", or "members".
+// Useful params: filter, since, before, limit (max 1000), page.
+func (c *Client) GetActions(model, id string, params url.Values) ([]Action, error) {
+	body, err := c.Get("/"+model+"/"+id+"/actions", params)
+	if err != nil {
+		return nil, err
+	}
+	var actions []Action
+	return actions, json.Unmarshal(body, &actions)
+}
+
+// ---- Webhooks ----
+
+// GetTokenWebhooks returns all webhooks registered with the client's token.
+func (c *Client) GetTokenWebhooks() ([]Webhook, error) {
+	body, err := c.Get("/tokens/"+c.apiToken+"/webhooks", nil)
+	if err != nil {
+		return nil, err
+	}
+	var webhooks []Webhook
+	return webhooks, json.Unmarshal(body, &webhooks)
+}
+
+// GetWebhook returns a webhook by ID.
+func (c *Client) GetWebhook(id string) (*Webhook, error) {
+	body, err := c.Get("/webhooks/"+id, nil)
+	if err != nil {
+		return nil, err
+	}
+	var w Webhook
+	return &w, json.Unmarshal(body, &w)
+}
+
+// CreateWebhook registers a webhook that posts events for idModel to callbackURL.
+func (c *Client) CreateWebhook(callbackURL, idModel, description string, params url.Values) (*Webhook, error) {
+	p := url.Values{}
+	p.Set("callbackURL", callbackURL)
+	p.Set("idModel", idModel)
+	if description != "" {
+		p.Set("description", description)
+	}
+	for k, vs := range params {
+		for _, v := range vs {
+			p.Set(k, v)
+		}
+	}
+	body, err := c.Post("/webhooks", p, nil)
+	if err != nil {
+		return nil, err
+	}
+	var w Webhook
+	return &w, json.Unmarshal(body, &w)
+}
+
+// UpdateWebhook updates a webhook.
+func (c *Client) UpdateWebhook(id string, params url.Values) (*Webhook, error) {
+	body, err := c.Put("/webhooks/"+id, params, nil)
+	if err != nil {
+		return nil, err
+	}
+	var w Webhook
+	return &w, json.Unmarshal(body, &w)
+}
+
+// DeleteWebhook deletes a webhook.
+func (c *Client) DeleteWebhook(id string) error {
+	_, err := c.Delete("/webhooks/"+id, nil)
+	return err
+}
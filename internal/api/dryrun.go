@@ -0,0 +1,546 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/the20100/trello-cli/internal/strutil"
+)
+
+// Operation is a POST, PUT, or DELETE request intercepted in dry-run mode.
+type Operation struct {
+	Method      string     `json:"method"`
+	Path        string     `json:"path"`
+	Description string     `json:"description"`
+	Params      url.Values `json:"params,omitempty"`
+	Body        any        `json:"body,omitempty"`
+}
+
+// dryRun is the state of a client in dry-run mode: the objects "created"
+// so far, so later requests in the same command can refer to them.
+type dryRun struct {
+	report func(Operation)
+
+	mu      sync.Mutex
+	n       int
+	objects map[string]map[string]any // synthetic ID -> object
+	kinds   map[string]string         // synthetic ID -> collection ("cards", ...)
+	names   map[string]string         // ID -> display name, cached lookups
+}
+
+// syntheticPrefix starts every ID made up in dry-run mode. The IDs are
+// valid 24-character hex IDs so commands treat them like real ones.
+const syntheticPrefix = "dddddddddddddddd"
+
+// SetDryRun switches the client to dry-run mode: POST, PUT, and DELETE
+// requests are passed to report instead of being sent, and answered with
+// synthetic results built from their parameters, so commands that chain
+// several requests run to the end. GET requests still reach the API,
+// except for objects created during the dry run, which are answered from
+// memory. report may be called from several goroutines, one at a time.
+func (c *Client) SetDryRun(report func(Operation)) {
+	c.dry = &dryRun{
+		report:  report,
+		objects: map[string]map[string]any{},
+		kinds:   map[string]string{},
+		names:   map[string]string{},
+	}
+}
+
+// DryRun reports whether the client is in dry-run mode.
+func (c *Client) DryRun() bool {
+	return c.dry != nil
+}
+
+// IsSynthetic reports whether id was made up by a dry run.
+func IsSynthetic(id string) bool {
+	return len(id) == 24 && strings.HasPrefix(id, syntheticPrefix)
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// intercept reports a mutating request and returns its synthetic result.
+func (c *Client) intercept(method, path string, params url.Values, payload any) ([]byte, error) {
+	d := c.dry
+	segs := splitPath(path)
+
+	// Fields the request sets, typed like the API returns them.
+	fields := map[string]any{}
+	for k, vs := range params {
+		if v, ok := typedParam(k, vs[0]); ok {
+			fields[k] = v
+		}
+	}
+	if payload != nil {
+		if data, err := json.Marshal(payload); err == nil {
+			var m map[string]any
+			if json.Unmarshal(data, &m) == nil {
+				for k, v := range m {
+					fields[k] = v
+				}
+			}
+		}
+	}
+
+	// PUT /<things>/<id>/<field>?value=... sets one field of the object.
+	target := ""
+	switch {
+	case len(segs) == 2:
+		target = path
+	case len(segs) == 3 && method == http.MethodPut && len(params) == 1 && params.Has("value"):
+		target = "/" + segs[0] + "/" + segs[1]
+		fields = map[string]any{}
+		if v, ok := typedParam(segs[2], params.Get("value")); ok {
+			fields[segs[2]] = v
+		}
+	}
+	var current map[string]any
+	if target != "" && method != http.MethodPost {
+		current = c.dryLookup(target)
+	}
+
+	op := Operation{
+		Method:      method,
+		Path:        path,
+		Description: c.describe(method, segs, params, current),
+		Params:      c.redact(params),
+		Body:        payload,
+	}
+
+	var result any = map[string]any{}
+	switch method {
+	case http.MethodPost:
+		obj := fields
+		d.mu.Lock()
+		d.n++
+		obj["id"] = fmt.Sprintf("%s%08x", syntheticPrefix, d.n)
+		d.mu.Unlock()
+		kind := segs[0]
+		if len(segs) >= 3 {
+			kind = segs[len(segs)-1]
+			obj["id"+singularKey(segs[0])] = segs[1]
+		}
+		if kind == "comments" {
+			obj = map[string]any{"id": obj["id"], "type": "commentCard", "date": time.Now().UTC().Format(time.RFC3339),
+				"data": map[string]any{"text": params.Get("text"), "card": map[string]any{"id": segs[1]}}}
+		}
+		c.dryStore(obj["id"].(string), kind, obj)
+		result = obj
+	case http.MethodPut:
+		obj := map[string]any{}
+		for k, v := range current {
+			obj[k] = v
+		}
+		for k, v := range fields {
+			obj[k] = v
+		}
+		if _, ok := obj["id"]; !ok {
+			obj["id"] = segs[len(segs)-1]
+			if target != "" {
+				obj["id"] = segs[1]
+			}
+		}
+		if id, _ := obj["id"].(string); IsSynthetic(id) {
+			c.dryStore(id, "", obj)
+		}
+		result = obj
+	}
+
+	d.mu.Lock()
+	d.report(op)
+	d.mu.Unlock()
+	return json.Marshal(result)
+}
+
+// typedParam converts a query parameter to the JSON type of the field it
+// sets, reporting false when it cannot be represented.
+func typedParam(key, value string) (any, bool) {
+	switch key {
+	case "closed", "dueComplete", "subscribed", "active":
+		b, err := strconv.ParseBool(value)
+		return b, err == nil
+	case "pos":
+		f, err := strconv.ParseFloat(value, 64)
+		return f, err == nil
+	case "idLabels", "idMembers":
+		if value == "" {
+			return []string{}, true
+		}
+		return strings.Split(value, ","), true
+	}
+	return value, true
+}
+
+// singularKey turns a collection into the suffix of its parent ID field:
+// "cards" -> "Card" (idCard).
+func singularKey(collection string) string {
+	s := strings.TrimSuffix(collection, "s")
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func (c *Client) dryStore(id, kind string, obj map[string]any) {
+	d := c.dry
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.objects[id] = obj
+	if kind != "" {
+		d.kinds[id] = kind
+	}
+	if name, ok := obj["name"].(string); ok {
+		d.names[id] = name
+	}
+}
+
+// dryLookup returns the current state of the object at path ("/cards/<id>"),
+// or nil if it cannot be fetched.
+func (c *Client) dryLookup(path string) map[string]any {
+	segs := splitPath(path)
+	if IsSynthetic(segs[1]) {
+		c.dry.mu.Lock()
+		defer c.dry.mu.Unlock()
+		return c.dry.objects[segs[1]]
+	}
+	body, err := c.Get(path, nil)
+	if err != nil {
+		return nil
+	}
+	var obj map[string]any
+	if json.Unmarshal(body, &obj) != nil {
+		return nil
+	}
+	if name, ok := obj["name"].(string); ok {
+		c.dry.mu.Lock()
+		c.dry.names[segs[1]] = name
+		c.dry.mu.Unlock()
+	}
+	return obj
+}
+
+// dryGet answers a GET for a path that refers to an object created during
+// the dry run; ok is false for other paths.
+func (c *Client) dryGet(path string) (body []byte, ok bool) {
+	segs := splitPath(path)
+	if len(segs) < 2 || !IsSynthetic(segs[1]) {
+		return nil, false
+	}
+	d := c.dry
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(segs) == 2 {
+		obj := d.objects[segs[1]]
+		if obj == nil {
+			obj = map[string]any{"id": segs[1]}
+		}
+		body, _ = json.Marshal(obj)
+		return body, true
+	}
+	// A collection under the object: the objects created in it.
+	var children []map[string]any
+	ids := make([]string, 0, len(d.kinds))
+	for id := range d.kinds {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	parentKey := "id" + singularKey(segs[0])
+	for _, id := range ids {
+		obj := d.objects[id]
+		if d.kinds[id] == segs[2] && (obj[parentKey] == segs[1] || obj["idModel"] == segs[1]) {
+			children = append(children, obj)
+		}
+	}
+	if children == nil && !strings.HasSuffix(segs[len(segs)-1], "s") {
+		body, _ = json.Marshal(map[string]any{})
+		return body, true
+	}
+	if children == nil {
+		children = []map[string]any{}
+	}
+	body, _ = json.Marshal(children)
+	return body, true
+}
+
+// dryName returns a display name for the object id of collection, looking
+// it up (once) when it is not known yet.
+func (c *Client) dryName(collection, id string) string {
+	d := c.dry
+	d.mu.Lock()
+	name, ok := d.names[id]
+	d.mu.Unlock()
+	if !ok && !IsSynthetic(id) {
+		if obj := c.dryLookup("/" + collection + "/" + id); obj == nil {
+			d.mu.Lock()
+			d.names[id] = ""
+			d.mu.Unlock()
+		}
+		d.mu.Lock()
+		name = d.names[id]
+		d.mu.Unlock()
+	}
+	if name == "" {
+		return id
+	}
+	return fmt.Sprintf("%q (%s)", name, id)
+}
+
+var nouns = map[string]string{
+	"boards":       "board",
+	"lists":        "list",
+	"cards":        "card",
+	"labels":       "label",
+	"checklists":   "checklist",
+	"checkItems":   "check item",
+	"checkItem":    "check item",
+	"actions":      "comment",
+	"comments":     "comment",
+	"customFields": "custom field",
+	"customField":  "custom field",
+	"options":      "option",
+	"webhooks":     "webhook",
+	"members":      "member",
+	"idMembers":    "member",
+	"idLabels":     "label",
+	"attachments":  "attachment",
+	"boardPlugins": "power-up",
+}
+
+func noun(segment string) string {
+	if n, ok := nouns[segment]; ok {
+		return n
+	}
+	return segment
+}
+
+// describe turns a mutating request into a sentence such as
+// `Move card "Fix login" (abc) to list "Done" (def)`.
+func (c *Client) describe(method string, segs []string, params url.Values, current map[string]any) string {
+	what := noun(segs[0])
+	subject := func() string {
+		if len(segs) < 2 {
+			return what
+		}
+		return what + " " + c.dryName(segs[0], segs[1])
+	}
+	// parents names the object a new one is created in.
+	parents := func() string {
+		for _, p := range []struct{ key, collection, prep string }{
+			{"idCard", "cards", "on card"},
+			{"idList", "lists", "in list"},
+			{"idChecklist", "checklists", "in checklist"},
+			{"idBoard", "boards", "on board"},
+			{"idModel", "", "on"},
+		} {
+			if id := params.Get(p.key); id != "" {
+				if p.collection != "" {
+					id = c.dryName(p.collection, id)
+				}
+				return p.prep + " " + id
+			}
+		}
+		return ""
+	}
+
+	switch {
+	case method == http.MethodPost && len(segs) == 1:
+		s := "Create " + what
+		if name := params.Get("name"); name != "" {
+			s += fmt.Sprintf(" %q", name)
+		}
+		if p := parents(); p != "" {
+			s += " " + p
+		}
+		if cb := params.Get("callbackURL"); cb != "" {
+			s += " calling " + c.redactValue("callbackURL", cb)
+		}
+		return s
+	case method == http.MethodPost && len(segs) >= 3:
+		sub := segs[len(segs)-1]
+		switch sub {
+		case "comments":
+			return "Comment on " + subject()
+		case "attachments":
+			item := strutil.FirstNonEmpty(params.Get("name"), params.Get("url"), "a file")
+			return fmt.Sprintf("Attach %s to %s", item, subject())
+		}
+		item := strutil.FirstNonEmpty(params.Get("name"), params.Get("value"), params.Get("idPlugin"))
+		if item == "" {
+			return fmt.Sprintf("Add %s to %s", noun(sub), subject())
+		}
+		switch sub {
+		case "idLabels":
+			item = c.dryName("labels", item)
+		case "idMembers":
+		default:
+			item = fmt.Sprintf("%q", item)
+		}
+		return fmt.Sprintf("Add %s %s to %s", noun(sub), item, subject())
+	case method == http.MethodDelete && len(segs) == 2:
+		return "Delete " + subject()
+	case method == http.MethodDelete && len(segs) >= 4:
+		return fmt.Sprintf("Remove %s %s from %s", noun(segs[len(segs)-2]), segs[len(segs)-1], subject())
+	case method == http.MethodPut && len(segs) == 3 && params.Has("value"):
+		if segs[2] == "closed" {
+			if params.Get("value") == "true" {
+				return "Archive " + subject()
+			}
+			return "Unarchive " + subject()
+		}
+		if segs[2] == "text" {
+			return "Edit " + subject()
+		}
+		return fmt.Sprintf("Set %s of %s", segs[2], subject())
+	case method == http.MethodPut && len(segs) == 4 && segs[2] == "members":
+		return fmt.Sprintf("Make member %s %s of %s", segs[3], strutil.FirstNonEmpty(params.Get("type"), "a member"), subject())
+	case method == http.MethodPut && len(segs) >= 4:
+		return fmt.Sprintf("Update %s %s of %s", noun(segs[len(segs)-2]), segs[len(segs)-1], subject())
+	case method == http.MethodPut:
+		return c.describeUpdate(subject(), params, current)
+	}
+	return method + " " + "/" + strings.Join(segs, "/")
+}
+
+// describeUpdate describes a PUT on an object, naming archive and move
+// changes and listing the other fields set.
+func (c *Client) describeUpdate(subject string, params url.Values, current map[string]any) string {
+	var verbs, fields []string
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := params.Get(k)
+		switch k {
+		case "closed":
+			if v == "true" {
+				verbs = append(verbs, "archive")
+			} else {
+				verbs = append(verbs, "unarchive")
+			}
+		case "idList":
+			verbs = append(verbs, "move")
+		case "idBoard":
+		default:
+			if old, ok := current[k]; ok && fmt.Sprint(old) == v {
+				continue
+			}
+			fields = append(fields, k)
+		}
+	}
+	s := "Update " + subject
+	if len(verbs) > 0 {
+		s = strings.ToUpper(verbs[0][:1]) + strings.Join(verbs, " and ")[1:] + " " + subject
+	}
+	if id := params.Get("idList"); id != "" {
+		s += " to list " + c.dryName("lists", id)
+		if b := params.Get("idBoard"); b != "" {
+			s += " on board " + c.dryName("boards", b)
+		}
+	}
+	if len(fields) > 0 {
+		sep := ": "
+		if len(verbs) > 0 {
+			sep = "; set "
+		}
+		s += sep + strings.Join(fields, ", ")
+	}
+	return s
+}
+
+// redact copies params, hiding credentials and secrets.
+func (c *Client) redact(params url.Values) url.Values {
+	if len(params) == 0 {
+		return nil
+	}
+	out := url.Values{}
+	for k, vs := range params {
+		for _, v := range vs {
+			out.Add(k, c.redactValue(k, v))
+		}
+	}
+	return out
+}
+
+const redacted = "REDACTED"
+
+func isSecretName(name string) bool {
+	n := strings.ToLower(name)
+	for _, s := range []string{"token", "key", "secret", "password"} {
+		if strings.Contains(n, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactValue hides the value of secret parameters, the client's own
+// credentials, and secret query parameters of URLs.
+func (c *Client) redactValue(name, value string) string {
+	if isSecretName(name) {
+		return redacted
+	}
+	for _, cred := range []string{c.apiKey, c.apiToken} {
+		if len(cred) >= 8 {
+			value = strings.ReplaceAll(value, cred, redacted)
+		}
+	}
+	if u, err := url.Parse(value); err == nil && u.Host != "" && u.RawQuery != "" {
+		q := u.Query()
+		changed := false
+		for k := range q {
+			if isSecretName(k) {
+				q.Set(k, redacted)
+				changed = true
+			}
+		}
+		if changed {
+			u.RawQuery = q.Encode()
+			value = u.String()
+		}
+	}
+	return value
+}
+
+// dryBatch answers a batch that includes objects created during the dry
+// run, sending the other requests to the API; ok is false when the batch
+// has none.
+func (c *Client) dryBatch(paths []string) (results []json.RawMessage, errs []error, ok bool, err error) {
+	var real []string
+	var realIdx []int
+	results = make([]json.RawMessage, len(paths))
+	errs = make([]error, len(paths))
+	for i, p := range paths {
+		path, _, _ := strings.Cut(p, "?")
+		if body, found := c.dryGet(path); found {
+			results[i] = body
+			ok = true
+			continue
+		}
+		real = append(real, p)
+		realIdx = append(realIdx, i)
+	}
+	if !ok {
+		return nil, nil, false, nil
+	}
+	if len(real) > 0 {
+		res, es, err := c.Batch(real)
+		if err != nil {
+			return nil, nil, true, err
+		}
+		for j, i := range realIdx {
+			results[i], errs[i] = res[j], es[j]
+		}
+	}
+	return results, errs, true, nil
+}
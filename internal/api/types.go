@@ -75,6 +75,11 @@ type Card struct {
 	Subscribed      bool     `json:"subscribed"`
 	DateLastActivity string  `json:"dateLastActivity"`
 	Badges          CardBadges `json:"badges"`
+	Members         []Member   `json:"members,omitempty"`
+	Actions         []Action   `json:"actions,omitempty"`
+	CustomFieldItems []CustomFieldItem `json:"customFieldItems,omitempty"`
+	Checklists       []Checklist       `json:"checklists,omitempty"`
+	Attachments      []Attachment      `json:"attachments,omitempty"`
 }
 
 // CardBadges holds summary counts for a card.
@@ -96,6 +101,48 @@ type Label struct {
 	IDBoard string `json:"idBoard"`
 	Name    string `json:"name"`
 	Color   string `json:"color"`
+	Uses    int    `json:"uses,omitempty"`
+}
+
+// CustomField is a custom field defined on a board (Custom Fields power-up).
+type CustomField struct {
+	ID        string              `json:"id"`
+	IDModel   string              `json:"idModel"`
+	ModelType string              `json:"modelType"`
+	Name      string              `json:"name"`
+	Type      string              `json:"type"` // text, number, date, checkbox, or list
+	Pos       float64             `json:"pos"`
+	Options   []CustomFieldOption `json:"options,omitempty"`
+	Display   struct {
+		CardFront bool `json:"cardFront"`
+	} `json:"display"`
+}
+
+// CustomFieldOption is one choice of a list custom field.
+type CustomFieldOption struct {
+	ID    string           `json:"id,omitempty"`
+	Value CustomFieldValue `json:"value"`
+	Color string           `json:"color,omitempty"`
+	Pos   float64          `json:"pos,omitempty"`
+}
+
+// CustomFieldValue holds a custom field value; only the member matching the
+// field type is set. Numbers, dates, and checkbox states are strings.
+type CustomFieldValue struct {
+	Text    string `json:"text,omitempty"`
+	Number  string `json:"number,omitempty"`
+	Date    string `json:"date,omitempty"`
+	Checked string `json:"checked,omitempty"`
+}
+
+// CustomFieldItem is the value of a custom field on a card. List fields
+// reference an option through IDValue instead of carrying a Value.
+type CustomFieldItem struct {
+	ID            string            `json:"id"`
+	IDCustomField string            `json:"idCustomField"`
+	IDModel       string            `json:"idModel"`
+	IDValue       string            `json:"idValue,omitempty"`
+	Value         *CustomFieldValue `json:"value,omitempty"`
 }
 
 // Member represents a Trello member.
@@ -103,6 +150,7 @@ type Member struct {
 	ID          string `json:"id"`
 	FullName    string `json:"fullName"`
 	Username    string `json:"username"`
+	Initials    string `json:"initials"`
 	Email       string `json:"email"`
 	Bio         string `json:"bio"`
 	AvatarURL   string `json:"avatarUrl"`
@@ -112,6 +160,16 @@ type Member struct {
 	Confirmed   bool   `json:"confirmed"`
 }
 
+// Membership is a member's role on a board.
+type Membership struct {
+	ID          string  `json:"id"`
+	IDMember    string  `json:"idMember"`
+	MemberType  string  `json:"memberType"` // normal, admin, observer
+	Unconfirmed bool    `json:"unconfirmed"`
+	Deactivated bool    `json:"deactivated"`
+	Member      *Member `json:"member,omitempty"`
+}
+
 // Organization represents a Trello workspace/organization.
 type Organization struct {
 	ID          string `json:"id"`
@@ -148,6 +206,7 @@ type Attachment struct {
 	ID       string `json:"id"`
 	Name     string `json:"name"`
 	URL      string `json:"url"`
+	FileName string `json:"fileName,omitempty"`
 	MimeType string `json:"mimeType"`
 	Bytes    int64  `json:"bytes"`
 	Date     string `json:"date"`
@@ -182,6 +241,48 @@ type ActionData struct {
 		ID   string `json:"id"`
 		Name string `json:"name"`
 	} `json:"list,omitempty"`
+	ListBefore *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"listBefore,omitempty"`
+	ListAfter *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"listAfter,omitempty"`
+	// Old holds the previous values of fields changed by an update action,
+	// e.g. {"name": "..."}, {"closed": false} or {"due": null}.
+	Old       map[string]any `json:"old,omitempty"`
+	Checklist *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"checklist,omitempty"`
+	CheckItem *struct {
+		ID    string `json:"id"`
+		Name  string `json:"name"`
+		State string `json:"state"`
+	} `json:"checkItem,omitempty"`
+	Attachment *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"attachment,omitempty"`
+	Label *struct {
+		ID    string `json:"id"`
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	} `json:"label,omitempty"`
+	Member *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"member,omitempty"`
+	BoardSource *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"boardSource,omitempty"`
+	BoardTarget *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"boardTarget,omitempty"`
 }
 
 // Webhook represents a Trello webhook subscription.
@@ -0,0 +1,432 @@
+// Package backup reads and writes board backup archives: gzipped tar files
+// with a manifest, a board export per board (including archived lists and
+// cards), and the files uploaded as attachments.
+//
+// Layout:
+//
+//	manifest.json
+//	boards/<board-id>/board.json
+//	boards/<board-id>/attachments/<card-id>/<attachment-id>/<file name>
+//
+// An incremental archive holds the full board structure but only the cards
+// changed since its base archive, which it names in its manifest.
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/the20100/trello-cli/internal/api"
+	"github.com/the20100/trello-cli/internal/snapshot"
+)
+
+// Format identifies backup archives in their manifest.
+const Format = "trello-cli-backup"
+
+// Version is the version of the archive layout written by this package.
+const Version = 1
+
+// Manifest describes an archive.
+type Manifest struct {
+	Format    string `json:"format"`
+	Version   int    `json:"version"`
+	CreatedAt string `json:"createdAt"`
+	// Base is the file name of the archive an incremental backup builds on,
+	// relative to this archive's directory; Since is when Base was created.
+	Base   string         `json:"base,omitempty"`
+	Since  string         `json:"since,omitempty"`
+	Boards []BoardSummary `json:"boards"`
+}
+
+// BoardSummary describes one board of an archive.
+type BoardSummary struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Lists       int    `json:"lists"`
+	Cards       int    `json:"cards"`
+	Actions     int    `json:"actions"`
+	Attachments int    `json:"attachments"`
+	Bytes       int64  `json:"bytes"`
+}
+
+// Incremental reports whether the archive builds on a base archive.
+func (m *Manifest) Incremental() bool {
+	return m.Base != ""
+}
+
+func boardPath(boardID string) string {
+	return path.Join("boards", boardID, "board.json")
+}
+
+func attachmentPath(boardID, cardID, attachmentID, fileName string) string {
+	return path.Join("boards", boardID, "attachments", cardID, attachmentID, fileName)
+}
+
+// ---- Writing ----
+
+// Writer writes an archive. Boards and attachments are added as they are
+// fetched; the manifest is written last, by Close.
+type Writer struct {
+	f        *os.File
+	gz       *gzip.Writer
+	tw       *tar.Writer
+	manifest Manifest
+	now      time.Time
+}
+
+// Create starts an archive at path. base and since are empty for a full backup.
+func Create(path, base, since string) (*Writer, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	gz := gzip.NewWriter(f)
+	now := time.Now().UTC()
+	return &Writer{
+		f:   f,
+		gz:  gz,
+		tw:  tar.NewWriter(gz),
+		now: now,
+		manifest: Manifest{
+			Format:    Format,
+			Version:   Version,
+			CreatedAt: now.Format(time.RFC3339),
+			Base:      base,
+			Since:     since,
+		},
+	}, nil
+}
+
+// CreatedAt returns the time recorded as the archive's creation.
+func (w *Writer) CreatedAt() string {
+	return w.manifest.CreatedAt
+}
+
+// AddBoard adds a board export.
+func (w *Writer) AddBoard(b *snapshot.Board) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := w.add(boardPath(b.ID), int64(len(data)), bytes.NewReader(data)); err != nil {
+		return err
+	}
+	s := w.summary(b.ID)
+	s.Name = b.Name
+	s.Lists, s.Cards, s.Actions = len(b.Lists), len(b.Cards), len(b.Actions)
+	return nil
+}
+
+// AddAttachment adds the content of an uploaded attachment, of size bytes.
+func (w *Writer) AddAttachment(boardID, cardID, attachmentID, fileName string, size int64, r io.Reader) error {
+	if err := w.add(attachmentPath(boardID, cardID, attachmentID, cleanName(fileName)), size, r); err != nil {
+		return err
+	}
+	s := w.summary(boardID)
+	s.Attachments++
+	s.Bytes += size
+	return nil
+}
+
+func (w *Writer) summary(boardID string) *BoardSummary {
+	for i := range w.manifest.Boards {
+		if w.manifest.Boards[i].ID == boardID {
+			return &w.manifest.Boards[i]
+		}
+	}
+	w.manifest.Boards = append(w.manifest.Boards, BoardSummary{ID: boardID})
+	return &w.manifest.Boards[len(w.manifest.Boards)-1]
+}
+
+func (w *Writer) add(name string, size int64, r io.Reader) error {
+	hdr := &tar.Header{Name: name, Mode: 0644, Size: size, ModTime: w.now, Typeflag: tar.TypeReg}
+	if err := w.tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := io.Copy(w.tw, r)
+	return err
+}
+
+// Manifest returns the manifest as it stands.
+func (w *Writer) Manifest() Manifest {
+	return w.manifest
+}
+
+// Close writes the manifest and finishes the archive.
+func (w *Writer) Close() error {
+	data, err := json.MarshalIndent(w.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = w.add("manifest.json", int64(len(data)), bytes.NewReader(data))
+	return errors.Join(err, w.tw.Close(), w.gz.Close(), w.f.Close())
+}
+
+// Abort discards a partially written archive.
+func (w *Writer) Abort() {
+	w.tw.Close()
+	w.gz.Close()
+	w.f.Close()
+	os.Remove(w.f.Name())
+}
+
+// cleanName makes a file name safe to use as one path element.
+func cleanName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == "/" || name == ".." || name == "" {
+		return "file"
+	}
+	return name
+}
+
+// ---- Reading ----
+
+// Archive is an opened archive. Attachments are extracted to a temporary
+// directory, removed by Close.
+type Archive struct {
+	Path     string
+	Manifest Manifest
+	Boards   map[string]*snapshot.Board
+	// Base is the opened base archive of an incremental backup.
+	Base *Archive
+
+	dir   string
+	files map[string]string // archive path → extracted file
+}
+
+// Open reads an archive and, for an incremental backup, its chain of base
+// archives, found relative to the archive's directory.
+func Open(p string) (*Archive, error) {
+	a, err := openOne(p)
+	if err != nil {
+		return nil, err
+	}
+	if a.Manifest.Base != "" {
+		basePath := filepath.Join(filepath.Dir(p), a.Manifest.Base)
+		if a.Base, err = Open(basePath); err != nil {
+			a.Close()
+			return nil, fmt.Errorf("opening base backup of %s: %w", filepath.Base(p), err)
+		}
+	}
+	return a, nil
+}
+
+// ReadManifest reads only the manifest of an archive.
+func ReadManifest(p string) (*Manifest, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a backup archive: %w", p, err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s is not a trello backup archive", p)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", p, err)
+		}
+		if path.Clean(hdr.Name) != "manifest.json" {
+			continue
+		}
+		var m Manifest
+		if err := json.NewDecoder(tr).Decode(&m); err != nil {
+			return nil, fmt.Errorf("reading manifest of %s: %w", p, err)
+		}
+		if m.Format != Format {
+			return nil, fmt.Errorf("%s is not a trello backup archive", p)
+		}
+		return &m, nil
+	}
+}
+
+func openOne(p string) (*Archive, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a backup archive: %w", p, err)
+	}
+	dir, err := os.MkdirTemp("", "trello-restore-*")
+	if err != nil {
+		return nil, err
+	}
+	a := &Archive{Path: p, Boards: map[string]*snapshot.Board{}, dir: dir, files: map[string]string{}}
+
+	tr := tar.NewReader(gz)
+	haveManifest := false
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			a.Close()
+			return nil, fmt.Errorf("reading %s: %w", p, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(hdr.Name)
+		switch {
+		case name == "manifest.json":
+			if err := json.NewDecoder(tr).Decode(&a.Manifest); err != nil {
+				a.Close()
+				return nil, fmt.Errorf("reading manifest of %s: %w", p, err)
+			}
+			haveManifest = true
+		case strings.HasPrefix(name, "boards/") && strings.HasSuffix(name, "/board.json"):
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				a.Close()
+				return nil, err
+			}
+			b, err := snapshot.Parse(data)
+			if err != nil {
+				a.Close()
+				return nil, fmt.Errorf("%s in %s: %w", name, p, err)
+			}
+			a.Boards[b.ID] = b
+		case strings.HasPrefix(name, "boards/") && strings.Contains(name, "/attachments/"):
+			if err := a.extract(name, tr); err != nil {
+				a.Close()
+				return nil, err
+			}
+		}
+	}
+
+	switch {
+	case !haveManifest || a.Manifest.Format != Format:
+		a.Close()
+		return nil, fmt.Errorf("%s is not a trello backup archive", p)
+	case a.Manifest.Version > Version:
+		a.Close()
+		return nil, fmt.Errorf("%s has format version %d; this trello supports up to %d", p, a.Manifest.Version, Version)
+	}
+	return a, nil
+}
+
+func (a *Archive) extract(name string, r io.Reader) error {
+	target := filepath.Join(a.dir, fmt.Sprintf("%d", len(a.files)))
+	f, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	a.files[name] = target
+	return nil
+}
+
+// Close removes the extracted files of the archive and its bases.
+func (a *Archive) Close() error {
+	if a.Base != nil {
+		a.Base.Close()
+	}
+	return os.RemoveAll(a.dir)
+}
+
+// Board returns a board of the archive, merged with its state in the base
+// archives for an incremental backup.
+func (a *Archive) Board(id string) (*snapshot.Board, bool) {
+	b, ok := a.Boards[id]
+	if !ok {
+		return nil, false
+	}
+	if a.Base == nil {
+		return b, true
+	}
+	base, ok := a.Base.Board(id)
+	if !ok {
+		return b, true
+	}
+	return Merge(base, b), true
+}
+
+// BoardIDs returns the IDs of the boards in the archive, by name.
+func (a *Archive) BoardIDs() []string {
+	ids := make([]string, 0, len(a.Boards))
+	for id := range a.Boards {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return a.Boards[ids[i]].Name < a.Boards[ids[j]].Name })
+	return ids
+}
+
+// AttachmentFile returns the extracted file of an uploaded attachment,
+// looking through the base archives too.
+func (a *Archive) AttachmentFile(boardID, cardID, attachmentID, fileName string) (string, bool) {
+	if f, ok := a.files[attachmentPath(boardID, cardID, attachmentID, cleanName(fileName))]; ok {
+		return f, true
+	}
+	if a.Base != nil {
+		return a.Base.AttachmentFile(boardID, cardID, attachmentID, fileName)
+	}
+	return "", false
+}
+
+// Merge applies an incremental board export to its base: structure comes
+// from inc, cards and checklists of inc replace those of base, cards deleted
+// or moved off the board according to inc's actions are dropped, and actions
+// are combined.
+func Merge(base, inc *snapshot.Board) *snapshot.Board {
+	out := *inc
+	deleted := map[string]bool{}
+	for _, a := range inc.Actions {
+		if (a.Type == "deleteCard" || a.Type == "moveCardFromBoard") && a.Data.Card != nil {
+			deleted[a.Data.Card.ID] = true
+		}
+	}
+	changed := map[string]bool{}
+	for _, c := range inc.Cards {
+		changed[c.ID] = true
+	}
+
+	out.Cards = append([]api.Card(nil), inc.Cards...)
+	for _, c := range base.Cards {
+		if !changed[c.ID] && !deleted[c.ID] {
+			out.Cards = append(out.Cards, c)
+		}
+	}
+	out.Checklists = append([]api.Checklist(nil), inc.Checklists...)
+	for _, cl := range base.Checklists {
+		if !changed[cl.IDCard] && !deleted[cl.IDCard] {
+			out.Checklists = append(out.Checklists, cl)
+		}
+	}
+	seen := map[string]bool{}
+	out.Actions = nil
+	for _, a := range append(append([]api.Action(nil), inc.Actions...), base.Actions...) {
+		if !seen[a.ID] {
+			seen[a.ID] = true
+			out.Actions = append(out.Actions, a)
+		}
+	}
+	sort.SliceStable(out.Actions, func(i, j int) bool { return out.Actions[i].Date > out.Actions[j].Date })
+	out.Sort()
+	return &out
+}
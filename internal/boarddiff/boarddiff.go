@@ -0,0 +1,452 @@
+// Package boarddiff compares two board exports: their lists, labels, and
+// cards with their fields and checklist state.
+package boarddiff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/the20100/trello-cli/internal/api"
+	"github.com/the20100/trello-cli/internal/importer"
+	"github.com/the20100/trello-cli/internal/snapshot"
+)
+
+// Change kinds.
+const (
+	Added   = "added"
+	Removed = "removed"
+	Moved   = "moved"
+	Changed = "changed"
+)
+
+// Result is the difference from board A to board B.
+type Result struct {
+	Lists  []Entry `json:"lists"`
+	Labels []Entry `json:"labels"`
+	Cards  []Entry `json:"cards"`
+}
+
+// Entry is a list, label, or card that differs. Moved cards may carry
+// field changes too.
+type Entry struct {
+	Change string `json:"change"`
+	Name   string `json:"name"`
+	// List is the card's list on the side it is on (B unless removed);
+	// FromList is its list on A when moved.
+	List     string  `json:"list,omitempty"`
+	FromList string  `json:"fromList,omitempty"`
+	IDA      string  `json:"idA,omitempty"`
+	IDB      string  `json:"idB,omitempty"`
+	Fields   []Field `json:"fields,omitempty"`
+}
+
+// Field is one changed field; Old or New is empty when it was unset.
+type Field struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// Empty reports whether the boards are the same.
+func (r *Result) Empty() bool {
+	return len(r.Lists) == 0 && len(r.Labels) == 0 && len(r.Cards) == 0
+}
+
+// Options control what is compared.
+type Options struct {
+	// Archived includes archived lists and cards; otherwise they are
+	// treated as absent.
+	Archived bool
+}
+
+// Compare returns the difference from a to b. Lists and labels are matched
+// by ID, then by name (labels without a name by color); cards by ID, then
+// by external-ID marker, then by name within the same list, then by name.
+func Compare(a, b *snapshot.Board, opts Options) *Result {
+	r := &Result{}
+	listsA, listsB := openLists(a, opts), openLists(b, opts)
+	listMatch := matchLists(listsA, listsB)
+	r.Lists = compareLists(listsA, listsB, listMatch)
+	labelMatch := map[string]string{}
+	r.Labels = compareLabels(a.Labels, b.Labels, labelMatch)
+	m := matcher{a: a, b: b, lists: listMatch, labels: labelMatch}
+	r.Cards = m.compareCards(openCards(a, listsA, opts), openCards(b, listsB, opts))
+	return r
+}
+
+func openLists(b *snapshot.Board, opts Options) []api.TrelloList {
+	var out []api.TrelloList
+	for _, l := range b.Lists {
+		if opts.Archived || !l.Closed {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+func openCards(b *snapshot.Board, lists []api.TrelloList, opts Options) []api.Card {
+	in := map[string]bool{}
+	for _, l := range lists {
+		in[l.ID] = true
+	}
+	var out []api.Card
+	for _, c := range b.Cards {
+		if in[c.IDList] && (opts.Archived || !c.Closed) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// matchLists maps list IDs of A to the matching list IDs of B.
+func matchLists(a, b []api.TrelloList) map[string]string {
+	match := map[string]string{}
+	used := map[string]bool{}
+	for _, la := range a {
+		for _, lb := range b {
+			if la.ID == lb.ID {
+				match[la.ID], used[lb.ID] = lb.ID, true
+			}
+		}
+	}
+	for _, la := range a {
+		if _, ok := match[la.ID]; ok {
+			continue
+		}
+		for _, lb := range b {
+			if !used[lb.ID] && strings.EqualFold(la.Name, lb.Name) {
+				match[la.ID], used[lb.ID] = lb.ID, true
+				break
+			}
+		}
+	}
+	return match
+}
+
+func compareLists(a, b []api.TrelloList, match map[string]string) []Entry {
+	var out []Entry
+	byID := map[string]api.TrelloList{}
+	for _, l := range b {
+		byID[l.ID] = l
+	}
+
+	// Positions among the lists on both sides, to report reordering.
+	posA, posB := map[string]int{}, map[string]int{}
+	for _, l := range a {
+		if _, ok := match[l.ID]; ok {
+			posA[l.ID] = len(posA) + 1
+		}
+	}
+	matched := map[string]bool{}
+	for _, id := range match {
+		matched[id] = true
+	}
+	for _, l := range b {
+		if matched[l.ID] {
+			posB[l.ID] = len(posB) + 1
+		}
+	}
+
+	for _, la := range a {
+		idB, ok := match[la.ID]
+		if !ok {
+			out = append(out, Entry{Change: Removed, Name: la.Name, IDA: la.ID})
+			continue
+		}
+		lb := byID[idB]
+		var fields []Field
+		if la.Name != lb.Name {
+			fields = append(fields, Field{"name", la.Name, lb.Name})
+		}
+		if la.Closed != lb.Closed {
+			fields = append(fields, Field{"archived", yesNo(la.Closed), yesNo(lb.Closed)})
+		}
+		if posA[la.ID] != posB[idB] {
+			fields = append(fields, Field{"position", fmt.Sprint(posA[la.ID]), fmt.Sprint(posB[idB])})
+		}
+		if len(fields) > 0 {
+			out = append(out, Entry{Change: Changed, Name: lb.Name, IDA: la.ID, IDB: idB, Fields: fields})
+		}
+	}
+	for _, lb := range b {
+		if !matched[lb.ID] {
+			out = append(out, Entry{Change: Added, Name: lb.Name, IDB: lb.ID})
+		}
+	}
+	return out
+}
+
+// compareLabels compares labels, recording matches from A's label IDs to
+// B's in match.
+func compareLabels(a, b []api.Label, match map[string]string) []Entry {
+	var out []Entry
+	used := map[string]bool{}
+	find := func(la api.Label) (api.Label, bool) {
+		for _, lb := range b {
+			if !used[lb.ID] && lb.ID == la.ID {
+				return lb, true
+			}
+		}
+		for _, lb := range b {
+			if used[lb.ID] {
+				continue
+			}
+			if la.Name != "" && strings.EqualFold(la.Name, lb.Name) || la.Name == "" && lb.Name == "" && la.Color == lb.Color {
+				return lb, true
+			}
+		}
+		return api.Label{}, false
+	}
+	for _, la := range a {
+		lb, ok := find(la)
+		if !ok {
+			out = append(out, Entry{Change: Removed, Name: labelName(la), IDA: la.ID})
+			continue
+		}
+		used[lb.ID] = true
+		match[la.ID] = lb.ID
+		var fields []Field
+		if la.Name != lb.Name {
+			fields = append(fields, Field{"name", la.Name, lb.Name})
+		}
+		if la.Color != lb.Color {
+			fields = append(fields, Field{"color", la.Color, lb.Color})
+		}
+		if len(fields) > 0 {
+			out = append(out, Entry{Change: Changed, Name: labelName(lb), IDA: la.ID, IDB: lb.ID, Fields: fields})
+		}
+	}
+	for _, lb := range b {
+		if !used[lb.ID] {
+			out = append(out, Entry{Change: Added, Name: labelName(lb), IDB: lb.ID})
+		}
+	}
+	return out
+}
+
+func labelName(l api.Label) string {
+	if l.Name != "" {
+		return l.Name
+	}
+	return "(" + l.Color + ")"
+}
+
+// matcher holds the boards being compared and the list and label matches
+// from A to B.
+type matcher struct {
+	a, b   *snapshot.Board
+	lists  map[string]string
+	labels map[string]string
+}
+
+func (m *matcher) compareCards(cardsA, cardsB []api.Card) []Entry {
+	a, b := m.a, m.b
+	used := map[string]bool{}
+	pick := func(ok func(api.Card) bool) (api.Card, bool) {
+		for _, cb := range cardsB {
+			if !used[cb.ID] && ok(cb) {
+				used[cb.ID] = true
+				return cb, true
+			}
+		}
+		return api.Card{}, false
+	}
+
+	// Match in passes, strongest key first, so a weak match cannot take a
+	// card that a stronger key would pair with another.
+	match := map[string]api.Card{}
+	passes := []func(ca, cb api.Card) bool{
+		func(ca, cb api.Card) bool { return ca.ID == cb.ID },
+		func(ca, cb api.Card) bool {
+			id := importer.ExternalID(ca.Desc)
+			return id != "" && id == importer.ExternalID(cb.Desc)
+		},
+		func(ca, cb api.Card) bool {
+			return strings.EqualFold(ca.Name, cb.Name) && m.lists[ca.IDList] == cb.IDList
+		},
+		func(ca, cb api.Card) bool { return strings.EqualFold(ca.Name, cb.Name) },
+	}
+	for _, same := range passes {
+		for _, ca := range cardsA {
+			if _, ok := match[ca.ID]; ok {
+				continue
+			}
+			if cb, ok := pick(func(cb api.Card) bool { return same(ca, cb) }); ok {
+				match[ca.ID] = cb
+			}
+		}
+	}
+
+	var out []Entry
+	for _, ca := range cardsA {
+		cb, ok := match[ca.ID]
+		if !ok {
+			out = append(out, Entry{Change: Removed, Name: ca.Name, List: listName(a, ca.IDList), IDA: ca.ID})
+			continue
+		}
+		e := Entry{Name: cb.Name, List: listName(b, cb.IDList), IDA: ca.ID, IDB: cb.ID, Fields: m.cardFields(ca, cb)}
+		if m.lists[ca.IDList] != cb.IDList {
+			e.Change, e.FromList = Moved, listName(a, ca.IDList)
+		} else if len(e.Fields) > 0 {
+			e.Change = Changed
+		} else {
+			continue
+		}
+		out = append(out, e)
+	}
+	for _, cb := range cardsB {
+		if !used[cb.ID] {
+			out = append(out, Entry{Change: Added, Name: cb.Name, List: listName(b, cb.IDList), IDB: cb.ID})
+		}
+	}
+	return out
+}
+
+func listName(b *snapshot.Board, id string) string {
+	if l, ok := b.List(id); ok {
+		return l.Name
+	}
+	return id
+}
+
+// cardFields returns the field changes between two matched cards.
+func (m *matcher) cardFields(ca, cb api.Card) []Field {
+	a, b := m.a, m.b
+	var fields []Field
+	add := func(name, old, new string) {
+		if old != new {
+			fields = append(fields, Field{name, old, new})
+		}
+	}
+	add("name", ca.Name, cb.Name)
+	add("desc", importer.StripMarker(ca.Desc), importer.StripMarker(cb.Desc))
+	add("due", normalizeDate(ca.Due), normalizeDate(cb.Due))
+	if ca.Due != nil && cb.Due != nil {
+		add("dueComplete", yesNo(ca.DueComplete), yesNo(cb.DueComplete))
+	}
+	add("start", normalizeDate(ca.Start), normalizeDate(cb.Start))
+	add("labels", strings.Join(m.cardLabels(ca, true), ", "), strings.Join(m.cardLabels(cb, false), ", "))
+	add("members", strings.Join(cardMembers(a, ca), ", "), strings.Join(cardMembers(b, cb), ", "))
+	add("archived", yesNo(ca.Closed), yesNo(cb.Closed))
+	return append(fields, checklistFields(a.CardChecklists(ca.ID), b.CardChecklists(cb.ID))...)
+}
+
+// checklistFields compares checklists by name and their items by name.
+func checklistFields(a, b []api.Checklist) []Field {
+	var fields []Field
+	find := func(lists []api.Checklist, name string) (api.Checklist, bool) {
+		for _, cl := range lists {
+			if strings.EqualFold(cl.Name, name) {
+				return cl, true
+			}
+		}
+		return api.Checklist{}, false
+	}
+	for _, cla := range a {
+		clb, ok := find(b, cla.Name)
+		if !ok {
+			fields = append(fields, Field{"checklist " + quote(cla.Name), checklistSummary(cla), ""})
+			continue
+		}
+		used := map[string]bool{}
+		for _, ia := range cla.CheckItems {
+			field := "checklist " + quote(cla.Name) + " item " + quote(ia.Name)
+			var ib *api.CheckItem
+			for k := range clb.CheckItems {
+				if it := &clb.CheckItems[k]; !used[it.ID] && it.Name == ia.Name {
+					ib = it
+					break
+				}
+			}
+			switch {
+			case ib == nil:
+				fields = append(fields, Field{field, ia.State, ""})
+			case ib.State != ia.State:
+				fields = append(fields, Field{field, ia.State, ib.State})
+			}
+			if ib != nil {
+				used[ib.ID] = true
+			}
+		}
+		for _, ib := range clb.CheckItems {
+			if !used[ib.ID] {
+				fields = append(fields, Field{"checklist " + quote(cla.Name) + " item " + quote(ib.Name), "", ib.State})
+			}
+		}
+	}
+	for _, clb := range b {
+		if _, ok := find(a, clb.Name); !ok {
+			fields = append(fields, Field{"checklist " + quote(clb.Name), "", checklistSummary(clb)})
+		}
+	}
+	return fields
+}
+
+func checklistSummary(cl api.Checklist) string {
+	done := 0
+	for _, it := range cl.CheckItems {
+		if it.State == "complete" {
+			done++
+		}
+	}
+	return fmt.Sprintf("%d/%d complete", done, len(cl.CheckItems))
+}
+
+// cardLabels returns the label names of a card of A (onA) or B. Labels of
+// A matched to a label of B go by B's name, so a renamed label is reported
+// once rather than on every card.
+func (m *matcher) cardLabels(c api.Card, onA bool) []string {
+	var names []string
+	for _, id := range c.IDLabels {
+		board := m.b
+		if onA {
+			if matched, ok := m.labels[id]; ok {
+				id = matched
+			} else {
+				board = m.a
+			}
+		}
+		if l, ok := board.Label(id); ok {
+			names = append(names, labelName(l))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func cardMembers(b *snapshot.Board, c api.Card) []string {
+	var names []string
+	for _, id := range c.IDMembers {
+		if m, ok := b.Member(id); ok {
+			names = append(names, "@"+m.Username)
+		} else {
+			names = append(names, id)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// normalizeDate formats a date in UTC so equal instants compare equal.
+func normalizeDate(s *string) string {
+	if s == nil || *s == "" {
+		return ""
+	}
+	if t, err := time.Parse(time.RFC3339, *s); err == nil {
+		return t.UTC().Format(time.RFC3339)
+	}
+	return *s
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func quote(s string) string {
+	return "'" + s + "'"
+}
@@ -0,0 +1,281 @@
+// Package boardspec defines the YAML document that describes the structure
+// of a board — its lists, labels, custom fields, members, prefs, and cards —
+// as used by board templates and by "trello plan" / "trello apply", and
+// computes the changes needed to bring a live board in line with a spec.
+package boardspec
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec describes a board.
+type Spec struct {
+	Name string `yaml:"name"`
+	// Board pins the spec to an existing board (ID, short link, or URL);
+	// otherwise the board is found by name. Used by apply only.
+	Board        string        `yaml:"board,omitempty"`
+	Description  string        `yaml:"description,omitempty"`
+	Prefs        *Prefs        `yaml:"prefs,omitempty"`
+	Members      []Member      `yaml:"members,omitempty"`
+	Labels       []Label       `yaml:"labels,omitempty"`
+	CustomFields []CustomField `yaml:"customFields,omitempty"`
+	Lists        []List        `yaml:"lists"`
+}
+
+// Prefs are board preferences. Unset fields are left as they are.
+type Prefs struct {
+	PermissionLevel string `yaml:"permissionLevel,omitempty"` // private, org, public
+	Voting          string `yaml:"voting,omitempty"`          // disabled, members, observers, org, public
+	Comments        string `yaml:"comments,omitempty"`        // disabled, members, observers, org, public
+	Background      string `yaml:"background,omitempty"`      // color name or background ID
+	CardAging       string `yaml:"cardAging,omitempty"`       // regular, pirate
+	SelfJoin        *bool  `yaml:"selfJoin,omitempty"`
+	CardCovers      *bool  `yaml:"cardCovers,omitempty"`
+}
+
+// Member is a board member, by username.
+type Member struct {
+	Username string `yaml:"username"`
+	Type     string `yaml:"type,omitempty"` // normal (default), admin, observer
+}
+
+// Label is a board label. Unnamed labels are identified by color. ID pins
+// the label to an existing one, so that it can be renamed.
+type Label struct {
+	ID    string `yaml:"id,omitempty"`
+	Name  string `yaml:"name,omitempty"`
+	Color string `yaml:"color,omitempty"`
+}
+
+// CustomField is a custom field definition.
+type CustomField struct {
+	Name      string   `yaml:"name"`
+	Type      string   `yaml:"type"`
+	Options   []string `yaml:"options,omitempty,flow"`
+	CardFront bool     `yaml:"cardFront,omitempty"`
+}
+
+// List is a list, in board order, with its cards. ID pins the list to an
+// existing one, so that it can be renamed.
+type List struct {
+	ID    string `yaml:"id,omitempty"`
+	Name  string `yaml:"name"`
+	Cards []Card `yaml:"cards,omitempty"`
+}
+
+// Card is a seed card of a template, or a card pinned to a list by apply.
+// Labels are referenced by name (or color), or as "name (color)" when
+// several labels share the name.
+type Card struct {
+	Name       string      `yaml:"name"`
+	Desc       string      `yaml:"desc,omitempty"`
+	Labels     []string    `yaml:"labels,omitempty,flow"`
+	Checklists []Checklist `yaml:"checklists,omitempty"`
+}
+
+// Checklist is a checklist of a seed card.
+type Checklist struct {
+	Name  string   `yaml:"name"`
+	Items []string `yaml:"items,omitempty"`
+}
+
+// Load reads and validates a spec from a YAML file.
+func Load(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse decodes and validates a spec. Unknown keys are rejected so that
+// typos do not silently drop parts of the board.
+func Parse(data []byte) (*Spec, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var s Spec
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("parsing board spec: %w", err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Save writes the spec as YAML.
+func (s *Spec) Save(path string) error {
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(s); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b.Bytes(), 0644)
+}
+
+// Validate checks that names are present and unique and that every label a
+// card references is defined.
+func (s *Spec) Validate() error {
+	defined := map[string]bool{}
+	for i, l := range s.Labels {
+		if l.Key() == "" {
+			return fmt.Errorf("label %d: name or color is required", i+1)
+		}
+		if defined[l.Ref()] {
+			return fmt.Errorf("label %q is defined twice", l.String())
+		}
+		defined[l.Ref()] = true
+	}
+	labels := s.LabelRefs()
+
+	if s.Prefs != nil {
+		if err := s.Prefs.validate(); err != nil {
+			return err
+		}
+	}
+
+	members := map[string]bool{}
+	for i, m := range s.Members {
+		name := strings.ToLower(strings.TrimPrefix(m.Username, "@"))
+		if name == "" {
+			return fmt.Errorf("member %d: username is required", i+1)
+		}
+		switch m.Type {
+		case "", "normal", "admin", "observer":
+		default:
+			return fmt.Errorf("member %q: unknown type %q (use normal, admin, or observer)", m.Username, m.Type)
+		}
+		if members[name] {
+			return fmt.Errorf("member %q is listed twice", m.Username)
+		}
+		members[name] = true
+	}
+
+	fields := map[string]bool{}
+	for i, f := range s.CustomFields {
+		if f.Name == "" {
+			return fmt.Errorf("custom field %d: name is required", i+1)
+		}
+		switch f.Type {
+		case "text", "number", "date", "checkbox":
+			if len(f.Options) > 0 {
+				return fmt.Errorf("custom field %q: options only apply to list fields", f.Name)
+			}
+		case "list":
+			if len(f.Options) == 0 {
+				return fmt.Errorf("custom field %q: list fields need options", f.Name)
+			}
+		default:
+			return fmt.Errorf("custom field %q: unknown type %q", f.Name, f.Type)
+		}
+		if fields[strings.ToLower(f.Name)] {
+			return fmt.Errorf("custom field %q is defined twice", f.Name)
+		}
+		fields[strings.ToLower(f.Name)] = true
+	}
+
+	lists := map[string]bool{}
+	for i, l := range s.Lists {
+		if l.Name == "" {
+			return fmt.Errorf("list %d: name is required", i+1)
+		}
+		if lists[strings.ToLower(l.Name)] {
+			return fmt.Errorf("list %q is defined twice", l.Name)
+		}
+		lists[strings.ToLower(l.Name)] = true
+		for j, c := range l.Cards {
+			if c.Name == "" {
+				return fmt.Errorf("list %q, card %d: name is required", l.Name, j+1)
+			}
+			for _, ref := range c.Labels {
+				if _, ok := labels[strings.ToLower(ref)]; ok {
+					continue
+				}
+				for _, l := range s.Labels {
+					if l.Key() == strings.ToLower(ref) {
+						return fmt.Errorf("card %q uses ambiguous label %q: add its color, as in %q", c.Name, ref, l.String())
+					}
+				}
+				return fmt.Errorf("card %q uses undefined label %q", c.Name, ref)
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Prefs) validate() error {
+	for _, f := range []struct {
+		name, value string
+		allowed     []string
+	}{
+		{"permissionLevel", p.PermissionLevel, []string{"private", "org", "public"}},
+		{"voting", p.Voting, []string{"disabled", "members", "observers", "org", "public"}},
+		{"comments", p.Comments, []string{"disabled", "members", "observers", "org", "public"}},
+		{"cardAging", p.CardAging, []string{"regular", "pirate"}},
+	} {
+		if f.value == "" {
+			continue
+		}
+		ok := false
+		for _, a := range f.allowed {
+			ok = ok || f.value == a
+		}
+		if !ok {
+			return fmt.Errorf("prefs.%s: invalid value %q (use %s)", f.name, f.value, strings.Join(f.allowed, ", "))
+		}
+	}
+	return nil
+}
+
+// MemberType returns the membership type, defaulting to normal.
+func (m Member) MemberType() string {
+	if m.Type == "" {
+		return "normal"
+	}
+	return m.Type
+}
+
+// Key returns the case-insensitive name by which cards reference the label:
+// its name, or its color when unnamed.
+func (l Label) Key() string {
+	if l.Name != "" {
+		return strings.ToLower(l.Name)
+	}
+	return strings.ToLower(l.Color)
+}
+
+// Ref returns the case-insensitive reference that names the label alone:
+// "name (color)" for a named, colored label, otherwise its key.
+func (l Label) Ref() string {
+	return strings.ToLower(l.String())
+}
+
+// String returns the label as "name (color)", or its name or color alone.
+func (l Label) String() string {
+	return labelDisplay(l.Name, l.Color)
+}
+
+// LabelRefs maps every reference a card may use to the index of the label
+// it names: each label's Ref, and its key when no other label shares it.
+func (s *Spec) LabelRefs() map[string]int {
+	keys := map[string]int{}
+	for _, l := range s.Labels {
+		keys[l.Key()]++
+	}
+	refs := map[string]int{}
+	for i, l := range s.Labels {
+		refs[l.Ref()] = i
+	}
+	for i, l := range s.Labels {
+		if _, ok := refs[l.Key()]; !ok && keys[l.Key()] == 1 {
+			refs[l.Key()] = i
+		}
+	}
+	return refs
+}
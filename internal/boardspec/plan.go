@@ -0,0 +1,510 @@
+package boardspec
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/the20100/trello-cli/internal/api"
+)
+
+// Action is what an Op does to a board object.
+type Action string
+
+const (
+	Create Action = "create"
+	Update Action = "update"
+	Delete Action = "delete"
+)
+
+// Kinds of board objects an Op applies to.
+const (
+	KindBoard  = "board"
+	KindMember = "member"
+	KindLabel  = "label"
+	KindField  = "field"
+	KindOption = "option"
+	KindList   = "list"
+	KindCard   = "card"
+)
+
+// Change is one attribute an update changes, formatted for display.
+type Change struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// Op is one step of a plan. ID is the live object the op updates or
+// deletes. The fields hidden from JSON carry what the executor needs: the
+// spec element to create or update to, and API parameters.
+type Op struct {
+	Action  Action   `json:"action"`
+	Kind    string   `json:"kind"`
+	Name    string   `json:"name"`
+	ID      string   `json:"id,omitempty"`
+	Note    string   `json:"note,omitempty"`
+	Changes []Change `json:"changes,omitempty"`
+
+	// Parent is the custom field an option belongs to.
+	Parent string `json:"parent,omitempty"`
+	// OptionID is the live option a delete removes.
+	OptionID string `json:"-"`
+
+	Member *Member      `json:"-"`
+	Label  *Label       `json:"-"`
+	Field  *CustomField `json:"-"`
+	Card   *Card        `json:"-"`
+	// Params are the board fields an update sets.
+	Params map[string]string `json:"-"`
+	// List is the index in Spec.Lists of the list an op creates or
+	// updates, or in which a card is created or to which it moves.
+	List int `json:"-"`
+	// Pos is the position to give a created or reordered list.
+	Pos float64 `json:"-"`
+}
+
+// Live is the current state of a board, as fetched from the API. Board is
+// nil when the board does not exist yet. Lists and Cards are the open ones.
+type Live struct {
+	Board       *api.Board
+	Lists       []api.TrelloList
+	Labels      []api.Label
+	Memberships []api.Membership
+	Fields      []api.CustomField
+	Cards       []api.Card
+	// Self is the authenticated member, who is never removed from the board.
+	Self string
+}
+
+// Plan is the ordered list of operations that brings a live board in line
+// with a spec.
+type Plan struct {
+	Ops []Op `json:"ops"`
+
+	// ListIDs maps indexes in Spec.Lists to the live lists they matched,
+	// and LabelIDs label references to the live labels they matched.
+	ListIDs  map[int]string    `json:"-"`
+	LabelIDs map[string]string `json:"-"`
+}
+
+// Counts returns how many ops add, change, and destroy objects.
+func (p *Plan) Counts() (add, change, destroy int) {
+	for _, op := range p.Ops {
+		switch op.Action {
+		case Create:
+			add++
+		case Update:
+			change++
+		case Delete:
+			destroy++
+		}
+	}
+	return
+}
+
+// Diff computes the plan for spec against live. Sections the spec leaves
+// empty (members, labels, custom fields) are not managed; lists not in the
+// spec are archived; cards are only managed when listed in the spec, and
+// other cards are left alone.
+func Diff(spec *Spec, live *Live) *Plan {
+	p := &Plan{ListIDs: map[int]string{}, LabelIDs: map[string]string{}}
+	p.board(spec, live)
+	p.members(spec, live)
+	p.labels(spec, live)
+	p.fields(spec, live)
+	p.lists(spec, live)
+	p.cards(spec, live)
+	return p
+}
+
+func (p *Plan) add(op Op) {
+	p.Ops = append(p.Ops, op)
+}
+
+func (p *Plan) board(spec *Spec, live *Live) {
+	if live.Board == nil {
+		p.add(Op{Action: Create, Kind: KindBoard, Name: spec.Name})
+		return
+	}
+	b := live.Board
+	op := Op{Action: Update, Kind: KindBoard, Name: b.Name, ID: b.ID, Params: map[string]string{}}
+	change := func(param, field, old, new string) {
+		if new != "" && new != old {
+			op.Changes = append(op.Changes, Change{Field: field, Old: old, New: new})
+			op.Params[param] = new
+		}
+	}
+	change("name", "name", b.Name, spec.Name)
+	change("desc", "description", b.Desc, spec.Description)
+	if pr := spec.Prefs; pr != nil {
+		change("prefs/permissionLevel", "prefs.permissionLevel", b.Prefs.PermissionLevel, pr.PermissionLevel)
+		change("prefs/voting", "prefs.voting", b.Prefs.Voting, pr.Voting)
+		change("prefs/comments", "prefs.comments", b.Prefs.Comments, pr.Comments)
+		change("prefs/background", "prefs.background", b.Prefs.Background, pr.Background)
+		change("prefs/cardAging", "prefs.cardAging", b.Prefs.CardAging, pr.CardAging)
+		if pr.SelfJoin != nil {
+			change("prefs/selfJoin", "prefs.selfJoin", strconv.FormatBool(b.Prefs.SelfJoin), strconv.FormatBool(*pr.SelfJoin))
+		}
+		if pr.CardCovers != nil {
+			change("prefs/cardCovers", "prefs.cardCovers", strconv.FormatBool(b.Prefs.CardCovers), strconv.FormatBool(*pr.CardCovers))
+		}
+	}
+	if len(op.Changes) > 0 {
+		p.add(op)
+	}
+}
+
+func (p *Plan) members(spec *Spec, live *Live) {
+	if len(spec.Members) == 0 {
+		return
+	}
+	matched := map[string]bool{}
+	for i := range spec.Members {
+		m := &spec.Members[i]
+		name := strings.TrimPrefix(m.Username, "@")
+		var found *api.Membership
+		for j, ms := range live.Memberships {
+			if ms.Member != nil && strings.EqualFold(ms.Member.Username, name) {
+				found = &live.Memberships[j]
+				break
+			}
+		}
+		switch {
+		case found == nil:
+			p.add(Op{Action: Create, Kind: KindMember, Name: "@" + name, Note: m.MemberType(), Member: m})
+		case found.MemberType != m.MemberType() && found.IDMember != live.Self:
+			matched[found.IDMember] = true
+			p.add(Op{Action: Update, Kind: KindMember, Name: "@" + name, ID: found.IDMember, Member: m,
+				Changes: []Change{{Field: "type", Old: found.MemberType, New: m.MemberType()}}})
+		default:
+			matched[found.IDMember] = true
+		}
+	}
+	for _, ms := range live.Memberships {
+		if matched[ms.IDMember] || ms.IDMember == live.Self || ms.Deactivated {
+			continue
+		}
+		name := ms.IDMember
+		if ms.Member != nil && ms.Member.Username != "" {
+			name = "@" + ms.Member.Username
+		}
+		p.add(Op{Action: Delete, Kind: KindMember, Name: name, ID: ms.IDMember, Note: "remove from board"})
+	}
+}
+
+func (p *Plan) labels(spec *Spec, live *Live) {
+	if len(spec.Labels) == 0 {
+		return
+	}
+	// Match by ID, then by name and color, then by name alone, so that
+	// labels sharing a name each keep their own live label.
+	found := make([]int, len(spec.Labels))
+	matched := map[string]bool{}
+	match := func(same func(l *Label, ll Label) bool) {
+		for i := range spec.Labels {
+			l := &spec.Labels[i]
+			if found[i] >= 0 {
+				continue
+			}
+			for j, ll := range live.Labels {
+				if !matched[ll.ID] && same(l, Label{ID: ll.ID, Name: ll.Name, Color: ll.Color}) {
+					found[i] = j
+					matched[ll.ID] = true
+					break
+				}
+			}
+		}
+	}
+	for i := range found {
+		found[i] = -1
+	}
+	match(func(l *Label, ll Label) bool { return l.ID != "" && ll.ID == l.ID })
+	match(func(l *Label, ll Label) bool { return l.ID == "" && ll.Ref() == l.Ref() })
+	match(func(l *Label, ll Label) bool { return l.ID == "" && ll.Key() == l.Key() })
+
+	for ref, i := range spec.LabelRefs() {
+		if found[i] >= 0 {
+			p.LabelIDs[ref] = live.Labels[found[i]].ID
+		}
+	}
+	for i := range spec.Labels {
+		l := &spec.Labels[i]
+		if found[i] < 0 {
+			p.add(Op{Action: Create, Kind: KindLabel, Name: labelDisplay(l.Name, l.Color), Label: l})
+			continue
+		}
+		ll := live.Labels[found[i]]
+		op := Op{Action: Update, Kind: KindLabel, Name: labelDisplay(ll.Name, ll.Color), ID: ll.ID, Label: l}
+		if ll.Name != l.Name {
+			op.Changes = append(op.Changes, Change{Field: "name", Old: ll.Name, New: l.Name})
+		}
+		if ll.Color != l.Color {
+			op.Changes = append(op.Changes, Change{Field: "color", Old: ll.Color, New: l.Color})
+		}
+		if len(op.Changes) > 0 {
+			p.add(op)
+		}
+	}
+	for _, ll := range live.Labels {
+		if !matched[ll.ID] {
+			p.add(Op{Action: Delete, Kind: KindLabel, Name: labelDisplay(ll.Name, ll.Color), ID: ll.ID})
+		}
+	}
+}
+
+func (p *Plan) fields(spec *Spec, live *Live) {
+	if len(spec.CustomFields) == 0 {
+		return
+	}
+	matched := map[string]bool{}
+	for i := range spec.CustomFields {
+		f := &spec.CustomFields[i]
+		var found *api.CustomField
+		for j, lf := range live.Fields {
+			if !matched[lf.ID] && strings.EqualFold(lf.Name, f.Name) {
+				found = &live.Fields[j]
+				break
+			}
+		}
+		if found == nil {
+			p.add(Op{Action: Create, Kind: KindField, Name: f.Name, Note: f.Type, Field: f})
+			continue
+		}
+		matched[found.ID] = true
+		if found.Type != f.Type {
+			// The type of a field cannot be changed: replace it.
+			p.add(Op{Action: Delete, Kind: KindField, Name: found.Name, ID: found.ID, Note: "type " + found.Type + " → " + f.Type + ", values are lost"})
+			p.add(Op{Action: Create, Kind: KindField, Name: f.Name, Note: f.Type, Field: f})
+			continue
+		}
+		op := Op{Action: Update, Kind: KindField, Name: found.Name, ID: found.ID, Field: f}
+		if found.Name != f.Name {
+			op.Changes = append(op.Changes, Change{Field: "name", Old: found.Name, New: f.Name})
+		}
+		if found.Display.CardFront != f.CardFront {
+			op.Changes = append(op.Changes, Change{Field: "cardFront", Old: strconv.FormatBool(found.Display.CardFront), New: strconv.FormatBool(f.CardFront)})
+		}
+		if len(op.Changes) > 0 {
+			p.add(op)
+		}
+
+		have := map[string]bool{}
+		for _, o := range found.Options {
+			have[strings.ToLower(o.Value.Text)] = true
+		}
+		want := map[string]bool{}
+		for _, o := range f.Options {
+			want[strings.ToLower(o)] = true
+			if !have[strings.ToLower(o)] {
+				p.add(Op{Action: Create, Kind: KindOption, Name: o, ID: found.ID, Parent: found.Name})
+			}
+		}
+		for _, o := range found.Options {
+			if !want[strings.ToLower(o.Value.Text)] {
+				p.add(Op{Action: Delete, Kind: KindOption, Name: o.Value.Text, ID: found.ID, OptionID: o.ID, Parent: found.Name})
+			}
+		}
+	}
+	for _, lf := range live.Fields {
+		if !matched[lf.ID] {
+			p.add(Op{Action: Delete, Kind: KindField, Name: lf.Name, ID: lf.ID})
+		}
+	}
+}
+
+// posStep is the gap Trello leaves between positions of items added at the bottom.
+const posStep = 65536
+
+func (p *Plan) lists(spec *Spec, live *Live) {
+	lists := append([]api.TrelloList(nil), live.Lists...)
+	sort.SliceStable(lists, func(i, j int) bool { return lists[i].Pos < lists[j].Pos })
+
+	// Match spec lists to live ones, by pinned ID and then by name.
+	match := make([]int, len(spec.Lists))
+	used := map[int]bool{}
+	for i, l := range spec.Lists {
+		match[i] = -1
+		for j, ll := range lists {
+			if l.ID != "" && ll.ID == l.ID {
+				match[i], used[j] = j, true
+			}
+		}
+	}
+	for i, l := range spec.Lists {
+		if match[i] >= 0 || l.ID != "" {
+			continue
+		}
+		for j, ll := range lists {
+			if !used[j] && strings.EqualFold(ll.Name, l.Name) {
+				match[i], used[j] = j, true
+				break
+			}
+		}
+	}
+
+	// Positions: if the matched lists are already in spec order, new lists
+	// slot in between their neighbours; otherwise every list is renumbered.
+	inOrder := true
+	last := -1
+	for _, j := range match {
+		if j >= 0 {
+			if j < last {
+				inOrder = false
+			}
+			last = j
+		}
+	}
+	pos := make([]float64, len(spec.Lists))
+	for i := range spec.Lists {
+		pos[i] = float64(i+1) * posStep
+	}
+	if inOrder {
+		for i := range spec.Lists {
+			if match[i] >= 0 {
+				pos[i] = lists[match[i]].Pos
+				continue
+			}
+			lo, hi := 0.0, -1.0
+			if i > 0 {
+				lo = pos[i-1]
+			}
+			for k := i + 1; k < len(spec.Lists); k++ {
+				if match[k] >= 0 {
+					hi = lists[match[k]].Pos
+					break
+				}
+			}
+			if hi < 0 {
+				pos[i] = lo + posStep
+			} else {
+				// Leave room for the lists still to be placed before hi.
+				pos[i] = lo + (hi-lo)/2
+			}
+		}
+	}
+
+	// Display positions are 1-based indexes among the open lists.
+	for i := range spec.Lists {
+		l := &spec.Lists[i]
+		if match[i] < 0 {
+			p.add(Op{Action: Create, Kind: KindList, Name: l.Name, List: i, Pos: pos[i]})
+			continue
+		}
+		ll := lists[match[i]]
+		p.ListIDs[i] = ll.ID
+		op := Op{Action: Update, Kind: KindList, Name: ll.Name, ID: ll.ID, List: i, Pos: pos[i]}
+		if ll.Name != l.Name {
+			op.Changes = append(op.Changes, Change{Field: "name", Old: ll.Name, New: l.Name})
+		}
+		if !inOrder && ll.Pos != pos[i] {
+			op.Changes = append(op.Changes, Change{Field: "position", Old: strconv.Itoa(match[i] + 1), New: strconv.Itoa(i + 1)})
+		}
+		if len(op.Changes) > 0 {
+			p.add(op)
+		}
+	}
+	for j, ll := range lists {
+		if !used[j] {
+			p.add(Op{Action: Delete, Kind: KindList, Name: ll.Name, ID: ll.ID, Note: "archive"})
+		}
+	}
+}
+
+func (p *Plan) cards(spec *Spec, live *Live) {
+	listIDs := p.ListIDs
+	// Compare labels by their unique reference: live labels by their own,
+	// spec references by that of the label they name.
+	labelRefs := map[string]string{}
+	for _, l := range live.Labels {
+		labelRefs[l.ID] = (Label{Name: l.Name, Color: l.Color}).Ref()
+	}
+	specRefs := map[string]string{}
+	for ref, i := range spec.LabelRefs() {
+		specRefs[ref] = spec.Labels[i].Ref()
+	}
+	for _, l := range spec.Labels {
+		if id, ok := p.LabelIDs[l.Ref()]; ok {
+			labelRefs[id] = l.Ref()
+		}
+	}
+
+	used := map[string]bool{}
+	for i := range spec.Lists {
+		l := &spec.Lists[i]
+		for k := range l.Cards {
+			c := &l.Cards[k]
+			found := findCard(live.Cards, c.Name, listIDs[i], used)
+			if found == nil {
+				p.add(Op{Action: Create, Kind: KindCard, Name: c.Name, Note: "in " + l.Name, List: i, Card: c})
+				continue
+			}
+			used[found.ID] = true
+			op := Op{Action: Update, Kind: KindCard, Name: found.Name, ID: found.ID, List: i, Card: c}
+			if id, ok := listIDs[i]; !ok || found.IDList != id {
+				op.Changes = append(op.Changes, Change{Field: "list", Old: listName(live.Lists, found.IDList), New: l.Name})
+			}
+			if c.Desc != "" && strings.TrimSpace(found.Desc) != strings.TrimSpace(c.Desc) {
+				op.Changes = append(op.Changes, Change{Field: "desc", Old: found.Desc, New: c.Desc})
+			}
+			if len(c.Labels) > 0 {
+				var have, want []string
+				for _, id := range found.IDLabels {
+					have = append(have, labelRefs[id])
+				}
+				for _, ref := range c.Labels {
+					ref = strings.ToLower(ref)
+					if r, ok := specRefs[ref]; ok {
+						ref = r
+					}
+					want = append(want, ref)
+				}
+				sort.Strings(have)
+				sort.Strings(want)
+				if strings.Join(have, ",") != strings.Join(want, ",") {
+					op.Changes = append(op.Changes, Change{Field: "labels", Old: strings.Join(have, ", "), New: strings.Join(want, ", ")})
+				}
+			}
+			if len(op.Changes) > 0 {
+				p.add(op)
+			}
+		}
+	}
+}
+
+// findCard returns the unused card named name, preferring one in listID.
+func findCard(cards []api.Card, name, listID string, used map[string]bool) *api.Card {
+	var found *api.Card
+	for i, c := range cards {
+		if used[c.ID] || !strings.EqualFold(strings.TrimSpace(c.Name), strings.TrimSpace(name)) {
+			continue
+		}
+		if listID != "" && c.IDList == listID {
+			return &cards[i]
+		}
+		if found == nil {
+			found = &cards[i]
+		}
+	}
+	return found
+}
+
+func listName(lists []api.TrelloList, id string) string {
+	for _, l := range lists {
+		if l.ID == id {
+			return l.Name
+		}
+	}
+	return id
+}
+
+func labelDisplay(name, color string) string {
+	if name == "" {
+		return color
+	}
+	if color == "" {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, color)
+}
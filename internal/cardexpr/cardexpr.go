@@ -0,0 +1,616 @@
+// Package cardexpr parses and evaluates card filter expressions such as
+//
+//	list = "In Progress" and label = bug and due < +3d
+//
+// Comparisons test one field of a card; they combine with and, or, not,
+// and parentheses. Adjacent comparisons without an operator are and-ed.
+package cardexpr
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Card is the view of a card an expression is evaluated against.
+type Card struct {
+	Name     string
+	Desc     string
+	List     string
+	Labels   []Names // each label's ID, name, and color
+	Members  []Names // each member's ID, username, and full name
+	Due      time.Time
+	Start    time.Time
+	Activity time.Time
+	Complete bool
+	Archived bool
+	// CheckItems and CheckItemsChecked count checklist items.
+	CheckItems        int
+	CheckItemsChecked int
+}
+
+// Names are the ways a label or member can be referred to.
+type Names []string
+
+// Expr is a parsed expression.
+type Expr struct {
+	root node
+	used map[string]bool
+}
+
+// Match reports whether c satisfies the expression.
+func (e *Expr) Match(c Card) bool {
+	return e.root.match(c)
+}
+
+// Uses reports whether the expression tests field.
+func (e *Expr) Uses(field string) bool {
+	return e.used[field]
+}
+
+type kind int
+
+const (
+	textField kind = iota
+	setField
+	dateField
+	boolField
+	numField
+)
+
+var fields = map[string]kind{
+	"name":      textField,
+	"desc":      textField,
+	"list":      textField,
+	"label":     setField,
+	"member":    setField,
+	"due":       dateField,
+	"start":     dateField,
+	"activity":  dateField,
+	"complete":  boolField,
+	"archived":  boolField,
+	"overdue":   boolField,
+	"items":     numField,
+	"unchecked": numField,
+}
+
+// Fields returns the names of the fields an expression can test.
+func Fields() []string {
+	out := make([]string, 0, len(fields))
+	for f := range fields {
+		out = append(out, f)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Parse parses src. Relative dates ("today", "+3d") are fixed at parse time.
+func Parse(src string) (*Expr, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks, now: time.Now(), used: map[string]bool{}}
+	if p.peek().kind == tokEOF {
+		return nil, fmt.Errorf("empty expression")
+	}
+	root, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, p.errorf(t, "unexpected %s", t)
+	}
+	return &Expr{root: root, used: p.used}, nil
+}
+
+// ---- lexer ----
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokWord
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokString:
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+func isSpecial(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune(`()"'=!<>~`, r)
+}
+
+func lex(src string) ([]token, error) {
+	var toks []token
+	rs := []rune(src)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			toks = append(toks, token{tokLParen, "(", i})
+			i++
+		case r == ')':
+			toks = append(toks, token{tokRParen, ")", i})
+			i++
+		case r == '"' || r == '\'':
+			start := i
+			var b strings.Builder
+			for i++; ; i++ {
+				if i == len(rs) {
+					return nil, fmt.Errorf("at %d: unterminated string", start+1)
+				}
+				if rs[i] == r {
+					i++
+					break
+				}
+				if rs[i] == '\\' && r == '"' && i+1 < len(rs) {
+					i++
+				}
+				b.WriteRune(rs[i])
+			}
+			toks = append(toks, token{tokString, b.String(), start})
+		case strings.ContainsRune("=!<>~", r):
+			start := i
+			op := string(r)
+			if i+1 < len(rs) && (rs[i+1] == '=' || (r == '!' && rs[i+1] == '~')) {
+				op += string(rs[i+1])
+			}
+			i += len([]rune(op))
+			switch op {
+			case "=", "==", "!=", "~", "!~", "<", "<=", ">", ">=":
+			default:
+				return nil, fmt.Errorf("at %d: unknown operator %q", start+1, op)
+			}
+			if op == "==" {
+				op = "="
+			}
+			toks = append(toks, token{tokOp, op, start})
+		default:
+			start := i
+			for i < len(rs) && !isSpecial(rs[i]) {
+				i++
+			}
+			toks = append(toks, token{tokWord, string(rs[start:i]), start})
+		}
+	}
+	return append(toks, token{tokEOF, "", len(rs)}), nil
+}
+
+// ---- parser ----
+
+type parser struct {
+	toks []token
+	i    int
+	now  time.Time
+	used map[string]bool
+}
+
+func (p *parser) peek() token { return p.toks[p.i] }
+
+func (p *parser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) errorf(t token, format string, args ...any) error {
+	return fmt.Errorf("at %d: %s", t.pos+1, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) keyword(word string) bool {
+	t := p.peek()
+	return t.kind == tokWord && strings.EqualFold(t.text, word)
+}
+
+func (p *parser) or() (node, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		p.next()
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) and() (node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		switch {
+		case p.keyword("and"):
+			p.next()
+		case t.kind == tokEOF || t.kind == tokRParen || p.keyword("or"):
+			return left, nil
+		}
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+}
+
+func (p *parser) unary() (node, error) {
+	if p.keyword("not") {
+		p.next()
+		n, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{n}, nil
+	}
+	if p.peek().kind == tokLParen {
+		open := p.next()
+		n, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokRParen {
+			return nil, p.errorf(open, "unclosed parenthesis")
+		}
+		p.next()
+		return n, nil
+	}
+	return p.comparison()
+}
+
+func (p *parser) comparison() (node, error) {
+	t := p.next()
+	if t.kind != tokWord {
+		return nil, p.errorf(t, "expected a field, got %s", t)
+	}
+	field := strings.ToLower(t.text)
+	k, ok := fields[field]
+	if !ok {
+		return nil, p.errorf(t, "unknown field %q (fields: %s)", t.text, strings.Join(Fields(), ", "))
+	}
+	p.used[field] = true
+
+	if p.peek().kind != tokOp {
+		if k != boolField {
+			return nil, p.errorf(p.peek(), "expected an operator after %s", field)
+		}
+		return boolNode{field: field, want: true, now: p.now}, nil
+	}
+	opTok := p.next()
+	op := opTok.text
+	v := p.next()
+	if v.kind != tokWord && v.kind != tokString {
+		return nil, p.errorf(v, "expected a value after %s %s, got %s", field, op, v)
+	}
+	none := v.kind == tokWord && strings.EqualFold(v.text, "none")
+
+	switch k {
+	case textField:
+		return textNode(field, op, v.text, opTok, p)
+	case setField:
+		if none {
+			if op != "=" && op != "!=" {
+				return nil, p.errorf(opTok, "%s none only supports = and !=", field)
+			}
+			return emptyNode{field: field, negate: op == "!="}, nil
+		}
+		return setNode(field, op, v.text, opTok, p)
+	case dateField:
+		if none {
+			if op != "=" && op != "!=" {
+				return nil, p.errorf(opTok, "%s none only supports = and !=", field)
+			}
+			return emptyNode{field: field, negate: op == "!="}, nil
+		}
+		from, to, err := parseDate(v.text, p.now)
+		if err != nil {
+			return nil, p.errorf(v, "%v", err)
+		}
+		if op == "~" || op == "!~" {
+			return nil, p.errorf(opTok, "%s does not support %s", field, op)
+		}
+		return dateNode{field: field, op: op, from: from, to: to}, nil
+	case boolField:
+		b, err := strconv.ParseBool(strings.ToLower(v.text))
+		if err != nil || (op != "=" && op != "!=") {
+			return nil, p.errorf(opTok, "%s is true or false: use %s, not %s, or %s = true|false", field, field, field, field)
+		}
+		return boolNode{field: field, want: b == (op == "="), now: p.now}, nil
+	default:
+		n, err := strconv.Atoi(v.text)
+		if err != nil {
+			return nil, p.errorf(v, "%s needs a number, got %s", field, v)
+		}
+		if op == "~" || op == "!~" {
+			return nil, p.errorf(opTok, "%s does not support %s", field, op)
+		}
+		return numNode{field: field, op: op, n: n}, nil
+	}
+}
+
+func textNode(field, op, value string, opTok token, p *parser) (node, error) {
+	switch op {
+	case "=", "!=":
+		return textEq{field: field, value: value, negate: op == "!="}, nil
+	case "~", "!~":
+		re, err := regexp.Compile("(?i)" + value)
+		if err != nil {
+			return nil, p.errorf(opTok, "invalid regular expression: %v", err)
+		}
+		return textMatch{field: field, re: re, negate: op == "!~"}, nil
+	}
+	return nil, p.errorf(opTok, "%s does not support %s: use =, !=, ~, or !~", field, op)
+}
+
+func setNode(field, op, value string, opTok token, p *parser) (node, error) {
+	switch op {
+	case "=", "!=":
+		return setHas{field: field, value: value, negate: op == "!="}, nil
+	case "~", "!~":
+		re, err := regexp.Compile("(?i)" + value)
+		if err != nil {
+			return nil, p.errorf(opTok, "invalid regular expression: %v", err)
+		}
+		return setMatch{field: field, re: re, negate: op == "!~"}, nil
+	}
+	return nil, p.errorf(opTok, "%s does not support %s: use =, !=, ~, or !~", field, op)
+}
+
+// parseDate parses a date value into the half-open interval [from, to) it
+// covers: a whole day for YYYY-MM-DD, today, and relative days or weeks
+// (+3d, -2w); a single instant for RFC 3339 times, now, and relative hours
+// or minutes (-12h, +30m).
+func parseDate(s string, now time.Time) (time.Time, time.Time, error) {
+	day := func(t time.Time) (time.Time, time.Time, error) {
+		from := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		return from, from.AddDate(0, 0, 1), nil
+	}
+	instant := func(t time.Time) (time.Time, time.Time, error) {
+		return t, t.Add(time.Millisecond), nil
+	}
+
+	switch strings.ToLower(s) {
+	case "today":
+		return day(now)
+	case "now":
+		return instant(now)
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return day(t)
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return instant(t)
+	}
+	if len(s) >= 3 && (s[0] == '+' || s[0] == '-') {
+		n, err := strconv.Atoi(s[1 : len(s)-1])
+		if err == nil {
+			if s[0] == '-' {
+				n = -n
+			}
+			switch s[len(s)-1] {
+			case 'd':
+				return day(now.AddDate(0, 0, n))
+			case 'w':
+				return day(now.AddDate(0, 0, 7*n))
+			case 'h':
+				return instant(now.Add(time.Duration(n) * time.Hour))
+			case 'm':
+				return instant(now.Add(time.Duration(n) * time.Minute))
+			}
+		}
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("invalid date %q: use YYYY-MM-DD, an ISO-8601 time, today, now, or an offset like +3d, -2w, -12h", s)
+}
+
+// ---- evaluation ----
+
+type node interface {
+	match(c Card) bool
+}
+
+type andNode struct{ a, b node }
+type orNode struct{ a, b node }
+type notNode struct{ n node }
+
+func (n andNode) match(c Card) bool { return n.a.match(c) && n.b.match(c) }
+func (n orNode) match(c Card) bool  { return n.a.match(c) || n.b.match(c) }
+func (n notNode) match(c Card) bool { return !n.n.match(c) }
+
+func text(c Card, field string) string {
+	switch field {
+	case "name":
+		return c.Name
+	case "desc":
+		return c.Desc
+	}
+	return c.List
+}
+
+func set(c Card, field string) []Names {
+	if field == "label" {
+		return c.Labels
+	}
+	return c.Members
+}
+
+func date(c Card, field string) time.Time {
+	switch field {
+	case "due":
+		return c.Due
+	case "start":
+		return c.Start
+	}
+	return c.Activity
+}
+
+type textEq struct {
+	field, value string
+	negate       bool
+}
+
+func (n textEq) match(c Card) bool {
+	return strings.EqualFold(text(c, n.field), n.value) != n.negate
+}
+
+type textMatch struct {
+	field  string
+	re     *regexp.Regexp
+	negate bool
+}
+
+func (n textMatch) match(c Card) bool {
+	return n.re.MatchString(text(c, n.field)) != n.negate
+}
+
+type setHas struct {
+	field, value string
+	negate       bool
+}
+
+func (n setHas) match(c Card) bool {
+	for _, names := range set(c, n.field) {
+		for _, name := range names {
+			if name != "" && strings.EqualFold(name, n.value) {
+				return !n.negate
+			}
+		}
+	}
+	return n.negate
+}
+
+type setMatch struct {
+	field  string
+	re     *regexp.Regexp
+	negate bool
+}
+
+func (n setMatch) match(c Card) bool {
+	for _, names := range set(c, n.field) {
+		for _, name := range names {
+			if name != "" && n.re.MatchString(name) {
+				return !n.negate
+			}
+		}
+	}
+	return n.negate
+}
+
+// emptyNode tests "field = none": no labels, no members, or no date.
+type emptyNode struct {
+	field  string
+	negate bool
+}
+
+func (n emptyNode) match(c Card) bool {
+	var empty bool
+	if fields[n.field] == setField {
+		empty = len(set(c, n.field)) == 0
+	} else {
+		empty = date(c, n.field).IsZero()
+	}
+	return empty != n.negate
+}
+
+type dateNode struct {
+	field    string
+	op       string
+	from, to time.Time
+}
+
+// match compares the card's date against the interval [from, to). Cards
+// without the date only match !=.
+func (n dateNode) match(c Card) bool {
+	t := date(c, n.field)
+	if t.IsZero() {
+		return n.op == "!="
+	}
+	in := !t.Before(n.from) && t.Before(n.to)
+	switch n.op {
+	case "=":
+		return in
+	case "!=":
+		return !in
+	case "<":
+		return t.Before(n.from)
+	case "<=":
+		return t.Before(n.to)
+	case ">":
+		return !t.Before(n.to)
+	}
+	return !t.Before(n.from)
+}
+
+type boolNode struct {
+	field string
+	want  bool
+	now   time.Time
+}
+
+func (n boolNode) match(c Card) bool {
+	var v bool
+	switch n.field {
+	case "complete":
+		v = c.Complete
+	case "archived":
+		v = c.Archived
+	case "overdue":
+		v = !c.Due.IsZero() && !c.Complete && c.Due.Before(n.now)
+	}
+	return v == n.want
+}
+
+type numNode struct {
+	field string
+	op    string
+	n     int
+}
+
+func (n numNode) match(c Card) bool {
+	v := c.CheckItems
+	if n.field == "unchecked" {
+		v = c.CheckItems - c.CheckItemsChecked
+	}
+	switch n.op {
+	case "=":
+		return v == n.n
+	case "!=":
+		return v != n.n
+	case "<":
+		return v < n.n
+	case "<=":
+		return v <= n.n
+	case ">":
+		return v > n.n
+	}
+	return v >= n.n
+}
@@ -83,6 +83,35 @@ func Clear() error {
 	return err
 }
 
+// StatePath returns the path of a state file (e.g. a follow checkpoint)
+// stored next to the config file, creating its directory if needed.
+func StatePath(name string) (string, error) {
+	dir, err := subdir("state")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// TemplatesDir returns the directory holding saved board templates,
+// creating it if needed.
+func TemplatesDir() (string, error) {
+	return subdir("templates")
+}
+
+// subdir returns a directory next to the config file, creating it if needed.
+func subdir(name string) (string, error) {
+	path, err := configPath()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(filepath.Dir(path), name)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
 // Path returns the config file path for display purposes.
 func Path() string {
 	p, _ := configPath()
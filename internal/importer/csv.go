@@ -0,0 +1,156 @@
+package importer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/the20100/trello-cli/internal/strutil"
+)
+
+// CSVFields are the card fields a CSV column can be mapped to.
+var CSVFields = []string{"id", "name", "desc", "list", "labels", "due", "members", "checklist"}
+
+// csvAliases are the column headers recognised for each field when no
+// mapping is given, compared case-insensitively.
+var csvAliases = map[string][]string{
+	"id":        {"id", "external id", "external_id", "key"},
+	"name":      {"name", "title", "card", "task", "summary"},
+	"desc":      {"desc", "description", "notes", "details", "body"},
+	"list":      {"list", "status", "column", "stage"},
+	"labels":    {"labels", "label", "tags"},
+	"due":       {"due", "due date", "due_date", "deadline"},
+	"members":   {"members", "member", "assignee", "assignees", "owner"},
+	"checklist": {"checklist", "checklist items", "subtasks", "tasks"},
+}
+
+// FromCSV reads cards from CSV with a header row. mapping maps fields (see
+// CSVFields) to column headers and overrides the recognised aliases. Rows
+// without a list go to defaultList. Labels and members are separated by
+// commas or semicolons; checklist items by semicolons or newlines, with a
+// leading "[x]" marking an item complete. Without an id column, a card's
+// external ID is derived from its list and name.
+func FromCSV(r io.Reader, mapping map[string]string, defaultList string) (*Doc, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %w", err)
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	cols, err := csvColumns(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := cols["name"]; !ok {
+		return nil, fmt.Errorf("no name column in CSV (headers: %s); map one with --map name=<column>", strings.Join(header, ", "))
+	}
+
+	d := &Doc{}
+	for line := 2; ; line++ {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading CSV line %d: %w", line, err)
+		}
+		get := func(field string) string {
+			if i, ok := cols[field]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		card := Card{
+			Name:    get("name"),
+			Desc:    get("desc"),
+			List:    strutil.FirstNonEmpty(get("list"), defaultList),
+			Labels:  splitList(get("labels")),
+			Members: splitList(get("members")),
+			Due:     get("due"),
+		}
+		if card.Name == "" {
+			continue
+		}
+		if id := get("id"); id != "" {
+			card.ExternalID = "csv:" + id
+		} else {
+			card.ExternalID = hashID("csv", card.List, card.Name)
+		}
+		for i := range card.Members {
+			card.Members[i] = strings.TrimPrefix(card.Members[i], "@")
+		}
+		if items := checklistItems(get("checklist")); len(items) > 0 {
+			card.Checklists = []Checklist{{Name: "Checklist", Items: items}}
+		}
+
+		d.addList(card.List)
+		for _, l := range card.Labels {
+			d.addLabel(Label{Name: l})
+		}
+		d.Cards = append(d.Cards, card)
+	}
+	return d, nil
+}
+
+// csvColumns returns the column index of each mapped field.
+func csvColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	index := map[string]int{}
+	for i, h := range header {
+		index[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	cols := map[string]int{}
+	fields := make([]string, 0, len(mapping))
+	for f := range mapping {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	for _, f := range fields {
+		if _, ok := csvAliases[f]; !ok {
+			return nil, fmt.Errorf("unknown field %q in mapping (use %s)", f, strings.Join(CSVFields, ", "))
+		}
+		i, ok := index[strings.ToLower(strings.TrimSpace(mapping[f]))]
+		if !ok {
+			return nil, fmt.Errorf("mapping %s=%s: no such column (headers: %s)", f, mapping[f], strings.Join(header, ", "))
+		}
+		cols[f] = i
+	}
+	for f, aliases := range csvAliases {
+		if _, ok := cols[f]; ok {
+			continue
+		}
+		for _, a := range aliases {
+			if i, ok := index[a]; ok {
+				cols[f] = i
+				break
+			}
+		}
+	}
+	return cols, nil
+}
+
+// checklistItems splits a checklist cell into items.
+func checklistItems(s string) []Item {
+	var items []Item
+	for _, f := range strings.FieldsFunc(s, func(r rune) bool { return r == ';' || r == '\n' }) {
+		f = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(f), "-"))
+		item := Item{Name: f}
+		lower := strings.ToLower(f)
+		switch {
+		case strings.HasPrefix(lower, "[x]"):
+			item = Item{Name: strings.TrimSpace(f[3:]), Complete: true}
+		case strings.HasPrefix(lower, "[ ]"):
+			item.Name = strings.TrimSpace(f[3:])
+		}
+		if item.Name != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,129 @@
+// Package importer reads cards from other sources — Trello's JSON board
+// export, CSV files, and Markdown task lists — into a common document, and
+// defines the external-ID marker that makes imports idempotent.
+package importer
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Doc is the content to import: lists in order, labels, and cards.
+type Doc struct {
+	Name   string   `json:"name,omitempty"`
+	Lists  []string `json:"lists"`
+	Labels []Label  `json:"labels,omitempty"`
+	Cards  []Card   `json:"cards"`
+}
+
+// Label is a label by name and color; either may be empty.
+type Label struct {
+	Name  string `json:"name,omitempty"`
+	Color string `json:"color,omitempty"`
+}
+
+// Card is a card to import. ExternalID identifies it across runs; List,
+// Labels, and Members are referenced by name (members by username).
+type Card struct {
+	ExternalID  string      `json:"externalId"`
+	List        string      `json:"list"`
+	Name        string      `json:"name"`
+	Desc        string      `json:"desc,omitempty"`
+	Labels      []string    `json:"labels,omitempty"`
+	Members     []string    `json:"members,omitempty"`
+	Due         string      `json:"due,omitempty"`
+	DueComplete bool        `json:"dueComplete,omitempty"`
+	Checklists  []Checklist `json:"checklists,omitempty"`
+}
+
+// Checklist is a checklist of an imported card.
+type Checklist struct {
+	Name  string `json:"name"`
+	Items []Item `json:"items"`
+}
+
+// Item is a checklist item.
+type Item struct {
+	Name     string `json:"name"`
+	Complete bool   `json:"complete,omitempty"`
+}
+
+// addList adds a list name unless it is already present (case-insensitively).
+func (d *Doc) addList(name string) {
+	for _, l := range d.Lists {
+		if strings.EqualFold(l, name) {
+			return
+		}
+	}
+	d.Lists = append(d.Lists, name)
+}
+
+// addLabel adds a label by name unless it is already present.
+func (d *Doc) addLabel(l Label) {
+	for _, have := range d.Labels {
+		if strings.EqualFold(have.Name, l.Name) && (l.Name != "" || have.Color == l.Color) {
+			return
+		}
+	}
+	d.Labels = append(d.Labels, l)
+}
+
+// markerRe matches the external-ID marker line. The marker is a Markdown
+// link reference definition, which renders as nothing.
+var markerRe = regexp.MustCompile(`(?m)^\[//\]: # \(external-id: ([^)\s]+)\)\s*$`)
+
+// Marker returns the line that records an external ID in a description.
+// The ID is percent-encoded so that spaces and parentheses cannot break the
+// marker; plain IDs such as "csv:42" are written as they are.
+func Marker(id string) string {
+	return fmt.Sprintf("[//]: # (external-id: %s)", url.PathEscape(id))
+}
+
+// ExternalID returns the external ID recorded in a description, if any.
+func ExternalID(desc string) string {
+	m := markerRe.FindStringSubmatch(desc)
+	if m == nil {
+		return ""
+	}
+	if id, err := url.PathUnescape(m[1]); err == nil {
+		return id
+	}
+	return m[1]
+}
+
+// StripMarker removes the external-ID marker from a description.
+func StripMarker(desc string) string {
+	return strings.TrimRight(markerRe.ReplaceAllString(desc, ""), "\n ")
+}
+
+// WithMarker returns desc with the marker for id as its last line,
+// replacing any marker already present.
+func WithMarker(desc, id string) string {
+	desc = StripMarker(desc)
+	if desc == "" {
+		return Marker(id)
+	}
+	return desc + "\n\n" + Marker(id)
+}
+
+// hashID derives a stable external ID from the parts that identify a card
+// in sources without IDs.
+func hashID(prefix string, parts ...string) string {
+	h := sha1.Sum([]byte(strings.ToLower(strings.Join(parts, "\x00"))))
+	return prefix + ":" + hex.EncodeToString(h[:6])
+}
+
+// splitList splits a multi-value cell on commas, semicolons, or newlines.
+func splitList(s string) []string {
+	var out []string
+	for _, f := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ';' || r == '\n' }) {
+		if f = strings.TrimSpace(f); f != "" {
+			out = append(out, f)
+		}
+	}
+	return out
+}
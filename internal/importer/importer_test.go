@@ -0,0 +1,39 @@
+package importer
+
+import "testing"
+
+func TestMarkerRoundTrip(t *testing.T) {
+	ids := []string{
+		"5f0000000000000000000c01",
+		"csv:42",
+		"csv:Task 12",
+		"csv:fix (again)",
+		"csv:tab\there",
+		"csv:line\nbreak",
+		"csv:100%",
+		"csv:Tâche",
+		"md:3fa1c2d4e5b6",
+	}
+	for _, id := range ids {
+		for _, desc := range []string{"", "Some details.", "Old.\n\n" + Marker("other")} {
+			got := ExternalID(WithMarker(desc, id))
+			if got != id {
+				t.Errorf("ExternalID(WithMarker(%q, %q)) = %q", desc, id, got)
+			}
+		}
+	}
+}
+
+func TestMarkerPlainIDs(t *testing.T) {
+	// Markers written before IDs were escaped must still be recognised.
+	desc := "Details.\n\n[//]: # (external-id: csv:42)"
+	if got := ExternalID(desc); got != "csv:42" {
+		t.Errorf("ExternalID() = %q, want %q", got, "csv:42")
+	}
+	if got := Marker("csv:42"); got != "[//]: # (external-id: csv:42)" {
+		t.Errorf("Marker() = %q, want the ID unescaped", got)
+	}
+	if got := StripMarker(WithMarker("Details.", "csv:Task 12")); got != "Details." {
+		t.Errorf("StripMarker() = %q, want %q", got, "Details.")
+	}
+}
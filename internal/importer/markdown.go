@@ -0,0 +1,222 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/the20100/trello-cli/internal/strutil"
+)
+
+// FromMarkdown reads cards from a Markdown file, or from every .md file in
+// a directory in name order. Each file is a list, named by its first "# "
+// heading or else by its file name (without a leading "01-" style prefix).
+// A README.md in a directory only names the board.
+//
+// Two card styles are understood, and can be mixed:
+//
+//   - task list items, "- [ ] Fix login @alice #bug due:2026-05-01", with
+//     @members, #labels, and due: dates inline, and indented task items
+//     below them as checklist items; checked items are imported with their
+//     due date marked complete
+//   - "## Card name" sections, as written by "trello boards export --format
+//     markdown": "- **Labels:** ..." metadata lines, a description, "### Name"
+//     checklists of task items. Attachments and comments sections, and cards
+//     marked "(archived)", are skipped.
+func FromMarkdown(path string) (*Doc, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{path}
+	d := &Doc{}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		files = nil
+		for _, e := range entries {
+			if e.IsDir() || !strings.EqualFold(filepath.Ext(e.Name()), ".md") {
+				continue
+			}
+			if strings.EqualFold(e.Name(), "README.md") {
+				if data, err := os.ReadFile(filepath.Join(path, e.Name())); err == nil {
+					d.Name = firstHeading(string(data))
+				}
+				continue
+			}
+			files = append(files, filepath.Join(path, e.Name()))
+		}
+		sort.Strings(files)
+	}
+
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		list := strutil.FirstNonEmpty(firstHeading(string(data)), listNameFromFile(f))
+		d.addList(list)
+		parseMarkdownList(d, list, string(data))
+	}
+	return d, nil
+}
+
+var (
+	taskRe     = regexp.MustCompile(`^(\s*)[-*+] \[([ xX])\] (.+)$`)
+	metadataRe = regexp.MustCompile(`^[-*] \*\*([^*]+):\*\* (.*)$`)
+	filePrefix = regexp.MustCompile(`^\d+[-_ ]+`)
+)
+
+func firstHeading(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if h, ok := strings.CutPrefix(strings.TrimRight(line, "\r"), "# "); ok {
+			return strings.TrimSpace(h)
+		}
+	}
+	return ""
+}
+
+func listNameFromFile(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	name = filePrefix.ReplaceAllString(name, "")
+	return strings.ReplaceAll(name, "-", " ")
+}
+
+// parseMarkdownList adds the cards of one list document to d.
+func parseMarkdownList(d *Doc, list, text string) {
+	var (
+		card      *Card // current card
+		section   bool  // card is a "## " section rather than a task item
+		skip      bool  // inside a skipped section or archived card
+		checklist = -1  // index of the current checklist of card
+		desc      []string
+		inMeta    bool
+	)
+	flush := func() {
+		if card == nil {
+			return
+		}
+		if section {
+			body := strings.TrimSpace(strings.Join(desc, "\n"))
+			card.ExternalID = strutil.FirstNonEmpty(ExternalID(body), card.ExternalID)
+			card.Desc = StripMarker(body)
+		}
+		for _, l := range card.Labels {
+			d.addLabel(Label{Name: l})
+		}
+		d.Cards = append(d.Cards, *card)
+		card, desc, checklist = nil, nil, -1
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		switch {
+		case strings.HasPrefix(line, "# "):
+			continue
+		case strings.HasPrefix(line, "## "):
+			flush()
+			name := strings.TrimSpace(line[3:])
+			archived := strings.HasSuffix(name, " (archived)")
+			skip = archived
+			if !archived {
+				card = &Card{List: list, Name: name, ExternalID: hashID("md", list, name)}
+				section, inMeta = true, true
+			}
+			continue
+		case strings.HasPrefix(line, "### "):
+			if card == nil || !section {
+				continue
+			}
+			name := strings.TrimSpace(line[4:])
+			skip = name == "Attachments" || name == "Comments"
+			if !skip {
+				card.Checklists = append(card.Checklists, Checklist{Name: name})
+				checklist = len(card.Checklists) - 1
+			}
+			inMeta = false
+			continue
+		}
+		if skip {
+			continue
+		}
+
+		if m := taskRe.FindStringSubmatch(line); m != nil {
+			indented, done, text := m[1] != "", m[2] != " ", strings.TrimSpace(m[3])
+			switch {
+			case card != nil && (section || indented):
+				if checklist < 0 {
+					card.Checklists = append(card.Checklists, Checklist{Name: "Checklist"})
+					checklist = len(card.Checklists) - 1
+				}
+				cl := &card.Checklists[checklist]
+				cl.Items = append(cl.Items, Item{Name: text, Complete: done})
+			default:
+				flush()
+				card = parseTask(list, text, done)
+				section = false
+			}
+			inMeta = false
+			continue
+		}
+
+		if card == nil || !section {
+			continue
+		}
+		if m := metadataRe.FindStringSubmatch(line); m != nil && inMeta {
+			applyMetadata(card, m[1], m[2])
+			continue
+		}
+		if checklist >= 0 {
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			inMeta = false
+		}
+		if !inMeta || strings.TrimSpace(line) != "" {
+			desc = append(desc, line)
+		}
+	}
+	flush()
+}
+
+// parseTask builds a card from a task item with inline @members, #labels,
+// and due:dates.
+func parseTask(list, text string, done bool) *Card {
+	card := &Card{List: list, DueComplete: done}
+	var words []string
+	for _, w := range strings.Fields(text) {
+		switch {
+		case len(w) > 1 && w[0] == '@':
+			card.Members = append(card.Members, w[1:])
+		case len(w) > 1 && w[0] == '#':
+			card.Labels = append(card.Labels, w[1:])
+		case strings.HasPrefix(w, "due:") && len(w) > 4:
+			card.Due = w[4:]
+		default:
+			words = append(words, w)
+		}
+	}
+	card.Name = strings.Join(words, " ")
+	card.ExternalID = hashID("md", list, card.Name)
+	return card
+}
+
+// applyMetadata applies an exported "- **Key:** value" line to a card.
+func applyMetadata(card *Card, key, value string) {
+	switch strings.ToLower(key) {
+	case "labels":
+		card.Labels = splitList(value)
+	case "members":
+		for _, m := range splitList(value) {
+			card.Members = append(card.Members, strings.TrimPrefix(m, "@"))
+		}
+	case "due":
+		value, card.DueComplete = strings.CutSuffix(value, " (complete)")
+		card.Due = strings.TrimSpace(value)
+	}
+}
@@ -0,0 +1,67 @@
+package importer
+
+import (
+	"github.com/the20100/trello-cli/internal/snapshot"
+	"github.com/the20100/trello-cli/internal/strutil"
+)
+
+// FromTrello converts a Trello board export. Archived lists and cards are
+// skipped unless archived is set. External IDs are the source card IDs.
+func FromTrello(b *snapshot.Board, archived bool) *Doc {
+	d := &Doc{Name: b.Name}
+	lists := map[string]string{}
+	for _, l := range b.Lists {
+		if l.Closed && !archived {
+			continue
+		}
+		lists[l.ID] = l.Name
+		d.addList(l.Name)
+	}
+	for _, l := range b.Labels {
+		if l.Name != "" || l.Color != "" {
+			d.addLabel(Label{Name: l.Name, Color: l.Color})
+		}
+	}
+
+	for _, c := range b.Cards {
+		list, ok := lists[c.IDList]
+		if !ok || (c.Closed && !archived) {
+			continue
+		}
+		card := Card{
+			ExternalID:  strutil.FirstNonEmpty(ExternalID(c.Desc), "trello:"+c.ID),
+			List:        list,
+			Name:        c.Name,
+			Desc:        StripMarker(c.Desc),
+			DueComplete: c.DueComplete,
+		}
+		if c.Due != nil {
+			card.Due = *c.Due
+		}
+		labelIDs := c.IDLabels
+		if len(labelIDs) == 0 {
+			for _, l := range c.Labels {
+				labelIDs = append(labelIDs, l.ID)
+			}
+		}
+		for _, id := range labelIDs {
+			if l, ok := b.Label(id); ok {
+				card.Labels = append(card.Labels, strutil.FirstNonEmpty(l.Name, l.Color))
+			}
+		}
+		for _, id := range c.IDMembers {
+			if m, ok := b.Member(id); ok && m.Username != "" {
+				card.Members = append(card.Members, m.Username)
+			}
+		}
+		for _, cl := range b.CardChecklists(c.ID) {
+			ic := Checklist{Name: cl.Name}
+			for _, it := range cl.CheckItems {
+				ic.Items = append(ic.Items, Item{Name: it.Name, Complete: it.State == "complete"})
+			}
+			card.Checklists = append(card.Checklists, ic)
+		}
+		d.Cards = append(d.Cards, card)
+	}
+	return d
+}
@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/mattn/go-isatty"
+	"github.com/mattn/go-runewidth"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 // IsJSON returns true when output should be JSON:
@@ -121,6 +123,20 @@ func FormatBool(b bool) string {
 	return "no"
 }
 
+// FormatBytes formats a byte count as a human-readable size, e.g. "1.5 MB".
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
 // FormatLabels formats a slice of labels for display.
 func FormatLabels(labels []string) string {
 	if len(labels) == 0 {
@@ -129,6 +145,69 @@ func FormatLabels(labels []string) string {
 	return strings.Join(labels, ", ")
 }
 
+// TerminalWidth returns the width of the terminal attached to stdout.
+// Falls back to $COLUMNS, then 120, when stdout is not a terminal.
+func TerminalWidth() int {
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+		return w
+	}
+	var w int
+	if _, err := fmt.Sscanf(os.Getenv("COLUMNS"), "%d", &w); err == nil && w > 0 {
+		return w
+	}
+	return 120
+}
+
+// Wrap breaks s into lines of at most width terminal columns, splitting on
+// spaces where possible and hard-breaking words that are wider than width.
+// Wide characters such as emoji and CJK count as two columns.
+func Wrap(s string, width int) []string {
+	if width <= 0 {
+		return []string{s}
+	}
+	var lines []string
+	cur, curWidth := "", 0
+	for _, word := range strings.Fields(s) {
+		w := runewidth.StringWidth(word)
+		for w > width {
+			if curWidth > 0 {
+				lines = append(lines, cur)
+				cur, curWidth = "", 0
+			}
+			head := runewidth.Truncate(word, width, "")
+			if head == "" {
+				// A wide character in a one-column width.
+				head = string([]rune(word)[:1])
+			}
+			lines = append(lines, head)
+			word = word[len(head):]
+			w = runewidth.StringWidth(word)
+		}
+		switch {
+		case curWidth == 0:
+			cur, curWidth = word, w
+		case curWidth+1+w <= width:
+			cur, curWidth = cur+" "+word, curWidth+1+w
+		default:
+			lines = append(lines, cur)
+			cur, curWidth = word, w
+		}
+	}
+	if cur != "" || len(lines) == 0 {
+		lines = append(lines, cur)
+	}
+	return lines
+}
+
+// PadRight pads s with spaces to exactly width terminal columns, truncating
+// it with "…" if it is wider.
+func PadRight(s string, width int) string {
+	if runewidth.StringWidth(s) > width {
+		s = runewidth.Truncate(s, width, "…")
+	}
+	return s + strings.Repeat(" ", width-runewidth.StringWidth(s))
+}
+
 // PrintError prints an error message to stderr in a consistent format.
 func PrintError(err error) {
 	fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
@@ -0,0 +1,52 @@
+package output
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+)
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  []string
+	}{
+		{"Fix the login page", 10, []string{"Fix the", "login page"}},
+		{"abcdefghij", 4, []string{"abcd", "efgh", "ij"}},
+		{"", 10, []string{""}},
+		{"[bug] ⏰05-01 @AB", 11, []string{"[bug]", "⏰05-01 @AB"}},
+		{"[bug] ⏰05-01", 10, []string{"[bug]", "⏰05-01"}},
+		{"日本語のカード", 5, []string{"日本", "語の", "カー", "ド"}},
+		{"⏰", 1, []string{"⏰"}},
+	}
+	for _, tt := range tests {
+		got := Wrap(tt.s, tt.width)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Wrap(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestPadRight(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"abc", 5, "abc  "},
+		{"⏰05-01", 8, "⏰05-01 "},
+		{"abcdef", 4, "abc…"},
+		{"日本語", 4, "日… "},
+	}
+	for _, tt := range tests {
+		got := PadRight(tt.s, tt.width)
+		if got != tt.want {
+			t.Errorf("PadRight(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+		if w := runewidth.StringWidth(got); w != tt.width {
+			t.Errorf("PadRight(%q, %d) is %d columns wide", tt.s, tt.width, w)
+		}
+	}
+}
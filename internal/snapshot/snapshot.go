@@ -0,0 +1,142 @@
+// Package snapshot defines the JSON document a board is exported to. It has
+// the shape of Trello's own board export (Menu → Print, export, and share →
+// Export as JSON), so exports made by either can be read back.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/the20100/trello-cli/internal/api"
+)
+
+// Version is the version of the export format written by this package.
+const Version = 1
+
+// Board is a board with everything on it. Lists, cards, and checklists are
+// kept in board order.
+type Board struct {
+	api.Board
+	Lists        []api.TrelloList  `json:"lists"`
+	Cards        []api.Card        `json:"cards"`
+	Labels       []api.Label       `json:"labels"`
+	Members      []api.Member      `json:"members"`
+	Checklists   []api.Checklist   `json:"checklists"`
+	CustomFields []api.CustomField `json:"customFields"`
+	// Actions are newest first, as the API returns them.
+	Actions []api.Action `json:"actions"`
+
+	// Export metadata, absent from Trello's own exports.
+	ExportVersion int    `json:"exportVersion,omitempty"`
+	ExportedAt    string `json:"exportedAt,omitempty"`
+}
+
+// Load reads a board export.
+func Load(path string) (*Board, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse decodes a board export.
+func Parse(data []byte) (*Board, error) {
+	var b Board
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("parsing board export: %w", err)
+	}
+	if b.ID == "" && b.Name == "" && len(b.Lists) == 0 {
+		return nil, fmt.Errorf("parsing board export: not a Trello board export")
+	}
+	if b.ExportVersion > Version {
+		return nil, fmt.Errorf("board export has format version %d; this trello supports up to %d", b.ExportVersion, Version)
+	}
+	b.Sort()
+	return &b, nil
+}
+
+// Save writes the export as indented JSON.
+func (b *Board) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Sort puts lists, cards, checklists, and their items in board order.
+func (b *Board) Sort() {
+	sort.SliceStable(b.Lists, func(i, j int) bool { return b.Lists[i].Pos < b.Lists[j].Pos })
+	sort.SliceStable(b.Cards, func(i, j int) bool { return b.Cards[i].Pos < b.Cards[j].Pos })
+	sort.SliceStable(b.Checklists, func(i, j int) bool { return b.Checklists[i].Pos < b.Checklists[j].Pos })
+	for _, cl := range b.Checklists {
+		sort.SliceStable(cl.CheckItems, func(i, j int) bool { return cl.CheckItems[i].Pos < cl.CheckItems[j].Pos })
+	}
+}
+
+// ListCards returns the cards of a list, in order.
+func (b *Board) ListCards(listID string) []api.Card {
+	var cards []api.Card
+	for _, c := range b.Cards {
+		if c.IDList == listID {
+			cards = append(cards, c)
+		}
+	}
+	return cards
+}
+
+// List returns the list with the given ID.
+func (b *Board) List(id string) (api.TrelloList, bool) {
+	for _, l := range b.Lists {
+		if l.ID == id {
+			return l, true
+		}
+	}
+	return api.TrelloList{}, false
+}
+
+// CardChecklists returns the checklists of a card, in order.
+func (b *Board) CardChecklists(cardID string) []api.Checklist {
+	var out []api.Checklist
+	for _, cl := range b.Checklists {
+		if cl.IDCard == cardID {
+			out = append(out, cl)
+		}
+	}
+	return out
+}
+
+// CardComments returns the comments on a card, oldest first.
+func (b *Board) CardComments(cardID string) []api.Action {
+	var out []api.Action
+	for i := len(b.Actions) - 1; i >= 0; i-- {
+		a := b.Actions[i]
+		if a.Type == "commentCard" && a.Data.Card != nil && a.Data.Card.ID == cardID {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// Member returns the member with the given ID.
+func (b *Board) Member(id string) (api.Member, bool) {
+	for _, m := range b.Members {
+		if m.ID == id {
+			return m, true
+		}
+	}
+	return api.Member{}, false
+}
+
+// Label returns the label with the given ID.
+func (b *Board) Label(id string) (api.Label, bool) {
+	for _, l := range b.Labels {
+		if l.ID == id {
+			return l, true
+		}
+	}
+	return api.Label{}, false
+}
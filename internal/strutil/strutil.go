@@ -0,0 +1,14 @@
+// Package strutil holds small string helpers shared by the commands and the
+// internal packages.
+package strutil
+
+// FirstNonEmpty returns the first of values that is not empty, or "" if they
+// all are.
+func FirstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
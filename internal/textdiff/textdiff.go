@@ -0,0 +1,203 @@
+// Package textdiff compares and merges texts line by line.
+package textdiff
+
+import "strings"
+
+// Op is what happens to a line going from the old text to the new.
+type Op int
+
+const (
+	Equal Op = iota
+	Delete
+	Insert
+)
+
+// Line is one line of a diff.
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// Diff returns the line diff turning a into b, from a longest common
+// subsequence of their lines. Deletions come before insertions in each
+// changed run.
+func Diff(a, b string) []Line {
+	al, bl := split(a), split(b)
+	n, m := len(al), len(bl)
+	// lcs[i][j] is the length of the LCS of al[i:] and bl[j:].
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if al[i] == bl[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out []Line
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && al[i] == bl[j]:
+			out = append(out, Line{Equal, al[i]})
+			i++
+			j++
+		case j == m || (i < n && lcs[i+1][j] >= lcs[i][j+1]):
+			out = append(out, Line{Delete, al[i]})
+			i++
+		default:
+			out = append(out, Line{Insert, bl[j]})
+			j++
+		}
+	}
+	return out
+}
+
+// Unified renders the diff of a and b with "-" and "+" line prefixes,
+// keeping context unchanged lines around each change and replacing longer
+// unchanged runs with "...".
+func Unified(a, b string, context int) []string {
+	lines := Diff(a, b)
+	keep := make([]bool, len(lines))
+	for i, l := range lines {
+		if l.Op == Equal {
+			continue
+		}
+		for k := max(0, i-context); k <= min(len(lines)-1, i+context); k++ {
+			keep[k] = true
+		}
+	}
+	var out []string
+	skipped := false
+	for i, l := range lines {
+		if !keep[i] {
+			skipped = true
+			continue
+		}
+		if skipped {
+			out = append(out, "...")
+			skipped = false
+		}
+		switch l.Op {
+		case Equal:
+			out = append(out, " "+l.Text)
+		case Delete:
+			out = append(out, "-"+l.Text)
+		case Insert:
+			out = append(out, "+"+l.Text)
+		}
+	}
+	if skipped && len(out) > 0 {
+		out = append(out, "...")
+	}
+	return out
+}
+
+// split splits text into lines; an empty text has none.
+func split(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// Conflict markers written by Merge3 around conflicting changes.
+const (
+	MarkerLocal  = "<<<<<<< local"
+	MarkerSep    = "======="
+	MarkerRemote = ">>>>>>> remote"
+)
+
+// Merge3 merges the changes made to base in local and in remote. Changes to
+// different lines are combined; where both sides changed the same lines
+// differently, both versions are kept between conflict markers and
+// conflict is true.
+func Merge3(base, local, remote string) (merged string, conflict bool) {
+	bl, ll, rl := split(base), split(local), split(remote)
+	ml, mr := matches(Diff(base, local), len(bl)), matches(Diff(base, remote), len(bl))
+
+	var out []string
+	i, j, k := 0, 0, 0
+	for {
+		// Copy the run of base lines kept in place on both sides.
+		for i < len(bl) && ml[i] == j && mr[i] == k {
+			out = append(out, bl[i])
+			i, j, k = i+1, j+1, k+1
+		}
+		if i == len(bl) && j == len(ll) && k == len(rl) {
+			break
+		}
+		// The changed chunk runs to the next base line kept on both sides.
+		next := i
+		for next < len(bl) && (ml[next] < 0 || mr[next] < 0) {
+			next++
+		}
+		nj, nk := len(ll), len(rl)
+		if next < len(bl) {
+			nj, nk = ml[next], mr[next]
+		}
+		b, l, r := bl[i:next], ll[j:nj], rl[k:nk]
+		switch {
+		case equalLines(l, b):
+			out = append(out, r...)
+		case equalLines(r, b), equalLines(l, r):
+			out = append(out, l...)
+		default:
+			conflict = true
+			out = append(out, MarkerLocal)
+			out = append(out, l...)
+			out = append(out, MarkerSep)
+			out = append(out, r...)
+			out = append(out, MarkerRemote)
+		}
+		i, j, k = next, nj, nk
+	}
+	return strings.Join(out, "\n"), conflict
+}
+
+// HasConflict reports whether text contains Merge3 conflict markers.
+func HasConflict(text string) bool {
+	for _, line := range split(text) {
+		if line == MarkerLocal || line == MarkerRemote {
+			return true
+		}
+	}
+	return false
+}
+
+// matches returns, for each of the n base lines, the index of the line it
+// is kept as in the other text of diff, or -1 when it was deleted.
+func matches(diff []Line, n int) []int {
+	m := make([]int, n)
+	i, j := 0, 0
+	for _, l := range diff {
+		switch l.Op {
+		case Equal:
+			m[i] = j
+			i, j = i+1, j+1
+		case Delete:
+			m[i] = -1
+			i++
+		case Insert:
+			j++
+		}
+	}
+	return m
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,101 @@
+package textdiff
+
+import "testing"
+
+func TestMerge3(t *testing.T) {
+	conflict := func(local, remote string) string {
+		return MarkerLocal + "\n" + local + "\n" + MarkerSep + "\n" + remote + "\n" + MarkerRemote
+	}
+	tests := []struct {
+		name                string
+		base, local, remote string
+		want                string
+		wantConflict        bool
+	}{
+		{
+			name: "no changes",
+			base: "a\nb\nc", local: "a\nb\nc", remote: "a\nb\nc",
+			want: "a\nb\nc",
+		},
+		{
+			name: "local change only",
+			base: "a\nb\nc", local: "a\nB\nc", remote: "a\nb\nc",
+			want: "a\nB\nc",
+		},
+		{
+			name: "remote change only",
+			base: "a\nb\nc", local: "a\nb\nc", remote: "a\nb\nC",
+			want: "a\nb\nC",
+		},
+		{
+			name: "disjoint edits",
+			base: "a\nb\nc\nd\ne", local: "a\nB\nc\nd\ne", remote: "a\nb\nc\nD\ne",
+			want: "a\nB\nc\nD\ne",
+		},
+		{
+			name: "disjoint insert and delete",
+			base: "a\nb\nc\nd", local: "a\nnew\nb\nc\nd", remote: "a\nb\nc",
+			want: "a\nnew\nb\nc",
+		},
+		{
+			name: "same edit on both sides",
+			base: "a\nb\nc", local: "a\nB\nc", remote: "a\nB\nc",
+			want: "a\nB\nc",
+		},
+		{
+			name: "conflicting edits",
+			base: "a\nb\nc", local: "a\nL\nc", remote: "a\nR\nc",
+			want:         "a\n" + conflict("L", "R") + "\nc",
+			wantConflict: true,
+		},
+		{
+			name: "edit against delete",
+			base: "a\nb\nc", local: "a\nL\nc", remote: "a\nc",
+			want:         "a\n" + MarkerLocal + "\nL\n" + MarkerSep + "\n" + MarkerRemote + "\nc",
+			wantConflict: true,
+		},
+		{
+			name: "insert at EOF",
+			base: "a\nb", local: "a\nb\nc", remote: "A\nb",
+			want: "A\nb\nc",
+		},
+		{
+			name: "different inserts at EOF",
+			base: "a", local: "a\nL", remote: "a\nR",
+			want:         "a\n" + conflict("L", "R"),
+			wantConflict: true,
+		},
+		{
+			name: "empty base, one side adds",
+			base: "", local: "x\ny", remote: "",
+			want: "x\ny",
+		},
+		{
+			name: "empty base, same text on both sides",
+			base: "", local: "x", remote: "x",
+			want: "x",
+		},
+		{
+			name: "empty base, different texts",
+			base: "", local: "x", remote: "y",
+			want:         conflict("x", "y"),
+			wantConflict: true,
+		},
+		{
+			name: "trailing newline is ignored",
+			base: "a\nb\n", local: "a\nB\n", remote: "a\nb",
+			want: "a\nB",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotConflict := Merge3(tt.base, tt.local, tt.remote)
+			if got != tt.want || gotConflict != tt.wantConflict {
+				t.Errorf("Merge3() = %q, %v; want %q, %v", got, gotConflict, tt.want, tt.wantConflict)
+			}
+			if HasConflict(got) != tt.wantConflict {
+				t.Errorf("HasConflict() = %v, want %v", !tt.wantConflict, tt.wantConflict)
+			}
+		})
+	}
+}
@@ -0,0 +1,741 @@
+// Package tui implements the full-screen interactive board browser behind
+// "trello tui". It talks to Trello through the Backend interface, which
+// *api.Client satisfies, and draws on any tcell.Screen so it can be driven
+// headlessly with tcell.NewSimulationScreen.
+package tui
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+	"github.com/the20100/trello-cli/internal/api"
+)
+
+// Backend is the subset of api.Client used by the TUI.
+type Backend interface {
+	GetMyBoards(filter string) ([]api.Board, error)
+	GetBoard(id string, params url.Values) (*api.Board, error)
+	GetBoardLists(boardID, filter string) ([]api.TrelloList, error)
+	GetListCards(listID, filter string) ([]api.Card, error)
+	GetCard(id string, params url.Values) (*api.Card, error)
+	GetCardChecklists(cardID string) ([]api.Checklist, error)
+	GetCardAttachments(cardID string) ([]api.Attachment, error)
+	GetCardComments(cardID string) ([]api.Action, error)
+	MoveCard(id, idList, idBoard string) (*api.Card, error)
+	UpdateCheckItem(cardID, checklistID, checkItemID, state string) (*api.CheckItem, error)
+	AddComment(cardID, text string) (*api.Action, error)
+}
+
+type view int
+
+const (
+	viewBoards view = iota
+	viewLists
+	viewCards
+	viewCard
+	viewMove
+)
+
+// row is one line of a view. Rows with a non-nil item are check items that
+// can be toggled from the card view.
+type row struct {
+	text      string
+	style     tcell.Style
+	skip      bool // not selectable
+	item      *api.CheckItem
+	checklist string
+}
+
+// prompt is an active single-line text input.
+type prompt struct {
+	label    string
+	text     []rune
+	onSubmit func(string)
+}
+
+// App is the TUI state machine.
+type App struct {
+	screen  tcell.Screen
+	backend Backend
+
+	view   view
+	cursor map[view]int
+	scroll map[view]int
+
+	boards []api.Board
+	lists  []api.TrelloList
+	cards  []api.Card
+
+	board api.Board
+	list  api.TrelloList
+	card  *cardDetail
+
+	prompt *prompt
+	status string
+	quit   bool
+}
+
+// cardDetail is everything shown on the card view.
+type cardDetail struct {
+	card        api.Card
+	checklists  []api.Checklist
+	comments    []api.Action
+	attachments []api.Attachment
+}
+
+var (
+	styleDefault  = tcell.StyleDefault
+	styleHeader   = tcell.StyleDefault.Bold(true).Reverse(true)
+	styleSelected = tcell.StyleDefault.Reverse(true)
+	styleTitle    = tcell.StyleDefault.Bold(true)
+	styleDim      = tcell.StyleDefault.Dim(true)
+	styleError    = tcell.StyleDefault.Foreground(tcell.ColorRed)
+)
+
+const helpText = "↑↓ move  ⏎/→ open  ←/esc back  [ ] move card  m move to…  space toggle  c comment  r refresh  q quit"
+
+// New returns an App that draws on screen and reads data from backend.
+func New(screen tcell.Screen, backend Backend) *App {
+	return &App{
+		screen:  screen,
+		backend: backend,
+		cursor:  map[view]int{},
+		scroll:  map[view]int{},
+	}
+}
+
+// Run initialises the screen, loads the starting view, and processes events
+// until the user quits. If boardID is non-empty the TUI opens on that board.
+func (a *App) Run(boardID string) error {
+	if err := a.screen.Init(); err != nil {
+		return err
+	}
+	defer a.screen.Fini()
+
+	a.Start(boardID)
+	for !a.quit {
+		a.Draw()
+		switch ev := a.screen.PollEvent().(type) {
+		case nil:
+			return nil
+		case *tcell.EventResize:
+			a.screen.Sync()
+		case *tcell.EventKey:
+			a.HandleKey(ev)
+		}
+	}
+	return nil
+}
+
+// Start loads the initial view without entering the event loop.
+func (a *App) Start(boardID string) {
+	if boardID == "" {
+		a.loadBoards()
+		return
+	}
+	b, err := a.backend.GetBoard(boardID, nil)
+	if err != nil {
+		a.fail(err)
+		a.loadBoards()
+		return
+	}
+	a.board = *b
+	a.loadLists()
+}
+
+// Quit reports whether the user asked to exit.
+func (a *App) Quit() bool { return a.quit }
+
+// ---- loading ----
+
+func (a *App) loadBoards() {
+	boards, err := a.backend.GetMyBoards("open")
+	if err != nil {
+		a.fail(err)
+		return
+	}
+	a.boards = boards
+	a.setView(viewBoards)
+}
+
+func (a *App) loadLists() {
+	lists, err := a.backend.GetBoardLists(a.board.ID, "open")
+	if err != nil {
+		a.fail(err)
+		return
+	}
+	sort.SliceStable(lists, func(i, j int) bool { return lists[i].Pos < lists[j].Pos })
+	a.lists = lists
+	a.setView(viewLists)
+}
+
+func (a *App) loadCards() {
+	cards, err := a.backend.GetListCards(a.list.ID, "open")
+	if err != nil {
+		a.fail(err)
+		return
+	}
+	sort.SliceStable(cards, func(i, j int) bool { return cards[i].Pos < cards[j].Pos })
+	a.cards = cards
+	a.setView(viewCards)
+}
+
+func (a *App) loadCard(id string) {
+	card, err := a.backend.GetCard(id, nil)
+	if err != nil {
+		a.fail(err)
+		return
+	}
+	d := &cardDetail{card: *card}
+	if d.checklists, err = a.backend.GetCardChecklists(id); err != nil {
+		a.fail(err)
+		return
+	}
+	if d.comments, err = a.backend.GetCardComments(id); err != nil {
+		a.fail(err)
+		return
+	}
+	if d.attachments, err = a.backend.GetCardAttachments(id); err != nil {
+		a.fail(err)
+		return
+	}
+	a.card = d
+	a.setView(viewCard)
+}
+
+func (a *App) refresh() {
+	switch a.view {
+	case viewBoards:
+		a.loadBoards()
+	case viewLists:
+		a.loadLists()
+	case viewCards:
+		a.loadCards()
+	case viewCard:
+		a.loadCard(a.card.card.ID)
+	}
+	if a.status == "" {
+		a.status = "Refreshed."
+	}
+}
+
+// setView switches to v, keeping the cursor within bounds.
+func (a *App) setView(v view) {
+	if a.view != v {
+		a.scroll[v] = 0
+	}
+	a.view = v
+	a.clampCursor()
+}
+
+func (a *App) fail(err error) {
+	a.status = "Error: " + err.Error()
+}
+
+// ---- key handling ----
+
+// HandleKey applies a single key event to the app state.
+func (a *App) HandleKey(ev *tcell.EventKey) {
+	if a.prompt != nil {
+		a.handlePromptKey(ev)
+		return
+	}
+	a.status = ""
+
+	switch ev.Key() {
+	case tcell.KeyCtrlC:
+		a.quit = true
+	case tcell.KeyUp:
+		a.moveCursor(-1)
+	case tcell.KeyDown:
+		a.moveCursor(1)
+	case tcell.KeyPgUp:
+		a.moveCursor(-10)
+	case tcell.KeyPgDn:
+		a.moveCursor(10)
+	case tcell.KeyEnter, tcell.KeyRight:
+		a.open()
+	case tcell.KeyLeft, tcell.KeyEscape, tcell.KeyBackspace, tcell.KeyBackspace2:
+		a.back()
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case 'q':
+			a.quit = true
+		case 'k':
+			a.moveCursor(-1)
+		case 'j':
+			a.moveCursor(1)
+		case 'r':
+			a.refresh()
+		case ' ':
+			a.toggleCheckItem()
+		case '[':
+			a.shiftCard(-1)
+		case ']':
+			a.shiftCard(1)
+		case 'm':
+			a.startMove()
+		case 'c':
+			a.startComment()
+		}
+	}
+}
+
+func (a *App) handlePromptKey(ev *tcell.EventKey) {
+	p := a.prompt
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyCtrlC:
+		a.prompt = nil
+		a.status = "Cancelled."
+	case tcell.KeyEnter:
+		a.prompt = nil
+		p.onSubmit(string(p.text))
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if len(p.text) > 0 {
+			p.text = p.text[:len(p.text)-1]
+		}
+	case tcell.KeyRune:
+		p.text = append(p.text, ev.Rune())
+	}
+}
+
+func (a *App) open() {
+	i := a.cursor[a.view]
+	switch a.view {
+	case viewBoards:
+		if i < len(a.boards) {
+			a.board = a.boards[i]
+			a.loadLists()
+		}
+	case viewLists:
+		if i < len(a.lists) {
+			a.list = a.lists[i]
+			a.loadCards()
+		}
+	case viewCards:
+		if i < len(a.cards) {
+			a.loadCard(a.cards[i].ID)
+		}
+	case viewCard:
+		a.toggleCheckItem()
+	case viewMove:
+		if i < len(a.lists) {
+			a.moveCardTo(a.lists[i])
+		}
+	}
+}
+
+func (a *App) back() {
+	switch a.view {
+	case viewLists:
+		a.loadBoards()
+	case viewCards:
+		a.setView(viewLists)
+	case viewCard:
+		a.loadCards()
+	case viewMove:
+		a.setView(viewCard)
+	}
+}
+
+func (a *App) moveCursor(delta int) {
+	rows := a.rows()
+	i := a.cursor[a.view]
+	step := 1
+	if delta < 0 {
+		step = -1
+	}
+	for n := 0; n < abs(delta); n++ {
+		next := i + step
+		for next >= 0 && next < len(rows) && rows[next].skip {
+			next += step
+		}
+		if next < 0 || next >= len(rows) {
+			break
+		}
+		i = next
+	}
+	a.cursor[a.view] = i
+}
+
+func (a *App) clampCursor() {
+	rows := a.rows()
+	i := a.cursor[a.view]
+	if i >= len(rows) {
+		i = len(rows) - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	for i < len(rows) && rows[i].skip {
+		i++
+	}
+	if i >= len(rows) {
+		i = 0
+	}
+	a.cursor[a.view] = i
+}
+
+// ---- actions ----
+
+func (a *App) toggleCheckItem() {
+	if a.view != viewCard {
+		return
+	}
+	rows := a.rows()
+	i := a.cursor[viewCard]
+	if i >= len(rows) || rows[i].item == nil {
+		return
+	}
+	item := rows[i].item
+	state := "complete"
+	if item.State == "complete" {
+		state = "incomplete"
+	}
+	if _, err := a.backend.UpdateCheckItem(a.card.card.ID, rows[i].checklist, item.ID, state); err != nil {
+		a.fail(err)
+		return
+	}
+	item.State = state
+	a.status = fmt.Sprintf("Marked %q %s.", item.Name, state)
+}
+
+// shiftCard moves the selected card to the neighbouring list in board order.
+func (a *App) shiftCard(delta int) {
+	if a.view != viewCards && a.view != viewCard {
+		return
+	}
+	idx := -1
+	for i, l := range a.lists {
+		if l.ID == a.list.ID {
+			idx = i
+		}
+	}
+	target := idx + delta
+	if idx < 0 || target < 0 || target >= len(a.lists) {
+		a.status = "No list in that direction."
+		return
+	}
+	a.moveCardTo(a.lists[target])
+}
+
+func (a *App) startMove() {
+	if a.view != viewCards && a.view != viewCard {
+		return
+	}
+	if a.view == viewCards && len(a.cards) == 0 {
+		return
+	}
+	if a.view == viewCards {
+		a.loadCard(a.cards[a.cursor[viewCards]].ID)
+		if a.view != viewCard {
+			return
+		}
+	}
+	for i, l := range a.lists {
+		if l.ID == a.card.card.IDList {
+			a.cursor[viewMove] = i
+		}
+	}
+	a.setView(viewMove)
+}
+
+func (a *App) moveCardTo(dest api.TrelloList) {
+	var cardID, name string
+	switch {
+	case a.view == viewCards && a.cursor[viewCards] < len(a.cards):
+		c := a.cards[a.cursor[viewCards]]
+		cardID, name = c.ID, c.Name
+	case a.card != nil:
+		cardID, name = a.card.card.ID, a.card.card.Name
+	default:
+		return
+	}
+	fromCards := a.view == viewCards
+
+	if _, err := a.backend.MoveCard(cardID, dest.ID, ""); err != nil {
+		a.fail(err)
+		return
+	}
+	status := fmt.Sprintf("Moved %q to %s.", name, dest.Name)
+	if fromCards {
+		a.loadCards()
+	} else {
+		a.list = dest
+		a.loadCard(cardID)
+	}
+	if !strings.HasPrefix(a.status, "Error") {
+		a.status = status
+	}
+}
+
+func (a *App) startComment() {
+	var cardID string
+	switch {
+	case a.view == viewCard:
+		cardID = a.card.card.ID
+	case a.view == viewCards && a.cursor[viewCards] < len(a.cards):
+		cardID = a.cards[a.cursor[viewCards]].ID
+	default:
+		return
+	}
+	a.prompt = &prompt{
+		label: "Comment: ",
+		onSubmit: func(text string) {
+			if strings.TrimSpace(text) == "" {
+				a.status = "Empty comment discarded."
+				return
+			}
+			if _, err := a.backend.AddComment(cardID, text); err != nil {
+				a.fail(err)
+				return
+			}
+			if a.view == viewCard {
+				a.loadCard(cardID)
+			}
+			a.status = "Comment added."
+		},
+	}
+}
+
+// ---- rendering ----
+
+// rows returns the lines of the current view.
+func (a *App) rows() []row {
+	switch a.view {
+	case viewBoards:
+		rows := make([]row, len(a.boards))
+		for i, b := range a.boards {
+			rows[i] = row{text: b.Name}
+		}
+		return rows
+	case viewLists, viewMove:
+		rows := make([]row, len(a.lists))
+		for i, l := range a.lists {
+			text := l.Name
+			if a.view == viewMove && a.card != nil && l.ID == a.card.card.IDList {
+				text += "  (current)"
+			}
+			rows[i] = row{text: text}
+		}
+		return rows
+	case viewCards:
+		rows := make([]row, len(a.cards))
+		for i, c := range a.cards {
+			rows[i] = row{text: cardLine(c)}
+		}
+		return rows
+	case viewCard:
+		return a.cardRows()
+	}
+	return nil
+}
+
+func (a *App) cardRows() []row {
+	if a.card == nil {
+		return nil
+	}
+	c := a.card.card
+	var rows []row
+	add := func(text string, style tcell.Style) {
+		rows = append(rows, row{text: text, style: style, skip: true})
+	}
+
+	add(c.Name, styleTitle)
+	if meta := cardMeta(c); meta != "" {
+		add(meta, styleDim)
+	}
+	add(c.ShortURL, styleDim)
+	add("", styleDefault)
+
+	add("Description", styleTitle)
+	if c.Desc == "" {
+		add("  (none)", styleDim)
+	}
+	for _, line := range strings.Split(c.Desc, "\n") {
+		if c.Desc != "" {
+			add("  "+line, styleDefault)
+		}
+	}
+
+	for _, cl := range a.card.checklists {
+		add("", styleDefault)
+		add(fmt.Sprintf("Checklist: %s", cl.Name), styleTitle)
+		for j := range cl.CheckItems {
+			item := &cl.CheckItems[j]
+			rows = append(rows, row{item: item, checklist: cl.ID})
+		}
+	}
+
+	add("", styleDefault)
+	add(fmt.Sprintf("Comments (%d)", len(a.card.comments)), styleTitle)
+	for _, cm := range a.card.comments {
+		author := cm.IDMemberCreator
+		if cm.MemberCreator != nil {
+			author = cm.MemberCreator.FullName
+		}
+		add(fmt.Sprintf("  %s — %s", author, shortDate(cm.Date)), styleDim)
+		for _, line := range strings.Split(cm.Data.Text, "\n") {
+			add("    "+line, styleDefault)
+		}
+	}
+
+	add("", styleDefault)
+	add(fmt.Sprintf("Attachments (%d)", len(a.card.attachments)), styleTitle)
+	for _, at := range a.card.attachments {
+		add(fmt.Sprintf("  %s  %s", at.Name, at.URL), styleDefault)
+	}
+	return rows
+}
+
+// Draw renders the current state to the screen.
+func (a *App) Draw() {
+	s := a.screen
+	s.Clear()
+	w, h := s.Size()
+	if w <= 0 || h <= 0 {
+		return
+	}
+
+	drawLine(s, 0, w, styleHeader, " "+a.breadcrumb())
+
+	rows := a.rows()
+	bodyTop, bodyHeight := 1, h-3
+	if bodyHeight < 1 {
+		bodyHeight = 1
+	}
+	cur := a.cursor[a.view]
+	off := a.scroll[a.view]
+	if cur < off {
+		off = cur
+	}
+	if cur >= off+bodyHeight {
+		off = cur - bodyHeight + 1
+	}
+	a.scroll[a.view] = off
+
+	if len(rows) == 0 {
+		drawText(s, 1, bodyTop, w-1, styleDim, "(empty)")
+	}
+	for i := off; i < len(rows) && i-off < bodyHeight; i++ {
+		r := rows[i]
+		text, style := r.text, r.style
+		if r.item != nil {
+			mark := "[ ]"
+			if r.item.State == "complete" {
+				mark = "[x]"
+			}
+			text = "  " + mark + " " + r.item.Name
+		}
+		if i == cur && !r.skip {
+			style = styleSelected
+			drawLine(s, bodyTop+i-off, w, style, " "+text)
+			continue
+		}
+		drawText(s, 1, bodyTop+i-off, w-1, style, text)
+	}
+
+	if a.prompt != nil {
+		line := a.prompt.label + string(a.prompt.text)
+		drawLine(s, h-2, w, styleDefault, line)
+		s.ShowCursor(runewidth.StringWidth(line), h-2)
+	} else {
+		s.HideCursor()
+		style := styleDim
+		if strings.HasPrefix(a.status, "Error") {
+			style = styleError
+		}
+		drawLine(s, h-2, w, style, a.status)
+	}
+	drawLine(s, h-1, w, styleHeader, " "+helpText)
+	s.Show()
+}
+
+func (a *App) breadcrumb() string {
+	parts := []string{"Boards"}
+	if a.view >= viewLists {
+		parts = append(parts, a.board.Name)
+	}
+	if a.view >= viewCards {
+		parts = append(parts, a.list.Name)
+	}
+	if a.view >= viewCard && a.card != nil {
+		parts = append(parts, a.card.card.Name)
+	}
+	if a.view == viewMove {
+		parts = append(parts, "Move to list…")
+	}
+	return strings.Join(parts, " › ")
+}
+
+func cardLine(c api.Card) string {
+	if meta := cardMeta(c); meta != "" {
+		return c.Name + "  " + meta
+	}
+	return c.Name
+}
+
+func cardMeta(c api.Card) string {
+	var parts []string
+	for _, l := range c.Labels {
+		name := l.Name
+		if name == "" {
+			name = l.Color
+		}
+		parts = append(parts, "["+name+"]")
+	}
+	if c.Due != nil && *c.Due != "" {
+		mark := "due "
+		if c.DueComplete {
+			mark = "done "
+		}
+		parts = append(parts, mark+shortDate(*c.Due))
+	}
+	if n := c.Badges.CheckItems; n > 0 {
+		parts = append(parts, fmt.Sprintf("☑ %d/%d", c.Badges.CheckItemsChecked, n))
+	}
+	if n := c.Badges.Comments; n > 0 {
+		parts = append(parts, fmt.Sprintf("✎ %d", n))
+	}
+	return strings.Join(parts, " ")
+}
+
+func shortDate(s string) string {
+	if len(s) >= 10 {
+		return s[:10]
+	}
+	return s
+}
+
+// drawText writes s at (x, y), clipped to maxWidth cells.
+func drawText(s tcell.Screen, x, y, maxWidth int, style tcell.Style, text string) int {
+	col := 0
+	for _, r := range text {
+		rw := runewidth.RuneWidth(r)
+		if col+rw > maxWidth {
+			break
+		}
+		s.SetContent(x+col, y, r, nil, style)
+		col += rw
+	}
+	return col
+}
+
+// drawLine fills row y with style and writes text on it.
+func drawLine(s tcell.Screen, y, width int, style tcell.Style, text string) {
+	n := drawText(s, 0, y, width, style, text)
+	for x := n; x < width; x++ {
+		s.SetContent(x, y, ' ', nil, style)
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
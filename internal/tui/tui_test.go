@@ -0,0 +1,294 @@
+package tui
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/the20100/trello-cli/internal/api"
+)
+
+// fakeBackend serves a small board from memory and records every change.
+type fakeBackend struct {
+	boards     []api.Board
+	lists      map[string][]api.TrelloList // by board ID
+	cards      map[string]*api.Card
+	checklists map[string][]api.Checklist // by card ID
+	calls      []string
+}
+
+func newFakeBackend() *fakeBackend {
+	f := &fakeBackend{
+		boards: []api.Board{{ID: "b1", Name: "Alpha"}, {ID: "b2", Name: "Beta"}},
+		lists: map[string][]api.TrelloList{
+			"b2": {
+				{ID: "l3", Name: "Done", IDBoard: "b2", Pos: 3},
+				{ID: "l1", Name: "To Do", IDBoard: "b2", Pos: 1},
+				{ID: "l2", Name: "Doing", IDBoard: "b2", Pos: 2},
+			},
+		},
+		cards: map[string]*api.Card{
+			"c1": {ID: "c1", Name: "Write spec", IDList: "l1", Pos: 1},
+			"c2": {ID: "c2", Name: "Fix login", IDList: "l1", Pos: 2},
+		},
+		checklists: map[string][]api.Checklist{
+			"c2": {{ID: "cl1", Name: "QA", IDCard: "c2", CheckItems: []api.CheckItem{
+				{ID: "i1", Name: "Reproduce", State: "incomplete"},
+				{ID: "i2", Name: "Patch", State: "incomplete"},
+			}}},
+		},
+	}
+	return f
+}
+
+func (f *fakeBackend) GetMyBoards(filter string) ([]api.Board, error) { return f.boards, nil }
+
+func (f *fakeBackend) GetBoard(id string, params url.Values) (*api.Board, error) {
+	for _, b := range f.boards {
+		if b.ID == id {
+			return &b, nil
+		}
+	}
+	return nil, fmt.Errorf("no board %s", id)
+}
+
+func (f *fakeBackend) GetBoardLists(boardID, filter string) ([]api.TrelloList, error) {
+	return append([]api.TrelloList(nil), f.lists[boardID]...), nil
+}
+
+func (f *fakeBackend) GetListCards(listID, filter string) ([]api.Card, error) {
+	var cards []api.Card
+	for _, c := range f.cards {
+		if c.IDList == listID {
+			cards = append(cards, *c)
+		}
+	}
+	return cards, nil
+}
+
+func (f *fakeBackend) GetCard(id string, params url.Values) (*api.Card, error) {
+	c, ok := f.cards[id]
+	if !ok {
+		return nil, fmt.Errorf("no card %s", id)
+	}
+	cp := *c
+	return &cp, nil
+}
+
+func (f *fakeBackend) GetCardChecklists(cardID string) ([]api.Checklist, error) {
+	var out []api.Checklist
+	for _, cl := range f.checklists[cardID] {
+		cl.CheckItems = append([]api.CheckItem(nil), cl.CheckItems...)
+		out = append(out, cl)
+	}
+	return out, nil
+}
+
+func (f *fakeBackend) GetCardAttachments(cardID string) ([]api.Attachment, error) { return nil, nil }
+
+func (f *fakeBackend) GetCardComments(cardID string) ([]api.Action, error) { return nil, nil }
+
+func (f *fakeBackend) MoveCard(id, idList, idBoard string) (*api.Card, error) {
+	f.calls = append(f.calls, fmt.Sprintf("MoveCard %s %s", id, idList))
+	f.cards[id].IDList = idList
+	return f.cards[id], nil
+}
+
+func (f *fakeBackend) UpdateCheckItem(cardID, checklistID, checkItemID, state string) (*api.CheckItem, error) {
+	f.calls = append(f.calls, fmt.Sprintf("UpdateCheckItem %s %s %s %s", cardID, checklistID, checkItemID, state))
+	for i, cl := range f.checklists[cardID] {
+		for j, it := range cl.CheckItems {
+			if cl.ID == checklistID && it.ID == checkItemID {
+				f.checklists[cardID][i].CheckItems[j].State = state
+				return &f.checklists[cardID][i].CheckItems[j], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no check item %s", checkItemID)
+}
+
+func (f *fakeBackend) AddComment(cardID, text string) (*api.Action, error) {
+	f.calls = append(f.calls, fmt.Sprintf("AddComment %s %s", cardID, text))
+	return &api.Action{}, nil
+}
+
+// harness runs an App on a simulated terminal.
+type harness struct {
+	t      *testing.T
+	screen tcell.SimulationScreen
+	app    *App
+}
+
+func newHarness(t *testing.T, backend Backend) *harness {
+	t.Helper()
+	s := tcell.NewSimulationScreen("UTF-8")
+	if err := s.Init(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.Fini)
+	s.SetSize(100, 30)
+	h := &harness{t: t, screen: s, app: New(s, backend)}
+	h.app.Start("")
+	h.app.Draw()
+	return h
+}
+
+// press injects keys into the terminal and handles each event the way
+// Run does, redrawing after every key.
+func (h *harness) press(keys ...any) {
+	h.t.Helper()
+	for _, k := range keys {
+		switch k := k.(type) {
+		case tcell.Key:
+			h.screen.InjectKey(k, 0, tcell.ModNone)
+		case rune:
+			h.screen.InjectKey(tcell.KeyRune, k, tcell.ModNone)
+		case string:
+			for _, r := range k {
+				h.screen.InjectKey(tcell.KeyRune, r, tcell.ModNone)
+				h.app.HandleKey(h.screen.PollEvent().(*tcell.EventKey))
+			}
+			h.app.Draw()
+			continue
+		}
+		h.app.HandleKey(h.screen.PollEvent().(*tcell.EventKey))
+		h.app.Draw()
+	}
+}
+
+// line returns the text of screen row y.
+func (h *harness) line(y int) string {
+	cells, w, _ := h.screen.GetContents()
+	var b strings.Builder
+	for _, c := range cells[y*w : (y+1)*w] {
+		b.WriteString(string(c.Runes))
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// contains reports whether some screen row contains text.
+func (h *harness) contains(text string) bool {
+	_, _, rows := h.screen.GetContents()
+	for y := 0; y < rows; y++ {
+		if strings.Contains(h.line(y), text) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *harness) wantHeader(want string) {
+	h.t.Helper()
+	if got := h.line(0); got != want {
+		h.t.Fatalf("header = %q, want %q", got, want)
+	}
+}
+
+func TestNavigateMoveAndToggle(t *testing.T) {
+	backend := newFakeBackend()
+	h := newHarness(t, backend)
+	h.wantHeader("Boards")
+
+	// Board → list → card.
+	h.press(tcell.KeyDown, tcell.KeyEnter)
+	h.wantHeader("Boards › Beta")
+	if got := h.line(1); got != "To Do" {
+		t.Errorf("first list = %q, want lists in board order", got)
+	}
+	h.press(tcell.KeyEnter)
+	h.wantHeader("Boards › Beta › To Do")
+	h.press('j', tcell.KeyRight)
+	h.wantHeader("Boards › Beta › To Do › Fix login")
+
+	// The cursor starts on the first check item; toggle it and the next.
+	h.press(' ')
+	if !h.contains("[x] Reproduce") || !h.contains("[ ] Patch") {
+		t.Error("checked item not redrawn")
+	}
+	h.press(tcell.KeyDown, ' ')
+
+	// ] moves the card to the next list and follows it.
+	h.press(']')
+	h.wantHeader("Boards › Beta › Doing › Fix login")
+	if !h.contains(`Moved "Fix login" to Doing.`) {
+		t.Error("no move status")
+	}
+
+	// Back to the cards of Doing, then move the card through the picker.
+	h.press(tcell.KeyEscape)
+	h.wantHeader("Boards › Beta › Doing")
+	h.press('m')
+	h.wantHeader("Boards › Beta › Doing › Fix login › Move to list…")
+	if !h.contains("Doing  (current)") {
+		t.Error("current list not marked in the move picker")
+	}
+	h.press(tcell.KeyDown, tcell.KeyEnter)
+	h.wantHeader("Boards › Beta › Done › Fix login")
+
+	// Comment through the prompt.
+	h.press('c', "Ship it", tcell.KeyEnter)
+	if !h.contains("Comment added.") {
+		t.Error("no comment status")
+	}
+
+	h.press('q')
+	if !h.app.Quit() {
+		t.Error("q did not quit")
+	}
+
+	want := []string{
+		"UpdateCheckItem c2 cl1 i1 complete",
+		"UpdateCheckItem c2 cl1 i2 complete",
+		"MoveCard c2 l2",
+		"MoveCard c2 l3",
+		"AddComment c2 Ship it",
+	}
+	if !reflect.DeepEqual(backend.calls, want) {
+		t.Errorf("backend calls:\n%s\nwant:\n%s", strings.Join(backend.calls, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestShiftCardFromCardsView(t *testing.T) {
+	backend := newFakeBackend()
+	h := newHarness(t, backend)
+	h.press(tcell.KeyDown, tcell.KeyEnter, tcell.KeyEnter)
+	h.wantHeader("Boards › Beta › To Do")
+
+	// [ at the first list has nowhere to go.
+	h.press('[')
+	if !h.contains("No list in that direction.") {
+		t.Error("no status for a move past the first list")
+	}
+	// ] moves the selected card and stays on the list.
+	h.press(']')
+	h.wantHeader("Boards › Beta › To Do")
+	if got := h.line(1); !strings.Contains(got, "Fix login") {
+		t.Errorf("first card = %q, want the card left behind", got)
+	}
+	if got := h.line(2); got != "" {
+		t.Errorf("second row = %q, want the moved card gone", got)
+	}
+	if !h.contains(`Moved "Write spec" to Doing.`) {
+		t.Error("no move status")
+	}
+
+	want := []string{"MoveCard c1 l2"}
+	if !reflect.DeepEqual(backend.calls, want) {
+		t.Errorf("backend calls = %q, want %q", backend.calls, want)
+	}
+}
+
+func TestCancelPrompt(t *testing.T) {
+	backend := newFakeBackend()
+	h := newHarness(t, backend)
+	h.press(tcell.KeyDown, tcell.KeyEnter, tcell.KeyEnter, 'c', "draft", tcell.KeyEscape)
+	if !h.contains("Cancelled.") {
+		t.Error("no status after cancelling")
+	}
+	if len(backend.calls) != 0 {
+		t.Errorf("backend calls = %q, want none", backend.calls)
+	}
+}
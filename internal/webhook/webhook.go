@@ -0,0 +1,128 @@
+// Package webhook receives Trello webhook deliveries: it answers Trello's
+// HEAD verification request, checks the X-Trello-Webhook signature, and
+// decodes payloads into api.Action values.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/the20100/trello-cli/internal/api"
+)
+
+// SignatureHeader is the header Trello uses to sign webhook deliveries.
+const SignatureHeader = "X-Trello-Webhook"
+
+// maxBodySize bounds the size of an accepted payload.
+const maxBodySize = 10 << 20
+
+// Event is a decoded webhook delivery.
+type Event struct {
+	Action  api.Action      `json:"action"`
+	Model   json.RawMessage `json:"model"`
+	Webhook json.RawMessage `json:"webhook,omitempty"`
+}
+
+// Sign returns the X-Trello-Webhook signature for body delivered to
+// callbackURL: base64(HMAC-SHA1(secret, body + callbackURL)).
+func Sign(secret string, body []byte, callbackURL string) string {
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(body)
+	mac.Write([]byte(callbackURL))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is valid for body delivered to callbackURL.
+func Verify(secret string, body []byte, callbackURL, signature string) bool {
+	want := Sign(secret, body, callbackURL)
+	return hmac.Equal([]byte(want), []byte(signature))
+}
+
+// Handler is an http.Handler for Trello webhook deliveries.
+type Handler struct {
+	// Secret is the Trello application secret. When empty, signatures are not checked.
+	Secret string
+	// CallbackURL is the URL registered with Trello. When empty it is
+	// reconstructed from the request, honouring X-Forwarded-Proto/Host.
+	CallbackURL string
+	// OnEvent is called for every verified delivery with the decoded event
+	// and the raw request body.
+	OnEvent func(ev Event, raw []byte)
+	// OnError, when set, is called for rejected deliveries.
+	OnError func(r *http.Request, err error)
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodHead, http.MethodGet:
+		// Trello sends HEAD when the webhook is created and expects a 200.
+		w.WriteHeader(http.StatusOK)
+		return
+	case http.MethodPost:
+	default:
+		w.Header().Set("Allow", "HEAD, GET, POST")
+		h.reject(w, r, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		h.reject(w, r, http.StatusBadRequest, fmt.Errorf("reading body: %w", err))
+		return
+	}
+
+	if h.Secret != "" {
+		sig := r.Header.Get(SignatureHeader)
+		if sig == "" {
+			h.reject(w, r, http.StatusUnauthorized, fmt.Errorf("missing %s header", SignatureHeader))
+			return
+		}
+		if !Verify(h.Secret, body, h.callbackURL(r), sig) {
+			h.reject(w, r, http.StatusUnauthorized, fmt.Errorf("invalid signature for callback URL %s", h.callbackURL(r)))
+			return
+		}
+	}
+
+	var ev Event
+	if err := json.Unmarshal(body, &ev); err != nil {
+		h.reject(w, r, http.StatusBadRequest, fmt.Errorf("decoding payload: %w", err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if h.OnEvent != nil {
+		h.OnEvent(ev, body)
+	}
+}
+
+// callbackURL returns the URL Trello signed the request against.
+func (h *Handler) callbackURL(r *http.Request) string {
+	if h.CallbackURL != "" {
+		return h.CallbackURL
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if p := r.Header.Get("X-Forwarded-Proto"); p != "" {
+		scheme = p
+	}
+	host := r.Host
+	if fh := r.Header.Get("X-Forwarded-Host"); fh != "" {
+		host = fh
+	}
+	return scheme + "://" + host + r.URL.RequestURI()
+}
+
+func (h *Handler) reject(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if h.OnError != nil {
+		h.OnError(r, err)
+	}
+	http.Error(w, err.Error(), status)
+}
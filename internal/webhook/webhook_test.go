@@ -0,0 +1,176 @@
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const (
+	testSecret      = "app-secret"
+	testCallbackURL = "https://hooks.example.com/trello"
+	testBody        = `{"action":{"id":"a1","type":"createCard"},"model":{"id":"b1"}}`
+	// testSignature is base64(HMAC-SHA1(testSecret, testBody + testCallbackURL)),
+	// computed with openssl.
+	testSignature = "B5el7us4TMaWOUMH5tZn9+FLSXU="
+)
+
+func TestSign(t *testing.T) {
+	if got := Sign(testSecret, []byte(testBody), testCallbackURL); got != testSignature {
+		t.Errorf("Sign() = %q, want %q", got, testSignature)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		secret      string
+		body        string
+		callbackURL string
+		signature   string
+		want        bool
+	}{
+		{"valid", testSecret, testBody, testCallbackURL, testSignature, true},
+		{"wrong secret", "other-secret", testBody, testCallbackURL, testSignature, false},
+		{"wrong callback URL", testSecret, testBody, "https://hooks.example.com/other", testSignature, false},
+		{"tampered body", testSecret, strings.Replace(testBody, "a1", "a2", 1), testCallbackURL, testSignature, false},
+		{"empty signature", testSecret, testBody, testCallbackURL, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Verify(tt.secret, []byte(tt.body), tt.callbackURL, tt.signature); got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		body        string
+		signature   string
+		secret      string
+		callbackURL string
+		header      map[string]string
+		wantStatus  int
+		wantEvent   bool
+	}{
+		{
+			name:       "HEAD probe",
+			method:     http.MethodHead,
+			target:     testCallbackURL,
+			secret:     testSecret,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:        "signed delivery",
+			method:      http.MethodPost,
+			target:      "http://localhost:8080/trello",
+			body:        testBody,
+			signature:   testSignature,
+			secret:      testSecret,
+			callbackURL: testCallbackURL,
+			wantStatus:  http.StatusOK,
+			wantEvent:   true,
+		},
+		{
+			name:       "callback URL from forwarded headers",
+			method:     http.MethodPost,
+			target:     "http://localhost:8080/trello",
+			body:       testBody,
+			signature:  testSignature,
+			secret:     testSecret,
+			header:     map[string]string{"X-Forwarded-Proto": "https", "X-Forwarded-Host": "hooks.example.com"},
+			wantStatus: http.StatusOK,
+			wantEvent:  true,
+		},
+		{
+			name:        "wrong secret",
+			method:      http.MethodPost,
+			target:      testCallbackURL,
+			body:        testBody,
+			signature:   testSignature,
+			secret:      "other-secret",
+			callbackURL: testCallbackURL,
+			wantStatus:  http.StatusUnauthorized,
+		},
+		{
+			name:        "wrong callback URL",
+			method:      http.MethodPost,
+			target:      testCallbackURL,
+			body:        testBody,
+			signature:   testSignature,
+			secret:      testSecret,
+			callbackURL: "https://hooks.example.com/other",
+			wantStatus:  http.StatusUnauthorized,
+		},
+		{
+			name:       "missing signature",
+			method:     http.MethodPost,
+			target:     testCallbackURL,
+			body:       testBody,
+			secret:     testSecret,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "no secret skips the check",
+			method:     http.MethodPost,
+			target:     testCallbackURL,
+			body:       testBody,
+			wantStatus: http.StatusOK,
+			wantEvent:  true,
+		},
+		{
+			name:       "bad payload",
+			method:     http.MethodPost,
+			target:     testCallbackURL,
+			body:       "not json",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "other method",
+			method:     http.MethodPut,
+			target:     testCallbackURL,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var events []Event
+			var rejected []error
+			h := &Handler{
+				Secret:      tt.secret,
+				CallbackURL: tt.callbackURL,
+				OnEvent:     func(ev Event, raw []byte) { events = append(events, ev) },
+				OnError:     func(r *http.Request, err error) { rejected = append(rejected, err) },
+			}
+
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.signature != "" {
+				req.Header.Set(SignatureHeader, tt.signature)
+			}
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := len(events) == 1; got != tt.wantEvent || len(events) > 1 {
+				t.Fatalf("events = %+v, want event: %v", events, tt.wantEvent)
+			}
+			if tt.wantEvent && (events[0].Action.ID != "a1" || events[0].Action.Type != "createCard") {
+				t.Errorf("action = %+v, want a1 createCard", events[0].Action)
+			}
+			if wantRejected := tt.wantStatus != http.StatusOK; (len(rejected) > 0) != wantRejected {
+				t.Errorf("OnError calls = %v, want rejected: %v", rejected, wantRejected)
+			}
+		})
+	}
+}